@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/internal/domain/services"
+)
+
+// importBooksCommand is the subcommand importing a catalog file instead of starting the server
+const importBooksCommand = "import-books"
+
+// runImportBooks imports the catalog file named by the arguments, or stdin for "-",
+// and writes the report as JSON to out
+func runImportBooks(ctx context.Context, args []string, importer services.BookImporter, out io.Writer) error {
+	flags := flag.NewFlagSet(importBooksCommand, flag.ContinueOnError)
+	format := flags.String("format", "", "catalog format: csv or ndjson, detected from the file extension by default")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s [-format csv|ndjson] <file|->\n", importBooksCommand)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected one catalog file")
+	}
+
+	path := flags.Arg(0)
+	if *format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			*format = models.ImportFormatCSV
+		case ".ndjson", ".jsonl":
+			*format = models.ImportFormatNDJSON
+		default:
+			return fmt.Errorf("unknown catalog format of %q, set -format to csv or ndjson", path)
+		}
+	}
+
+	data := io.Reader(os.Stdin)
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		data = file
+	}
+
+	report, err := importer.Import(ctx, *format, data)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+
+	if report.Failed > 0 {
+		return fmt.Errorf("%d of %d rows failed", report.Failed, report.Total)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -9,13 +10,27 @@ import (
 	"time"
 
 	"github.com/bookshop/api/config"
+	"github.com/bookshop/api/internal/app/auth"
+	"github.com/bookshop/api/internal/app/book"
 	"github.com/bookshop/api/internal/app/cart"
+	"github.com/bookshop/api/internal/app/category"
 	"github.com/bookshop/api/internal/app/checkout"
+	"github.com/bookshop/api/internal/app/order"
+	"github.com/bookshop/api/internal/app/payment"
+	"github.com/bookshop/api/internal/app/pricing"
+	"github.com/bookshop/api/internal/app/rbac"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/internal/pkg/events"
+	"github.com/bookshop/api/internal/pkg/exchange"
+	"github.com/bookshop/api/internal/pkg/external"
+	"github.com/bookshop/api/internal/pkg/payments"
+	blobstorage "github.com/bookshop/api/internal/pkg/storage"
 	"github.com/bookshop/api/internal/repository/postgres"
 	"github.com/bookshop/api/internal/repository/redis"
 	"github.com/bookshop/api/internal/server"
 	"github.com/bookshop/api/internal/service"
 	"github.com/bookshop/api/pkg/logger"
+	"github.com/bookshop/api/pkg/money"
 )
 
 func main() {
@@ -52,9 +67,28 @@ func main() {
 	// Initialize repositories
 	bookRepo := postgres.NewBookRepository(db)
 	categoryRepo := postgres.NewCategoryRepository(db)
+	authorRepo := postgres.NewAuthorRepository(db)
 	orderRepo := postgres.NewOrderRepository(db)
 	userRepo := postgres.NewUserRepository(db)
 	cartRepo := redis.NewCartRepository(redisClient)
+	roleRepo := postgres.NewRoleRepository(db)
+	bookPriceRepo := postgres.NewBookPriceRepository(db)
+	paymentRepo := postgres.NewPaymentRepository(db)
+	webhookEventRepo := postgres.NewWebhookEventRepository(db)
+	refreshTokenRepo := redis.NewRefreshTokenRepository(redisClient)
+	tokenRevocationRepo := redis.NewTokenRevocationRepository(redisClient, cfg.JWT.RefreshTokenTTL)
+
+	// Import a catalog file instead of serving, e.g. "api import-books catalog.csv"
+	if len(os.Args) > 1 && os.Args[1] == importBooksCommand {
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		importer := book.NewImporter(bookRepo, categoryRepo, authorRepo, txManager)
+		if err := runImportBooks(ctx, os.Args[2:], importer, os.Stdout); err != nil {
+			l.Fatal("Catalog import error", err)
+		}
+		return
+	}
 
 	// Log wrapper for modules
 	log := logger.Logger(*l)
@@ -67,14 +101,112 @@ func main() {
 		log,
 	)
 
+	// Initialize token revocation service with in-process cache
+	tokenRevocations := service.NewTokenRevocationService(
+		tokenRevocationRepo,
+		cfg.JWT.RevocationCacheTTL,
+		log,
+	)
+
+	// Initialize auth module
+	authModule := auth.NewModule(
+		userRepo,
+		refreshTokenRepo,
+		tokenRevocations,
+		cfg.JWT,
+		log,
+	)
+
+	// Initialize RBAC module
+	rbacModule := rbac.NewModule(
+		roleRepo,
+		userRepo,
+		tokenRevocations,
+		log,
+	)
+
+	// Initialize exchange rate provider
+	var rateProvider services.ExchangeRateProvider
+	switch cfg.Currency.RateProvider {
+	case "http":
+		ratesClientConfig := external.DefaultConfig()
+		ratesClientConfig.BaseURL = cfg.Currency.RatesAPIURL
+		ratesClient := external.NewAPIClient(ratesClientConfig, log)
+		defer ratesClient.Close()
+
+		rateProvider = exchange.NewHTTPProvider(ratesClient, money.DefaultCurrency, cfg.Currency.RatesCacheTTL, log)
+	default:
+		rateProvider, err = exchange.NewFileProvider(cfg.Currency.RatesFile)
+		if err != nil {
+			l.Fatal("Exchange rates loading error", err)
+		}
+	}
+
+	// Initialize blob storage for uploaded files
+	var storage services.BlobStorage
+	switch cfg.Storage.Provider {
+	case "local":
+		storage, err = blobstorage.NewLocalStorage(cfg.Storage.LocalDir, cfg.Storage.PublicURL)
+		if err != nil {
+			l.Fatal("Storage initialization error", err)
+		}
+	default:
+		l.Fatal("Storage initialization error", fmt.Errorf("unknown storage provider %q", cfg.Storage.Provider))
+	}
+
+	// Initialize pricing module
+	pricingModule := pricing.NewModule(
+		bookPriceRepo,
+		rateProvider,
+		cfg.Currency.Supported,
+		log,
+	)
+
+	// Initialize event bus, subsystems subscribe to domain events on it
+	eventBus := events.NewBus(log)
+
 	// Initialize checkout module
 	checkoutModule := checkout.NewModule(
 		orderRepo,
 		cartRepo,
 		bookRepo,
 		txManager,
+		pricingModule.Service,
 		log,
 		profileCacheService,
+		eventBus,
+	)
+
+	// Initialize payment gateway
+	var gateway services.PaymentGateway
+	switch cfg.Payment.Provider {
+	case payments.FakeProviderName:
+		gateway, err = payments.NewFakeGateway(cfg.Payment.FakeOutcome, cfg.Payment.FakeDelay, cfg.Payment.WebhookSecret)
+		if err != nil {
+			l.Fatal("Payment gateway initialization error", err)
+		}
+	default:
+		l.Fatal("Payment gateway initialization error", fmt.Errorf("unknown payment provider %q", cfg.Payment.Provider))
+	}
+
+	// Initialize payment module
+	paymentModule := payment.NewModule(
+		orderRepo,
+		paymentRepo,
+		webhookEventRepo,
+		gateway,
+		checkoutModule.Service,
+		log,
+	)
+
+	// Initialize order management module
+	orderModule := order.NewModule(
+		orderRepo,
+		userRepo,
+		checkoutModule.Service,
+		paymentModule.Service,
+		pricingModule.Service,
+		log,
 	)
 
 	// Initialize cart module
@@ -82,6 +214,13 @@ func main() {
 		cartRepo,
 		bookRepo,
 		txManager,
+		pricingModule.Service,
+		log,
+	)
+
+	// Initialize category service
+	categoryService := category.NewService(
+		categoryRepo,
 		log,
 	)
 
@@ -89,10 +228,19 @@ func main() {
 	srv, err := server.NewServer(
 		&cfg,
 		l,
+		authModule.Service,
+		tokenRevocations,
+		rbacModule,
+		pricingModule,
+		orderModule,
+		paymentModule,
+		storage,
 		checkoutModule.Service,
 		cartModule.Service,
+		categoryService,
 		bookRepo,
 		categoryRepo,
+		authorRepo,
 		txManager,
 	)
 	if err != nil {
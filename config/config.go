@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -18,6 +19,10 @@ type Config struct {
 	Redis     RedisConfig
 	JWT       JWTConfig
 	RateLimit RateLimiterConfig
+	Currency  CurrencyConfig
+	Catalog   CatalogConfig
+	Storage   StorageConfig
+	Payment   PaymentConfig
 }
 
 // AppConfig contains general application settings
@@ -59,10 +64,11 @@ type RedisConfig struct {
 
 // JWTConfig contains JWT token settings
 type JWTConfig struct {
-	Secret            string
-	AccessTokenTTL    time.Duration
-	RefreshTokenTTL   time.Duration
-	CartExpirationTTL time.Duration
+	Secret             string
+	AccessTokenTTL     time.Duration
+	RefreshTokenTTL    time.Duration
+	CartExpirationTTL  time.Duration
+	RevocationCacheTTL time.Duration // How long revocation lookups are cached in process
 }
 
 // RateLimiterConfig contains rate limiter settings
@@ -74,20 +80,63 @@ type RateLimiterConfig struct {
 	Endpoints        map[string]int // Custom rate limits for specific endpoints
 }
 
+// CurrencyConfig contains multi-currency pricing settings.
+// Catalog prices are in money.DefaultCurrency
+type CurrencyConfig struct {
+	Supported     []string      // Currencies the shop sells in
+	RateProvider  string        // Exchange rate provider: "file" or "http"
+	RatesFile     string        // Path to the rates file for the file provider
+	RatesAPIURL   string        // Base URL of the rates API for the http provider
+	RatesCacheTTL time.Duration // How long rates fetched over HTTP are cached
+}
+
+// CatalogConfig contains catalog browsing settings
+type CatalogConfig struct {
+	FacetCacheSize int           // Maximum number of filters whose facets are cached
+	FacetCacheTTL  time.Duration // How long facet counts are cached
+	CoverMaxSize   int64         // Maximum size of an uploaded cover image in bytes
+	StorefrontURL  string        // Base URL of the shop, product feed items link to its /books/{id} pages
+}
+
+// StorageConfig contains blob storage settings for uploaded files
+type StorageConfig struct {
+	Provider  string // Storage provider: "local"
+	LocalDir  string // Directory files are stored in by the local provider
+	PublicURL string // URL stored files are served at, the API serves them itself if it is a path
+}
+
+// PaymentConfig contains payment provider settings
+type PaymentConfig struct {
+	Provider      string        // Payment provider: "fake", only allowed in development
+	WebhookSecret string        // Secret provider webhooks are signed with, required
+	FakeOutcome   string        // Outcome of authorizations by the fake provider: "success", "decline" or "error"
+	FakeDelay     time.Duration // How long every call to the fake provider takes
+}
+
 // LoadConfig loads configuration from environment variables
 // For local development, it will try to load .env file first
 func LoadConfig() (Config, error) {
 	// Load .env file if it exists (for local development)
 	_ = godotenv.Load()
 
-	return Config{
+	cfg := Config{
 		App:       loadAppConfig(),
 		HTTP:      loadHTTPConfig(),
 		Database:  loadDatabaseConfig(),
 		Redis:     loadRedisConfig(),
 		JWT:       loadJWTConfig(),
 		RateLimit: loadRateLimiterConfig(),
-	}, nil
+		Currency:  loadCurrencyConfig(),
+		Catalog:   loadCatalogConfig(),
+		Storage:   loadStorageConfig(),
+		Payment:   loadPaymentConfig(),
+	}
+
+	if err := cfg.Payment.validate(cfg.App.Environment); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
 }
 
 func loadAppConfig() AppConfig {
@@ -133,10 +182,11 @@ func loadRedisConfig() RedisConfig {
 
 func loadJWTConfig() JWTConfig {
 	return JWTConfig{
-		Secret:            getEnv("JWT_SECRET", "app-secret-key-change-in-production"),
-		AccessTokenTTL:    time.Duration(getEnvAsInt("JWT_ACCESS_TOKEN_TTL_MINUTES", 15)) * time.Minute,
-		RefreshTokenTTL:   time.Duration(getEnvAsInt("JWT_REFRESH_TOKEN_TTL_DAYS", 7)) * 24 * time.Hour,
-		CartExpirationTTL: time.Duration(getEnvAsInt("JWT_CART_EXPIRATION_TTL_HOURS", 24)) * time.Hour,
+		Secret:             getEnv("JWT_SECRET", "app-secret-key-change-in-production"),
+		AccessTokenTTL:     time.Duration(getEnvAsInt("JWT_ACCESS_TOKEN_TTL_MINUTES", 15)) * time.Minute,
+		RefreshTokenTTL:    time.Duration(getEnvAsInt("JWT_REFRESH_TOKEN_TTL_DAYS", 7)) * 24 * time.Hour,
+		CartExpirationTTL:  time.Duration(getEnvAsInt("JWT_CART_EXPIRATION_TTL_HOURS", 24)) * time.Hour,
+		RevocationCacheTTL: time.Duration(getEnvAsInt("JWT_REVOCATION_CACHE_TTL_SECONDS", 5)) * time.Second,
 	}
 }
 
@@ -166,6 +216,66 @@ func loadRateLimiterConfig() RateLimiterConfig {
 	}
 }
 
+func loadCurrencyConfig() CurrencyConfig {
+	var supported []string
+	for _, currency := range strings.Split(getEnv("CURRENCY_SUPPORTED", "USD,EUR,GBP"), ",") {
+		if currency = strings.ToUpper(strings.TrimSpace(currency)); currency != "" {
+			supported = append(supported, currency)
+		}
+	}
+
+	return CurrencyConfig{
+		Supported:     supported,
+		RateProvider:  getEnv("EXCHANGE_RATE_PROVIDER", "file"),
+		RatesFile:     getEnv("EXCHANGE_RATES_FILE", "config/exchange_rates.json"),
+		RatesAPIURL:   getEnv("EXCHANGE_RATES_API_URL", "https://api.frankfurter.app"),
+		RatesCacheTTL: time.Duration(getEnvAsInt("EXCHANGE_RATES_CACHE_TTL_MINUTES", 60)) * time.Minute,
+	}
+}
+
+func loadCatalogConfig() CatalogConfig {
+	return CatalogConfig{
+		FacetCacheSize: getEnvAsInt("CATALOG_FACET_CACHE_SIZE", 1000),
+		FacetCacheTTL:  time.Duration(getEnvAsInt("CATALOG_FACET_CACHE_TTL_SECONDS", 60)) * time.Second,
+		CoverMaxSize:   int64(getEnvAsInt("CATALOG_COVER_MAX_SIZE_KB", 5*1024)) * 1024,
+		StorefrontURL:  getEnv("CATALOG_STOREFRONT_URL", "http://localhost:3000"),
+	}
+}
+
+func loadStorageConfig() StorageConfig {
+	return StorageConfig{
+		Provider:  getEnv("STORAGE_PROVIDER", "local"),
+		LocalDir:  getEnv("STORAGE_LOCAL_DIR", "uploads"),
+		PublicURL: getEnv("STORAGE_PUBLIC_URL", "/media"),
+	}
+}
+
+func loadPaymentConfig() PaymentConfig {
+	return PaymentConfig{
+		Provider:      getEnv("PAYMENT_PROVIDER", ""),
+		WebhookSecret: getEnv("PAYMENT_WEBHOOK_SECRET", ""),
+		FakeOutcome:   getEnv("PAYMENT_FAKE_OUTCOME", "success"),
+		FakeDelay:     time.Duration(getEnvAsInt("PAYMENT_FAKE_DELAY_MS", 0)) * time.Millisecond,
+	}
+}
+
+// placeholderWebhookSecret is the webhook secret of examples, anyone can sign webhooks with it
+const placeholderWebhookSecret = "webhook-secret-change-in-production"
+
+// validate checks that payments are configured explicitly.
+// The fake provider charges nobody, so it only runs in development
+func (c PaymentConfig) validate(environment string) error {
+	switch {
+	case c.Provider == "":
+		return errors.New("PAYMENT_PROVIDER is required")
+	case c.Provider == "fake" && environment != "development":
+		return fmt.Errorf("payment provider %q is only allowed when APP_ENV is development", c.Provider)
+	case c.WebhookSecret == "" || c.WebhookSecret == placeholderWebhookSecret:
+		return errors.New("PAYMENT_WEBHOOK_SECRET is required and must not be the placeholder")
+	}
+	return nil
+}
+
 // Helper functions to get environment variables with defaults
 func getEnv(key, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
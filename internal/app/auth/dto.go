@@ -1,11 +1,26 @@
 package auth
 
-import "time"
+import (
+	"time"
+
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/internal/domain/services"
+)
 
 // RegisterRequest represents a user registration request
 type RegisterRequest struct {
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=6"`
+	Email           string `json:"email" validate:"required,email"`
+	Password        string `json:"password" validate:"required,min=6"`
+	ConfirmPassword string `json:"confirm_password" validate:"required,eqfield=Password"`
+}
+
+// ToModel converts RegisterRequest to UserRegistration model
+func (r *RegisterRequest) ToModel() models.UserRegistration {
+	return models.UserRegistration{
+		Email:           r.Email,
+		Password:        r.Password,
+		ConfirmPassword: r.ConfirmPassword,
+	}
 }
 
 // LoginRequest represents a user authentication request
@@ -14,6 +29,14 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
+// ToModel converts LoginRequest to UserCredentials model
+func (r *LoginRequest) ToModel() models.UserCredentials {
+	return models.UserCredentials{
+		Email:    r.Email,
+		Password: r.Password,
+	}
+}
+
 // RefreshTokenRequest represents a token refresh request
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" validate:"required"`
@@ -23,7 +46,7 @@ type RefreshTokenRequest struct {
 type UserResponse struct {
 	ID        int       `json:"id"`
 	Email     string    `json:"email"`
-	IsAdmin   bool      `json:"is_admin"`
+	Roles     []string  `json:"roles"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -34,3 +57,23 @@ type TokenResponse struct {
 	RefreshToken string    `json:"refresh_token"`
 	ExpiresAt    time.Time `json:"expires_at"`
 }
+
+// fromUserModel converts User model to UserResponse
+func fromUserModel(user *models.User) *UserResponse {
+	return &UserResponse{
+		ID:        user.ID,
+		Email:     user.Email,
+		Roles:     user.Roles,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
+
+// fromTokenPair converts a token pair to TokenResponse
+func fromTokenPair(pair *services.TokenPair) *TokenResponse {
+	return &TokenResponse{
+		AccessToken:  pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		ExpiresAt:    pair.AccessTokenExpiresAt,
+	}
+}
@@ -0,0 +1,193 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/labstack/echo/v4"
+)
+
+// Handler handles HTTP requests related to authentication
+type Handler struct {
+	authService services.AuthService
+}
+
+// NewHandler creates a new instance of the authentication handler
+func NewHandler(authService services.AuthService) *Handler {
+	return &Handler{
+		authService: authService,
+	}
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// errorResponse creates a consistent error response with just an error message
+func errorResponse(message string) *ErrorResponse {
+	return &ErrorResponse{
+		Error: message,
+	}
+}
+
+// handleError maps domain errors to appropriate HTTP responses
+func handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domainerrors.ErrInvalidCredentials),
+		errors.Is(err, domainerrors.ErrInvalidToken),
+		errors.Is(err, domainerrors.ErrTokenExpired),
+		errors.Is(err, domainerrors.ErrTokenReused):
+		return c.JSON(http.StatusUnauthorized, errorResponse(err.Error()))
+	case errors.Is(err, domainerrors.ErrUserAlreadyExists):
+		return c.JSON(http.StatusConflict, errorResponse(err.Error()))
+	case errors.Is(err, domainerrors.ErrInvalidData):
+		return c.JSON(http.StatusBadRequest, errorResponse(err.Error()))
+	default:
+		return c.JSON(http.StatusInternalServerError, errorResponse("internal server error"))
+	}
+}
+
+// RegisterRoutes registers routes for authentication request handling
+func (h *Handler) RegisterRoutes(router *echo.Group) {
+	auth := router.Group("/auth")
+	auth.POST("/register", h.register)
+	auth.POST("/login", h.login)
+	auth.POST("/refresh", h.refresh)
+	auth.POST("/logout", h.logout)
+}
+
+// register handles user registration request
+// @Summary Register a new user
+// @Description Creates a new user account
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param user body RegisterRequest true "User registration data"
+// @Success 201 {object} UserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/register [post]
+func (h *Handler) register(c echo.Context) error {
+	var req RegisterRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid request format"))
+	}
+
+	// Request validation
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse(err.Error()))
+	}
+
+	// Create user
+	user, err := h.authService.Register(c.Request().Context(), req.ToModel())
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, fromUserModel(user))
+}
+
+// login handles user login request
+// @Summary Log in a user
+// @Description Authenticates a user and returns an access/refresh token pair
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body LoginRequest true "Login credentials"
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/login [post]
+func (h *Handler) login(c echo.Context) error {
+	var req LoginRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid request format"))
+	}
+
+	// Request validation
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse(err.Error()))
+	}
+
+	// Authenticate user
+	pair, err := h.authService.Login(c.Request().Context(), req.ToModel())
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, fromTokenPair(pair))
+}
+
+// refresh handles token refresh request
+// @Summary Refresh tokens
+// @Description Exchanges a refresh token for a new token pair; the presented refresh token becomes invalid
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token body RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/refresh [post]
+func (h *Handler) refresh(c echo.Context) error {
+	var req RefreshTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid request format"))
+	}
+
+	// Request validation
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse(err.Error()))
+	}
+
+	// Rotate tokens
+	pair, err := h.authService.RefreshToken(c.Request().Context(), req.RefreshToken)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, fromTokenPair(pair))
+}
+
+// logout handles logout request
+// @Summary Log out
+// @Description Revokes the refresh token and every token rotated from the same login.
+// @Description If an access token is sent in the Authorization header it is revoked as well
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token body RefreshTokenRequest true "Refresh token"
+// @Param Authorization header string false "Bearer access token"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/logout [post]
+func (h *Handler) logout(c echo.Context) error {
+	var req RefreshTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid request format"))
+	}
+
+	// Request validation
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse(err.Error()))
+	}
+
+	// Access token is optional for logout
+	accessToken := strings.TrimPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+
+	// Revoke session
+	if err := h.authService.Logout(c.Request().Context(), req.RefreshToken, accessToken); err != nil {
+		return handleError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -11,7 +11,7 @@ type User struct {
 	ID           int
 	Email        string
 	PasswordHash string
-	IsAdmin      bool
+	Roles        []string
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
@@ -35,7 +35,7 @@ func (u *User) ToDomain() *domainmodels.User {
 		ID:           u.ID,
 		Email:        u.Email,
 		PasswordHash: u.PasswordHash,
-		IsAdmin:      u.IsAdmin,
+		Roles:        u.Roles,
 		CreatedAt:    u.CreatedAt,
 		UpdatedAt:    u.UpdatedAt,
 	}
@@ -47,7 +47,7 @@ func FromDomain(user *domainmodels.User) *User {
 		ID:           user.ID,
 		Email:        user.Email,
 		PasswordHash: user.PasswordHash,
-		IsAdmin:      user.IsAdmin,
+		Roles:        user.Roles,
 		CreatedAt:    user.CreatedAt,
 		UpdatedAt:    user.UpdatedAt,
 	}
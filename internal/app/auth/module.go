@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"github.com/bookshop/api/config"
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+// Module represents an authentication module
+type Module struct {
+	Handler      *Handler
+	Service      services.AuthService
+	TokenManager services.TokenManager
+}
+
+// NewModule creates a new instance of the authentication module
+func NewModule(
+	userRepo repositories.UserRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	revocations services.TokenRevocationService,
+	jwtConfig config.JWTConfig,
+	logger logger.Logger,
+) *Module {
+	// Create token manager
+	tokenMgr := NewTokenManager(jwtConfig, refreshTokenRepo)
+
+	// Create service
+	service := NewService(userRepo, tokenMgr, revocations, logger)
+
+	// Create handler
+	handler := NewHandler(service)
+
+	return &Module{
+		Handler:      handler,
+		Service:      service,
+		TokenManager: tokenMgr,
+	}
+}
+
+// RegisterRoutes registers routes for authentication request handling
+func (m *Module) RegisterRoutes(router *echo.Group) {
+	m.Handler.RegisterRoutes(router)
+}
@@ -15,48 +15,30 @@ import (
 )
 
 const (
-	// AccessTokenTTL lifetime of the access token
-	AccessTokenTTL = 15 * time.Minute
-	// RefreshTokenTTL lifetime of the refresh token
-	RefreshTokenTTL = 24 * 7 * time.Hour
 	// DefaultCost cost of password hashing
 	DefaultCost = 10
 )
 
 // Service implements services.AuthService interface
 type Service struct {
-	userRepo repositories.UserRepository
-	tokenMgr services.TokenManager
-	logger   logger.Logger
-}
-
-// TokenManager defines methods for working with tokens
-type TokenManager interface {
-	// CreateToken creates a new token
-	CreateToken(userID int, isAdmin bool, ttl time.Duration) (string, error)
-	// ValidateToken validates the token and returns user ID
-	ValidateToken(token string) (int, error)
-	// ParseToken parses the token and returns its information
-	ParseToken(token string) (*TokenClaims, error)
-}
-
-// TokenClaims represents token data
-type TokenClaims struct {
-	UserID  int
-	IsAdmin bool
-	Exp     time.Time
+	userRepo    repositories.UserRepository
+	tokenMgr    services.TokenManager
+	revocations services.TokenRevocationService
+	logger      logger.Logger
 }
 
 // NewService creates a new instance of the authentication service
 func NewService(
 	userRepo repositories.UserRepository,
 	tokenMgr services.TokenManager,
+	revocations services.TokenRevocationService,
 	logger logger.Logger,
 ) services.AuthService {
 	return &Service{
-		userRepo: userRepo,
-		tokenMgr: tokenMgr,
-		logger:   logger,
+		userRepo:    userRepo,
+		tokenMgr:    tokenMgr,
+		revocations: revocations,
+		logger:      logger,
 	}
 }
 
@@ -64,7 +46,7 @@ func NewService(
 func (s *Service) Register(ctx context.Context, input models.UserRegistration) (*models.User, error) {
 	// Check if passwords match
 	if input.Password != input.ConfirmPassword {
-		return nil, fmt.Errorf("passwords do not match")
+		return nil, fmt.Errorf("%w: passwords do not match", domainerrors.ErrInvalidData)
 	}
 
 	// Hash the password
@@ -92,76 +74,108 @@ func (s *Service) Register(ctx context.Context, input models.UserRegistration) (
 	return user, nil
 }
 
-// Login authenticates a user and returns tokens
-func (s *Service) Login(ctx context.Context, input models.UserCredentials) (string, string, error) {
+// Login authenticates a user and returns a token pair
+func (s *Service) Login(ctx context.Context, input models.UserCredentials) (*services.TokenPair, error) {
 	// Get user by email
 	user, err := s.userRepo.GetByEmail(ctx, input.Email)
 	if err != nil {
 		if errors.Is(err, domainerrors.ErrUserNotFound) {
-			return "", "", domainerrors.ErrInvalidCredentials
+			return nil, domainerrors.ErrInvalidCredentials
 		}
-		return "", "", fmt.Errorf("error getting user: %w", err)
+		return nil, fmt.Errorf("error getting user: %w", err)
 	}
 
 	// Check password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(input.Password)); err != nil {
-		return "", "", domainerrors.ErrInvalidCredentials
-	}
-
-	// Create access token
-	accessToken, err := s.tokenMgr.CreateToken(user.ID, user.IsAdmin, AccessTokenTTL)
-	if err != nil {
-		return "", "", fmt.Errorf("error creating access token: %w", err)
+		return nil, domainerrors.ErrInvalidCredentials
 	}
 
-	// Create refresh token
-	refreshToken, err := s.tokenMgr.CreateToken(user.ID, user.IsAdmin, RefreshTokenTTL)
+	// Create tokens
+	pair, err := s.tokenMgr.CreateTokenPair(ctx, user.ID, user.Roles)
 	if err != nil {
-		return "", "", fmt.Errorf("error creating refresh token: %w", err)
+		return nil, fmt.Errorf("error creating tokens: %w", err)
 	}
 
-	return accessToken, refreshToken, nil
+	return pair, nil
 }
 
-// ValidateToken validates the token and returns user ID
+// ValidateToken validates the access token and returns user ID
 func (s *Service) ValidateToken(_ context.Context, token string) (int, error) {
-	return s.tokenMgr.ValidateToken(token)
+	claims, err := s.tokenMgr.ParseAccessToken(token)
+	if err != nil {
+		return 0, err
+	}
+	return claims.UserID, nil
 }
 
-// RefreshToken refreshes the access token
-func (s *Service) RefreshToken(ctx context.Context, refreshToken string) (string, string, error) {
+// RefreshToken rotates the refresh token and returns a new token pair
+func (s *Service) RefreshToken(ctx context.Context, refreshToken string) (*services.TokenPair, error) {
 	// Verify refresh token
-	claims, err := s.tokenMgr.ParseToken(refreshToken)
+	claims, err := s.tokenMgr.ParseRefreshToken(refreshToken)
 	if err != nil {
-		return "", "", domainerrors.ErrInvalidToken
+		return nil, err
 	}
 
-	// Check token expiration
-	if time.Now().After(claims.Exp) {
-		return "", "", domainerrors.ErrTokenExpired
+	// Reject refresh tokens issued before the user's tokens were revoked
+	revoked, err := s.revocations.IsRevoked(ctx, claims.TokenID, claims.UserID, claims.IssuedAt)
+	if err != nil {
+		return nil, fmt.Errorf("error checking token revocation: %w", err)
+	}
+	if revoked {
+		if err := s.tokenMgr.RevokeRefreshToken(ctx, refreshToken); err != nil {
+			s.logger.Error("Error revoking refresh token family", err)
+		}
+		return nil, domainerrors.ErrInvalidToken
 	}
 
-	// Get user
+	// Get user to embed the current roles in the new tokens
 	user, err := s.userRepo.GetByID(ctx, claims.UserID)
 	if err != nil {
-		if errors.Is(err, repositories.ErrNotFound) {
-			return "", "", domainerrors.ErrInvalidToken
+		if errors.Is(err, domainerrors.ErrUserNotFound) {
+			return nil, domainerrors.ErrInvalidToken
 		}
-		return "", "", fmt.Errorf("error getting user: %w", err)
+		return nil, fmt.Errorf("error getting user: %w", err)
 	}
 
-	// Create new tokens
-	accessToken, err := s.tokenMgr.CreateToken(user.ID, user.IsAdmin, AccessTokenTTL)
+	// Rotate tokens
+	pair, err := s.tokenMgr.RotateRefreshToken(ctx, refreshToken, user.Roles)
 	if err != nil {
-		return "", "", fmt.Errorf("error creating access token: %w", err)
+		if errors.Is(err, domainerrors.ErrTokenReused) {
+			s.logger.Warn("Refresh token reuse detected, token family revoked")
+		}
+		return nil, err
+	}
+
+	return pair, nil
+}
+
+// Logout revokes the refresh token family of the session and the access token, if given
+func (s *Service) Logout(ctx context.Context, refreshToken, accessToken string) error {
+	if err := s.tokenMgr.RevokeRefreshToken(ctx, refreshToken); err != nil {
+		return err
 	}
 
-	newRefreshToken, err := s.tokenMgr.CreateToken(user.ID, user.IsAdmin, RefreshTokenTTL)
+	if accessToken == "" {
+		return nil
+	}
+
+	// The access token stays valid until it expires unless it is denylisted
+	claims, err := s.tokenMgr.ParseAccessToken(accessToken)
 	if err != nil {
-		return "", "", fmt.Errorf("error creating refresh token: %w", err)
+		// An expired or invalid access token can not be used anyway
+		return nil
+	}
+
+	if err := s.revocations.RevokeToken(ctx, claims.TokenID, claims.Exp); err != nil {
+		return fmt.Errorf("error revoking access token: %w", err)
 	}
 
-	return accessToken, newRefreshToken, nil
+	return nil
+}
+
+// RevokeUserTokens revokes all tokens issued to the user up to now
+func (s *Service) RevokeUserTokens(ctx context.Context, userID int) error {
+	return s.revocations.RevokeUserTokens(ctx, userID)
 }
 
 // GetUserByID returns a user by ID
@@ -185,5 +199,5 @@ func (s *Service) IsAdmin(ctx context.Context, userID int) (bool, error) {
 		}
 		return false, fmt.Errorf("error getting user: %w", err)
 	}
-	return user.IsAdmin, nil
+	return user.HasRole(models.RoleAdmin), nil
 }
@@ -0,0 +1,229 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bookshop/api/config"
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/golang-jwt/jwt"
+)
+
+// jwtClaims represents the claims embedded in issued tokens.
+// The user_id, roles and iat_us claim names are the ones read by middleware.AuthMiddleware
+type jwtClaims struct {
+	UserID       int                `json:"user_id"`
+	Roles        []string           `json:"roles"`
+	TokenType    services.TokenType `json:"token_type"`
+	FamilyID     string             `json:"fid,omitempty"`
+	IssuedAtUsec int64              `json:"iat_us"` // Issue time in microseconds, iat only has seconds
+	jwt.StandardClaims
+}
+
+// TokenManager implements services.TokenManager interface using HMAC-signed JWTs
+type TokenManager struct {
+	secret      []byte
+	accessTTL   time.Duration
+	refreshTTL  time.Duration
+	refreshRepo repositories.RefreshTokenRepository
+}
+
+// NewTokenManager creates a new instance of the token manager
+func NewTokenManager(
+	cfg config.JWTConfig,
+	refreshRepo repositories.RefreshTokenRepository,
+) services.TokenManager {
+	return &TokenManager{
+		secret:      []byte(cfg.Secret),
+		accessTTL:   cfg.AccessTokenTTL,
+		refreshTTL:  cfg.RefreshTokenTTL,
+		refreshRepo: refreshRepo,
+	}
+}
+
+// CreateTokenPair issues a new token pair starting a new refresh token family
+func (m *TokenManager) CreateTokenPair(ctx context.Context, userID int, roles []string) (*services.TokenPair, error) {
+	familyID, err := newTokenID()
+	if err != nil {
+		return nil, err
+	}
+
+	pair, refreshID, err := m.issuePair(userID, roles, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.refreshRepo.CreateFamily(ctx, familyID, userID, refreshID, m.refreshTTL); err != nil {
+		return nil, fmt.Errorf("error storing refresh token: %w", err)
+	}
+
+	return pair, nil
+}
+
+// RotateRefreshToken exchanges a refresh token for a new pair within the same family
+func (m *TokenManager) RotateRefreshToken(ctx context.Context, refreshToken string, roles []string) (*services.TokenPair, error) {
+	claims, err := m.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	pair, refreshID, err := m.issuePair(claims.UserID, roles, claims.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.refreshRepo.Rotate(ctx, claims.FamilyID, claims.TokenID, refreshID, m.refreshTTL); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrNotFound):
+			return nil, domainerrors.ErrInvalidToken
+		case errors.Is(err, domainerrors.ErrTokenReused):
+			return nil, domainerrors.ErrTokenReused
+		default:
+			return nil, fmt.Errorf("error rotating refresh token: %w", err)
+		}
+	}
+
+	return pair, nil
+}
+
+// RevokeRefreshToken revokes the family the refresh token belongs to
+func (m *TokenManager) RevokeRefreshToken(ctx context.Context, refreshToken string) error {
+	claims, err := m.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return err
+	}
+
+	if err := m.refreshRepo.RevokeFamily(ctx, claims.FamilyID); err != nil {
+		return fmt.Errorf("error revoking refresh token: %w", err)
+	}
+
+	return nil
+}
+
+// ParseAccessToken validates an access token and returns its information
+func (m *TokenManager) ParseAccessToken(token string) (*services.TokenClaims, error) {
+	return m.parse(token, services.TokenTypeAccess)
+}
+
+// ParseRefreshToken validates a refresh token and returns its information
+func (m *TokenManager) ParseRefreshToken(token string) (*services.TokenClaims, error) {
+	claims, err := m.parse(token, services.TokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.FamilyID == "" {
+		return nil, domainerrors.ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// issuePair signs an access and a refresh token for the user.
+// Returns the pair and the ID of the refresh token
+func (m *TokenManager) issuePair(userID int, roles []string, familyID string) (*services.TokenPair, string, error) {
+	now := time.Now()
+	accessExp := now.Add(m.accessTTL)
+	refreshExp := now.Add(m.refreshTTL)
+
+	accessID, err := newTokenID()
+	if err != nil {
+		return nil, "", err
+	}
+
+	refreshID, err := newTokenID()
+	if err != nil {
+		return nil, "", err
+	}
+
+	accessToken, err := m.sign(jwtClaims{
+		UserID:       userID,
+		Roles:        roles,
+		TokenType:    services.TokenTypeAccess,
+		IssuedAtUsec: now.UnixMicro(),
+		StandardClaims: jwt.StandardClaims{
+			Id:        accessID,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: accessExp.Unix(),
+		},
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("error creating access token: %w", err)
+	}
+
+	refreshToken, err := m.sign(jwtClaims{
+		UserID:       userID,
+		Roles:        roles,
+		TokenType:    services.TokenTypeRefresh,
+		FamilyID:     familyID,
+		IssuedAtUsec: now.UnixMicro(),
+		StandardClaims: jwt.StandardClaims{
+			Id:        refreshID,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: refreshExp.Unix(),
+		},
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("error creating refresh token: %w", err)
+	}
+
+	return &services.TokenPair{
+		AccessToken:           accessToken,
+		RefreshToken:          refreshToken,
+		AccessTokenExpiresAt:  accessExp,
+		RefreshTokenExpiresAt: refreshExp,
+	}, refreshID, nil
+}
+
+// sign creates a signed token string from the claims
+func (m *TokenManager) sign(claims jwtClaims) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+}
+
+// parse validates the token signature, expiration and type
+func (m *TokenManager) parse(token string, expected services.TokenType) (*services.TokenClaims, error) {
+	claims := &jwtClaims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		// Check signing method
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return m.secret, nil
+	})
+	if err != nil {
+		var validationErr *jwt.ValidationError
+		if errors.As(err, &validationErr) && validationErr.Errors&jwt.ValidationErrorExpired != 0 {
+			return nil, domainerrors.ErrTokenExpired
+		}
+		return nil, domainerrors.ErrInvalidToken
+	}
+
+	if !parsed.Valid || claims.TokenType != expected || claims.Id == "" || claims.IssuedAtUsec <= 0 {
+		return nil, domainerrors.ErrInvalidToken
+	}
+
+	return &services.TokenClaims{
+		TokenID:  claims.Id,
+		FamilyID: claims.FamilyID,
+		Type:     claims.TokenType,
+		UserID:   claims.UserID,
+		Roles:    claims.Roles,
+		IssuedAt: time.UnixMicro(claims.IssuedAtUsec),
+		Exp:      time.Unix(claims.ExpiresAt, 0),
+	}, nil
+}
+
+// newTokenID generates a random identifier for tokens and token families
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating token ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,69 @@
+package author
+
+import (
+	"time"
+
+	"github.com/bookshop/api/internal/domain/models"
+)
+
+// AuthorListRequest represents a request for getting a list of authors
+type AuthorListRequest struct {
+	Query    string `query:"q"`
+	Page     int    `query:"page"`
+	PageSize int    `query:"page_size"`
+}
+
+// ToModel converts AuthorListRequest to AuthorFilter model
+func (r *AuthorListRequest) ToModel() models.AuthorFilter {
+	return models.AuthorFilter{
+		Query:    r.Query,
+		Page:     r.Page,
+		PageSize: r.PageSize,
+	}
+}
+
+// AuthorResponse represents a response with author information
+type AuthorResponse struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	BookCount int       `json:"book_count"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AuthorListResponse represents a response with a list of authors
+type AuthorListResponse struct {
+	Authors    []AuthorResponse `json:"authors"`
+	TotalCount int              `json:"total_count"`
+	Page       int              `json:"page"`
+	PageSize   int              `json:"page_size"`
+	TotalPages int              `json:"total_pages"`
+}
+
+// fromModel converts Author model to AuthorResponse
+func fromModel(author *models.Author) AuthorResponse {
+	return AuthorResponse{
+		ID:        author.ID,
+		Name:      author.Name,
+		BookCount: author.BookCount,
+		CreatedAt: author.CreatedAt,
+		UpdatedAt: author.UpdatedAt,
+	}
+}
+
+// fromModelList converts AuthorListResponse model to AuthorListResponse
+func fromModelList(list *models.AuthorListResponse) *AuthorListResponse {
+	response := &AuthorListResponse{
+		Authors:    make([]AuthorResponse, 0, len(list.Authors)),
+		TotalCount: list.TotalCount,
+		Page:       list.Page,
+		PageSize:   list.PageSize,
+		TotalPages: list.TotalPages,
+	}
+
+	for i := range list.Authors {
+		response.Authors = append(response.Authors, fromModel(&list.Authors[i]))
+	}
+
+	return response
+}
@@ -0,0 +1,107 @@
+package author
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/labstack/echo/v4"
+)
+
+// Handler handles HTTP requests related to authors.
+// The books of an author are served by the book handler
+type Handler struct {
+	authorService services.AuthorService
+}
+
+// NewHandler creates a new instance of the author handler
+func NewHandler(authorService services.AuthorService) *Handler {
+	return &Handler{
+		authorService: authorService,
+	}
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// errorResponse creates a consistent error response with just an error message
+func errorResponse(message string) *ErrorResponse {
+	return &ErrorResponse{
+		Error: message,
+	}
+}
+
+// handleError maps domain errors to appropriate HTTP responses
+func handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domainerrors.ErrAuthorNotFound),
+		errors.Is(err, domainerrors.ErrNotFound):
+		return c.JSON(http.StatusNotFound, errorResponse(err.Error()))
+	case errors.Is(err, domainerrors.ErrInvalidData):
+		return c.JSON(http.StatusBadRequest, errorResponse(err.Error()))
+	default:
+		return c.JSON(http.StatusInternalServerError, errorResponse("internal server error"))
+	}
+}
+
+// RegisterRoutes registers public author routes
+func (h *Handler) RegisterRoutes(router *echo.Group) {
+	authors := router.Group("/authors")
+	authors.GET("", h.listAuthors)
+	authors.GET("/:id", h.getAuthor)
+}
+
+// listAuthors handles request to get a list of authors
+// @Summary Get list of authors
+// @Description Returns authors ordered by name with the number of books they contributed to
+// @Tags authors
+// @Produce json
+// @Param q query string false "Part of the name, case-insensitive"
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size"
+// @Success 200 {object} AuthorListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /authors [get]
+func (h *Handler) listAuthors(c echo.Context) error {
+	var req AuthorListRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid request format"))
+	}
+
+	authors, err := h.authorService.List(c.Request().Context(), req.ToModel())
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, fromModelList(authors))
+}
+
+// getAuthor handles request to get author information
+// @Summary Get an author
+// @Description Returns author information by ID
+// @Tags authors
+// @Produce json
+// @Param id path int true "Author ID"
+// @Success 200 {object} AuthorResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /authors/{id} [get]
+func (h *Handler) getAuthor(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid author ID"))
+	}
+
+	author, err := h.authorService.GetByID(c.Request().Context(), id)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, fromModel(author))
+}
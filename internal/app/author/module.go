@@ -0,0 +1,32 @@
+package author
+
+import (
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/labstack/echo/v4"
+)
+
+// Module represents an author browsing module
+type Module struct {
+	Handler *Handler
+	Service services.AuthorService
+}
+
+// NewModule creates a new instance of the author module
+func NewModule(authorRepo repositories.AuthorRepository) *Module {
+	// Create service
+	service := NewService(authorRepo)
+
+	// Create handler
+	handler := NewHandler(service)
+
+	return &Module{
+		Handler: handler,
+		Service: service,
+	}
+}
+
+// RegisterRoutes registers public author routes
+func (m *Module) RegisterRoutes(router *echo.Group) {
+	m.Handler.RegisterRoutes(router)
+}
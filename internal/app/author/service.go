@@ -0,0 +1,71 @@
+package author
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/bookshop/api/internal/domain/services"
+)
+
+// Pagination defaults of the author list
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// Service implements services.AuthorService interface
+type Service struct {
+	authorRepo repositories.AuthorRepository
+}
+
+// NewService creates a new instance of the author service
+func NewService(authorRepo repositories.AuthorRepository) services.AuthorService {
+	return &Service{
+		authorRepo: authorRepo,
+	}
+}
+
+// GetByID returns an author by ID
+func (s *Service) GetByID(ctx context.Context, id int) (*models.Author, error) {
+	author, err := s.authorRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, domainerrors.ErrAuthorNotFound
+		}
+		return nil, fmt.Errorf("error getting author: %w", err)
+	}
+
+	return author, nil
+}
+
+// List returns a list of authors ordered by name with their book counts
+func (s *Service) List(ctx context.Context, filter models.AuthorFilter) (*models.AuthorListResponse, error) {
+	// Set default values for pagination
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = defaultPageSize
+	} else if filter.PageSize > maxPageSize {
+		filter.PageSize = maxPageSize
+	}
+	filter.Query = strings.TrimSpace(filter.Query)
+
+	authors, total, err := s.authorRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error getting author list: %w", err)
+	}
+
+	return &models.AuthorListResponse{
+		Authors:    authors,
+		TotalCount: total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalPages: (total + filter.PageSize - 1) / filter.PageSize,
+	}, nil
+}
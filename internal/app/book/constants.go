@@ -12,6 +12,26 @@ const (
 	MaxPageSize = 100
 )
 
+// Constants for cover images
+const (
+	// DefaultCoverMaxSize - default maximum size of an uploaded cover in bytes
+	DefaultCoverMaxSize = 5 << 20
+
+	// MaxCoverPixels - maximum number of pixels of an uploaded cover,
+	// larger images are rejected before they are decoded
+	MaxCoverPixels = 25_000_000
+
+	// CoverThumbnailQuality - JPEG quality of cover thumbnails
+	CoverThumbnailQuality = 85
+)
+
+// CoverThumbnailWidths maps the cover thumbnail sizes to their widths in pixels
+var CoverThumbnailWidths = map[string]int{
+	"small":  150,
+	"medium": 300,
+	"large":  600,
+}
+
 // Constants for sorting
 const (
 	// SortByTitle - sort by title
@@ -35,3 +55,15 @@ const (
 	// SortDesc - descending sort order
 	SortDesc = "desc"
 )
+
+// Constants for catalog imports and exports
+const (
+	// ImportChunkSize - number of rows upserted in one transaction
+	ImportChunkSize = 100
+
+	// MaxImportSize - maximum size of a catalog file uploaded for import in bytes
+	MaxImportSize = 50 << 20
+
+	// ExportBufferSize - size of the buffer catalog exports are written through in bytes
+	ExportBufferSize = 64 << 10
+)
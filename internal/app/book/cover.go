@@ -0,0 +1,161 @@
+package book
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // Register the GIF decoder
+	"image/jpeg"
+	_ "image/png" // Register the PNG decoder
+	"io"
+	"net/http"
+	"sort"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/bookshop/api/internal/pkg/imaging"
+)
+
+// coverExtensions maps the sniffed content types of supported cover images to file extensions
+var coverExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// SetCover stores the cover image of a book with its thumbnails, replacing the previous cover.
+// The image type is sniffed from its content, file names and declared types are not trusted.
+// Every upload is stored under a new key, so cached URLs of the previous cover never show the new one
+func (s *Service) SetCover(ctx context.Context, id int, cover io.Reader) (*models.Book, error) {
+	book, err := s.bookRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, domainerrors.ErrBookNotFound
+		}
+		return nil, fmt.Errorf("error getting book: %w", err)
+	}
+
+	// One more byte tells if the image is over the limit
+	data, err := io.ReadAll(io.LimitReader(cover, s.coverMaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading cover image: %w", err)
+	}
+	if int64(len(data)) > s.coverMaxSize {
+		return nil, fmt.Errorf("%w: the limit is %d bytes", domainerrors.ErrCoverTooLarge, s.coverMaxSize)
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := coverExtensions[contentType]
+	if !ok {
+		return nil, domainerrors.ErrUnsupportedImage
+	}
+
+	// Dimensions are checked before decoding so that small files cannot expand into huge images
+	config, err := imageConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	if config.Width*config.Height > MaxCoverPixels {
+		return nil, fmt.Errorf("%w: the limit is %d pixels", domainerrors.ErrCoverTooLarge, MaxCoverPixels)
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrUnsupportedImage, err)
+	}
+
+	token, err := coverToken()
+	if err != nil {
+		return nil, err
+	}
+	key := coverPrefix(id) + token
+
+	coverURL, thumbnails, err := s.storeCover(ctx, key, data, contentType, ext, decoded)
+	if err != nil {
+		_ = s.storage.DeletePrefix(ctx, key+"/") // Best effort, the cover is not referenced yet
+		return nil, err
+	}
+
+	previousKey := book.CoverKey
+	book.CoverURL = coverURL
+	book.CoverKey = key
+	book.CoverThumbnails = thumbnails
+
+	if err := s.bookRepo.UpdateCover(ctx, book); err != nil {
+		_ = s.storage.DeletePrefix(ctx, key+"/") // Best effort, the cover is not referenced
+		return nil, fmt.Errorf("error updating book cover: %w", err)
+	}
+
+	// The previous cover is no longer referenced, a failed cleanup only leaves unused files
+	if previousKey != "" {
+		_ = s.storage.DeletePrefix(ctx, previousKey+"/")
+	}
+
+	return s.completeBook(ctx, book, "")
+}
+
+// storeCover stores the original cover and its JPEG thumbnails under the key
+// and returns the URL of the original and the thumbnail URLs by size
+func (s *Service) storeCover(
+	ctx context.Context,
+	key string,
+	data []byte,
+	contentType, ext string,
+	decoded image.Image,
+) (string, map[string]string, error) {
+	coverURL, err := s.storage.Put(ctx, key+"/original"+ext, bytes.NewReader(data), contentType)
+	if err != nil {
+		return "", nil, fmt.Errorf("error storing cover: %w", err)
+	}
+
+	sizes := make([]string, 0, len(CoverThumbnailWidths))
+	for size := range CoverThumbnailWidths {
+		sizes = append(sizes, size)
+	}
+	sort.Strings(sizes)
+
+	thumbnails := make(map[string]string, len(sizes))
+	for _, size := range sizes {
+		var buf bytes.Buffer
+		thumbnail := imaging.Thumbnail(decoded, CoverThumbnailWidths[size])
+		if err := jpeg.Encode(&buf, thumbnail, &jpeg.Options{Quality: CoverThumbnailQuality}); err != nil {
+			return "", nil, fmt.Errorf("error encoding %s cover thumbnail: %w", size, err)
+		}
+
+		url, err := s.storage.Put(ctx, key+"/"+size+".jpg", &buf, "image/jpeg")
+		if err != nil {
+			return "", nil, fmt.Errorf("error storing %s cover thumbnail: %w", size, err)
+		}
+		thumbnails[size] = url
+	}
+
+	return coverURL, thumbnails, nil
+}
+
+// imageConfig returns the dimensions of an encoded image
+func imageConfig(data []byte) (image.Config, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return image.Config{}, fmt.Errorf("%w: %v", domainerrors.ErrUnsupportedImage, err)
+	}
+	return config, nil
+}
+
+// coverToken returns a random token naming an uploaded cover
+func coverToken() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating cover token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// coverPrefix returns the storage prefix of all covers of a book
+func coverPrefix(bookID int) string {
+	return fmt.Sprintf("covers/%d/", bookID)
+}
@@ -1,19 +1,31 @@
 package book
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
 	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/pkg/money"
 )
 
 // CreateBookRequest represents a book creation request
 type CreateBookRequest struct {
-	Title         string  `json:"title" validate:"required"`
-	Author        string  `json:"author" validate:"required"`
-	YearPublished int     `json:"year_published" validate:"required,gt=0"`
-	Price         float64 `json:"price" validate:"required,gt=0"`
-	Stock         int     `json:"stock" validate:"required,gte=0"`
-	CategoryID    int     `json:"category_id" validate:"required,gt=0"`
+	Title         string          `json:"title" validate:"required"`
+	Author        string          `json:"author" validate:"required_without=Authors"` // Split into authors if they are not given
+	Authors       []AuthorRequest `json:"authors,omitempty" validate:"omitempty,max=50,dive"`
+	YearPublished int             `json:"year_published" validate:"required,gt=0"`
+	Price         money.Money     `json:"price" validate:"required,gt=0"`
+	Stock         int             `json:"stock" validate:"required,gte=0"`
+	CategoryID    int             `json:"category_id" validate:"required,gt=0"`
+	ISBN          string          `json:"isbn,omitempty" validate:"omitempty,isbn"`
+	Publisher     string          `json:"publisher,omitempty" validate:"max=255"`
+	Language      string          `json:"language,omitempty" validate:"omitempty,alpha,min=2,max=3"`
+	Format        string          `json:"format,omitempty" validate:"omitempty,oneof=hardcover paperback ebook audiobook"`
+	PageCount     int             `json:"page_count,omitempty" validate:"gte=0"`
+	Description   string          `json:"description,omitempty"`
+	CoverURL      string          `json:"cover_url,omitempty" validate:"omitempty,url,max=2048"`
 }
 
 // ToModel converts CreateBookRequest to BookCreate model
@@ -21,20 +33,36 @@ func (r *CreateBookRequest) ToModel() models.BookCreate {
 	return models.BookCreate{
 		Title:         r.Title,
 		Author:        r.Author,
+		Authors:       authorInputs(r.Authors),
 		YearPublished: r.YearPublished,
 		Price:         r.Price,
 		Stock:         r.Stock,
 		CategoryID:    r.CategoryID,
+		ISBN:          r.ISBN,
+		Publisher:     r.Publisher,
+		Language:      r.Language,
+		Format:        r.Format,
+		PageCount:     r.PageCount,
+		Description:   r.Description,
+		CoverURL:      r.CoverURL,
 	}
 }
 
 // UpdateBookRequest represents a book update request
 type UpdateBookRequest struct {
-	Title         *string  `json:"title,omitempty"`
-	Author        *string  `json:"author,omitempty"`
-	YearPublished *int     `json:"year_published,omitempty" validate:"omitempty,gt=0"`
-	Price         *float64 `json:"price,omitempty" validate:"omitempty,gt=0"`
-	CategoryID    *int     `json:"category_id,omitempty" validate:"omitempty,gt=0"`
+	Title         *string         `json:"title,omitempty"`
+	Author        *string         `json:"author,omitempty"` // Split into authors if they are not given
+	Authors       []AuthorRequest `json:"authors,omitempty" validate:"omitempty,max=50,dive"`
+	YearPublished *int            `json:"year_published,omitempty" validate:"omitempty,gt=0"`
+	Price         *money.Money    `json:"price,omitempty" validate:"omitempty,gt=0"`
+	CategoryID    *int            `json:"category_id,omitempty" validate:"omitempty,gt=0"`
+	ISBN          *string         `json:"isbn,omitempty" validate:"omitempty,isbn"`
+	Publisher     *string         `json:"publisher,omitempty" validate:"omitempty,max=255"`
+	Language      *string         `json:"language,omitempty" validate:"omitempty,alpha,min=2,max=3"`
+	Format        *string         `json:"format,omitempty" validate:"omitempty,oneof=hardcover paperback ebook audiobook"`
+	PageCount     *int            `json:"page_count,omitempty" validate:"omitempty,gte=0"`
+	Description   *string         `json:"description,omitempty"`
+	CoverURL      *string         `json:"cover_url,omitempty" validate:"omitempty,url,max=2048"`
 }
 
 // ToModel converts UpdateBookRequest to BookUpdate model
@@ -42,24 +70,61 @@ func (r *UpdateBookRequest) ToModel() models.BookUpdate {
 	return models.BookUpdate{
 		Title:         r.Title,
 		Author:        r.Author,
+		Authors:       authorInputs(r.Authors),
 		YearPublished: r.YearPublished,
 		Price:         r.Price,
 		CategoryID:    r.CategoryID,
+		ISBN:          r.ISBN,
+		Publisher:     r.Publisher,
+		Language:      r.Language,
+		Format:        r.Format,
+		PageCount:     r.PageCount,
+		Description:   r.Description,
+		CoverURL:      r.CoverURL,
 	}
 }
 
+// AuthorRequest represents a contributor to a book in a request
+type AuthorRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+	Role string `json:"role,omitempty" validate:"omitempty,oneof=author editor translator illustrator"` // Defaults to author
+}
+
+// authorInputs converts AuthorRequests to BookAuthorInput models
+func authorInputs(authors []AuthorRequest) []models.BookAuthorInput {
+	if authors == nil {
+		return nil
+	}
+
+	inputs := make([]models.BookAuthorInput, len(authors))
+	for i, author := range authors {
+		inputs[i] = models.BookAuthorInput{Name: author.Name, Role: author.Role}
+	}
+	return inputs
+}
+
 // BookResponse represents a response with book information
 type BookResponse struct {
-	ID            int       `json:"id"`
-	Title         string    `json:"title"`
-	Author        string    `json:"author"`
-	YearPublished int       `json:"year_published"`
-	Price         float64   `json:"price"`
-	Stock         int       `json:"stock"`
-	CategoryID    int       `json:"category_id"`
-	Category      *Category `json:"category,omitempty"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID              int               `json:"id"`
+	Title           string            `json:"title"`
+	Author          string            `json:"author"`
+	Authors         []Author          `json:"authors,omitempty"`
+	YearPublished   int               `json:"year_published"`
+	Price           money.Money       `json:"price"`
+	Stock           int               `json:"stock"`
+	CategoryID      int               `json:"category_id"`
+	Category        *Category         `json:"category,omitempty"`
+	ISBN            string            `json:"isbn,omitempty"`
+	Publisher       string            `json:"publisher,omitempty"`
+	Language        string            `json:"language,omitempty"`
+	Format          string            `json:"format,omitempty"`
+	PageCount       int               `json:"page_count,omitempty"`
+	Description     string            `json:"description,omitempty"`
+	CoverURL        string            `json:"cover_url,omitempty"`
+	CoverThumbnails map[string]string `json:"cover_thumbnails,omitempty"` // Thumbnail URLs by size: small, medium and large
+	Highlight       *Highlight        `json:"highlight,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
 }
 
 // Category represents book category information
@@ -68,18 +133,41 @@ type Category struct {
 	Name string `json:"name"`
 }
 
+// Author represents a contributor to a book
+type Author struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// Highlight represents the fields of a book matching the search query,
+// as HTML with matched words wrapped in <mark> tags. The text of the fields is HTML-escaped,
+// so the marks are the only markup
+type Highlight struct {
+	Title  string `json:"title"`
+	Author string `json:"author"`
+}
+
 // fromModel converts Book model to BookResponse
 func fromModel(book *models.Book) *BookResponse {
 	response := &BookResponse{
-		ID:            book.ID,
-		Title:         book.Title,
-		Author:        book.Author,
-		YearPublished: book.YearPublished,
-		Price:         book.Price,
-		Stock:         book.Stock,
-		CategoryID:    book.CategoryID,
-		CreatedAt:     book.CreatedAt,
-		UpdatedAt:     book.UpdatedAt,
+		ID:              book.ID,
+		Title:           book.Title,
+		Author:          book.Author,
+		YearPublished:   book.YearPublished,
+		Price:           book.Price,
+		Stock:           book.Stock,
+		CategoryID:      book.CategoryID,
+		ISBN:            book.ISBN,
+		Publisher:       book.Publisher,
+		Language:        book.Language,
+		Format:          book.Format,
+		PageCount:       book.PageCount,
+		Description:     book.Description,
+		CoverURL:        book.CoverURL,
+		CoverThumbnails: book.CoverThumbnails,
+		CreatedAt:       book.CreatedAt,
+		UpdatedAt:       book.UpdatedAt,
 	}
 
 	if book.Category != nil {
@@ -89,38 +177,214 @@ func fromModel(book *models.Book) *BookResponse {
 		}
 	}
 
+	for _, author := range book.Authors {
+		response.Authors = append(response.Authors, Author{
+			ID:   author.AuthorID,
+			Name: author.Name,
+			Role: author.Role,
+		})
+	}
+
+	if book.Highlight != nil {
+		response.Highlight = &Highlight{
+			Title:  book.Highlight.Title,
+			Author: book.Highlight.Author,
+		}
+	}
+
 	return response
 }
 
-// BookListRequest represents a request for getting a list of books
+// BookListRequest represents a request for getting a list of books.
+// Price bounds are in the request currency unless they name one, e.g. "12.50 EUR".
+// Pages are selected by number, or by the after or before cursor of a previous page
 type BookListRequest struct {
-	CategoryIDs []int    `form:"category_ids"`
-	MinPrice    *float64 `form:"min_price"`
-	MaxPrice    *float64 `form:"max_price"`
-	InStock     *bool    `form:"in_stock"`
-	Page        int      `form:"page,default=1"`
-	PageSize    int      `form:"page_size,default=10"`
+	Query                string   `query:"q" form:"q"`
+	CategoryIDs          []int    `query:"category_ids" form:"category_ids"`
+	IncludeSubcategories bool     `query:"include_subcategories" form:"include_subcategories"`
+	AuthorIDs            []int    `query:"author_ids" form:"author_ids"`
+	MinPrice             string   `query:"min_price" form:"min_price"`
+	MaxPrice             string   `query:"max_price" form:"max_price"`
+	InStock              *bool    `query:"in_stock" form:"in_stock"`
+	ISBN                 string   `query:"isbn" form:"isbn"`
+	Publisher            string   `query:"publisher" form:"publisher"`
+	Languages            []string `query:"languages" form:"languages"`
+	Formats              []string `query:"formats" form:"formats"`
+	MinPages             *int     `query:"min_pages" form:"min_pages"`
+	MaxPages             *int     `query:"max_pages" form:"max_pages"`
+	Sort                 string   `query:"sort" form:"sort"`
+	Page                 int      `query:"page" form:"page,default=1"`
+	PageSize             int      `query:"page_size" form:"page_size,default=10"`
+	After                string   `query:"after" form:"after"`
+	Before               string   `query:"before" form:"before"`
+	IncludeTotal         bool     `query:"include_total" form:"include_total"`
+	Facets               bool     `query:"facets" form:"facets"`
 }
 
-// ToModel converts BookListRequest to BookFilter model
-func (r *BookListRequest) ToModel() models.BookFilter {
+// ToModel converts BookListRequest to BookFilter model priced in the currency
+func (r *BookListRequest) ToModel(currency string) (models.BookFilter, error) {
+	minPrice, err := parsePriceBound(r.MinPrice, currency)
+	if err != nil {
+		return models.BookFilter{}, err
+	}
+
+	maxPrice, err := parsePriceBound(r.MaxPrice, currency)
+	if err != nil {
+		return models.BookFilter{}, err
+	}
+
+	sort, err := parseSort(r.Sort)
+	if err != nil {
+		return models.BookFilter{}, err
+	}
+
+	var isbn string
+	if r.ISBN != "" {
+		var ok bool
+		if isbn, ok = NormalizeISBN(r.ISBN); !ok {
+			return models.BookFilter{}, fmt.Errorf("%w: invalid ISBN %q", domainerrors.ErrInvalidData, r.ISBN)
+		}
+	}
+
+	languages := make([]string, 0, len(r.Languages))
+	for _, language := range r.Languages {
+		languages = append(languages, strings.ToLower(strings.TrimSpace(language)))
+	}
+
+	formats := make([]string, 0, len(r.Formats))
+	for _, format := range r.Formats {
+		format = strings.ToLower(strings.TrimSpace(format))
+		if !bookFormats[format] {
+			return models.BookFilter{}, fmt.Errorf("%w: unknown format %q", domainerrors.ErrInvalidData, format)
+		}
+		formats = append(formats, format)
+	}
+
+	after, err := models.DecodeCursor(r.After)
+	if err != nil {
+		return models.BookFilter{}, err
+	}
+
+	before, err := models.DecodeCursor(r.Before)
+	if err != nil {
+		return models.BookFilter{}, err
+	}
+	if after != nil && before != nil {
+		return models.BookFilter{}, fmt.Errorf("%w: after and before cannot be used together", domainerrors.ErrInvalidCursor)
+	}
+
 	return models.BookFilter{
-		CategoryIDs: r.CategoryIDs,
-		MinPrice:    r.MinPrice,
-		MaxPrice:    r.MaxPrice,
-		InStock:     r.InStock,
-		Page:        r.Page,
-		PageSize:    r.PageSize,
+		Query:                strings.TrimSpace(r.Query),
+		CategoryIDs:          r.CategoryIDs,
+		IncludeSubcategories: r.IncludeSubcategories,
+		AuthorIDs:            r.AuthorIDs,
+		MinPrice:             minPrice,
+		MaxPrice:             maxPrice,
+		InStock:              r.InStock,
+		ISBN:                 isbn,
+		Publisher:            strings.TrimSpace(r.Publisher),
+		Languages:            languages,
+		Formats:              formats,
+		MinPages:             r.MinPages,
+		MaxPages:             r.MaxPages,
+		Currency:             currency,
+		Sort:                 sort,
+		Page:                 r.Page,
+		PageSize:             r.PageSize,
+		After:                after,
+		Before:               before,
+		IncludeTotal:         r.IncludeTotal,
+		Facets:               r.Facets,
+	}, nil
+}
+
+// parsePriceBound parses an optional price bound, e.g. "12.50" or "12.50 EUR"
+func parsePriceBound(value, currency string) (*money.Money, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	amount, explicit, found := strings.Cut(value, " ")
+	if found {
+		currency = strings.TrimSpace(explicit)
+	}
+
+	price, err := money.Parse(amount, currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidData, err)
+	}
+
+	return &price, nil
+}
+
+// bookFormats is the whitelist of book formats
+var bookFormats = map[string]bool{
+	models.BookFormatHardcover: true,
+	models.BookFormatPaperback: true,
+	models.BookFormatEbook:     true,
+	models.BookFormatAudiobook: true,
+}
+
+// sortFields is the whitelist of fields books can be sorted by
+var sortFields = map[string]bool{
+	SortByTitle:     true,
+	SortByAuthor:    true,
+	SortByPrice:     true,
+	SortByPublished: true,
+	SortByCreated:   true,
+}
+
+// parseSort parses comma-separated sort keys, e.g. "price:asc,title".
+// The order defaults to ascending
+func parseSort(value string) ([]models.BookSort, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
 	}
+
+	keys := strings.Split(value, ",")
+	sort := make([]models.BookSort, 0, len(keys))
+	seen := make(map[string]bool, len(keys))
+
+	for _, key := range keys {
+		field, order, _ := strings.Cut(strings.TrimSpace(key), ":")
+		field = strings.ToLower(strings.TrimSpace(field))
+		order = strings.ToLower(strings.TrimSpace(order))
+
+		if !sortFields[field] {
+			return nil, fmt.Errorf("%w: cannot sort by %q", domainerrors.ErrInvalidData, field)
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("%w: duplicate sort field %q", domainerrors.ErrInvalidData, field)
+		}
+		seen[field] = true
+
+		switch order {
+		case "", SortAsc:
+			sort = append(sort, models.BookSort{Field: field})
+		case SortDesc:
+			sort = append(sort, models.BookSort{Field: field, Descending: true})
+		default:
+			return nil, fmt.Errorf("%w: invalid sort order %q", domainerrors.ErrInvalidData, order)
+		}
+	}
+
+	return sort, nil
 }
 
-// BookListResponse represents a response with a list of books
+// BookListResponse represents a response with a list of books.
+// Totals are only set in cursor mode when include_total is requested
+// and facets only when facets are requested
 type BookListResponse struct {
-	Books      []BookResponse `json:"books"`
-	TotalCount int            `json:"total_count"`
-	Page       int            `json:"page"`
-	PageSize   int            `json:"page_size"`
-	TotalPages int            `json:"total_pages"`
+	Books      []BookResponse     `json:"books"`
+	TotalCount *int               `json:"total_count,omitempty"`
+	Page       int                `json:"page,omitempty"`
+	PageSize   int                `json:"page_size"`
+	TotalPages *int               `json:"total_pages,omitempty"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+	PrevCursor string             `json:"prev_cursor,omitempty"`
+	Facets     *models.BookFacets `json:"facets,omitempty"`
 }
 
 // fromModelList converts BookListResponse model to BookListResponse
@@ -130,6 +394,9 @@ func fromModelList(modelResponse *models.BookListResponse) *BookListResponse {
 		Page:       modelResponse.Page,
 		PageSize:   modelResponse.PageSize,
 		TotalPages: modelResponse.TotalPages,
+		NextCursor: modelResponse.NextCursor,
+		PrevCursor: modelResponse.PrevCursor,
+		Facets:     modelResponse.Facets,
 		Books:      make([]BookResponse, 0, len(modelResponse.Books)),
 	}
 
@@ -0,0 +1,62 @@
+package book
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/models"
+)
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []models.BookSort
+		wantErr error
+	}{
+		{name: "empty", input: "", want: nil},
+		{name: "blank", input: "  ", want: nil},
+		{name: "default order", input: "title", want: []models.BookSort{{Field: "title"}}},
+		{name: "ascending", input: "price:asc", want: []models.BookSort{{Field: "price"}}},
+		{name: "descending", input: "price:desc", want: []models.BookSort{{Field: "price", Descending: true}}},
+		{
+			name:  "several keys",
+			input: "price:desc,title",
+			want:  []models.BookSort{{Field: "price", Descending: true}, {Field: "title"}},
+		},
+		{
+			name:  "spaces and case",
+			input: " Year_Published : DESC , created_at:ASC ",
+			want:  []models.BookSort{{Field: "year_published", Descending: true}, {Field: "created_at"}},
+		},
+		{name: "unknown key", input: "isbn", wantErr: domainerrors.ErrInvalidData},
+		{name: "unknown key after a valid one", input: "title,stock:desc", wantErr: domainerrors.ErrInvalidData},
+		{name: "sql in key", input: "title;drop table books", wantErr: domainerrors.ErrInvalidData},
+		{name: "empty key", input: "title,", wantErr: domainerrors.ErrInvalidData},
+		{name: "duplicate key", input: "price,price", wantErr: domainerrors.ErrInvalidData},
+		{name: "duplicate key with other orders", input: "price:asc,title,PRICE:desc", wantErr: domainerrors.ErrInvalidData},
+		{name: "bad order", input: "title:up", wantErr: domainerrors.ErrInvalidData},
+		{name: "empty order after colon", input: "title:", want: []models.BookSort{{Field: "title"}}},
+		{name: "several colons", input: "title:asc:desc", wantErr: domainerrors.ErrInvalidData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSort(tt.input)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("parseSort(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSort(%q) unexpected error: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSort(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package book
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+
+	"github.com/bookshop/api/internal/domain/models"
+)
+
+// exportRow returns a book as a row of a catalog import.
+// Uploaded covers are left out, imports keep them anyway and their URLs may be relative
+func exportRow(book *models.Book) models.BookImportRow {
+	row := models.BookImportRow{
+		Title:         book.Title,
+		Author:        book.Author,
+		YearPublished: book.YearPublished,
+		Price:         book.Price,
+		Stock:         book.Stock,
+		ISBN:          book.ISBN,
+		Publisher:     book.Publisher,
+		Language:      book.Language,
+		Format:        book.Format,
+		PageCount:     book.PageCount,
+		Description:   book.Description,
+	}
+	if book.Category != nil {
+		row.Category = book.Category.Name
+	}
+	if book.CoverKey == "" {
+		row.CoverURL = book.CoverURL
+	}
+	return row
+}
+
+// csvExportColumns are the columns of CSV exports, the ISBN comes first as it identifies books
+var csvExportColumns = []string{
+	"isbn", "title", "author", "year_published", "price", "stock", "category",
+	"publisher", "language", "format", "page_count", "description", "cover_url",
+}
+
+// csvCatalogWriter writes books as CSV rows under a header row
+type csvCatalogWriter struct {
+	writer *csv.Writer
+	header bool
+}
+
+func newCSVCatalogWriter(w io.Writer) *csvCatalogWriter {
+	return &csvCatalogWriter{writer: csv.NewWriter(w)}
+}
+
+// Write writes a book, the header row is written before the first one
+func (w *csvCatalogWriter) Write(book *models.Book) error {
+	if !w.header {
+		w.header = true
+		if err := w.writer.Write(csvExportColumns); err != nil {
+			return err
+		}
+	}
+
+	row := exportRow(book)
+	return w.writer.Write([]string{
+		row.ISBN,
+		row.Title,
+		row.Author,
+		strconv.Itoa(row.YearPublished),
+		row.Price.Decimal(), // Imports read bare prices in the base currency
+		strconv.Itoa(row.Stock),
+		row.Category,
+		row.Publisher,
+		row.Language,
+		row.Format,
+		strconv.Itoa(row.PageCount),
+		row.Description,
+		row.CoverURL,
+	})
+}
+
+// Close writes the header of an empty export and flushes the rows
+func (w *csvCatalogWriter) Close() error {
+	if !w.header {
+		w.header = true
+		if err := w.writer.Write(csvExportColumns); err != nil {
+			return err
+		}
+	}
+
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// ndjsonCatalogWriter writes books as one JSON object per line
+type ndjsonCatalogWriter struct {
+	encoder *json.Encoder
+}
+
+func newNDJSONCatalogWriter(w io.Writer) *ndjsonCatalogWriter {
+	return &ndjsonCatalogWriter{encoder: json.NewEncoder(w)}
+}
+
+// Write writes a book, the encoder ends every object with a newline
+func (w *ndjsonCatalogWriter) Write(book *models.Book) error {
+	return w.encoder.Encode(exportRow(book))
+}
+
+// Close has nothing to write, NDJSON has no footer
+func (w *ndjsonCatalogWriter) Close() error {
+	return nil
+}
+
+// Product feed constants, see the Google Merchant Center product data specification
+const (
+	feedNamespace       = "http://base.google.com/ns/1.0"
+	feedTitle           = "Bookshop catalog"
+	feedDescription     = "Books available in the shop"
+	feedProductCategory = "784" // Media > Books in the Google product taxonomy
+	feedConditionNew    = "new"
+	feedInStock         = "in_stock"
+	feedOutOfStock      = "out_of_stock"
+)
+
+// feedItem is a product of the feed, elements are in the g namespace declared on the root
+type feedItem struct {
+	XMLName          xml.Name `xml:"item"`
+	ID               string   `xml:"g:id"`
+	Title            string   `xml:"g:title"`
+	Description      string   `xml:"g:description"`
+	Link             string   `xml:"g:link"`
+	ImageLink        string   `xml:"g:image_link,omitempty"`
+	Price            string   `xml:"g:price"`
+	Availability     string   `xml:"g:availability"`
+	Condition        string   `xml:"g:condition"`
+	GTIN             string   `xml:"g:gtin,omitempty"`
+	IdentifierExists string   `xml:"g:identifier_exists,omitempty"`
+	Brand            string   `xml:"g:brand,omitempty"`
+	ProductCategory  string   `xml:"g:google_product_category"`
+	ProductType      string   `xml:"g:product_type,omitempty"`
+}
+
+// feedCatalogWriter writes books as items of an RSS 2.0 product feed
+type feedCatalogWriter struct {
+	encoder    *xml.Encoder
+	storefront *url.URL
+	started    bool
+}
+
+func newFeedCatalogWriter(w io.Writer, storefront *url.URL) *feedCatalogWriter {
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return &feedCatalogWriter{encoder: encoder, storefront: storefront}
+}
+
+// Write writes a book as a feed item, the channel is opened before the first one
+func (w *feedCatalogWriter) Write(book *models.Book) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	item := feedItem{
+		ID:              strconv.Itoa(book.ID),
+		Title:           book.Title,
+		Description:     book.Description,
+		Link:            w.storefront.JoinPath("books", strconv.Itoa(book.ID)).String(),
+		Price:           book.Price.String(),
+		Availability:    feedOutOfStock,
+		Condition:       feedConditionNew,
+		Brand:           book.Publisher,
+		ProductCategory: feedProductCategory,
+	}
+	if item.Description == "" {
+		item.Description = fmt.Sprintf("%s by %s", book.Title, book.Author)
+	}
+	if book.Stock > 0 {
+		item.Availability = feedInStock
+	}
+	if book.ISBN != "" {
+		item.GTIN = book.ISBN
+	} else {
+		item.IdentifierExists = "no"
+	}
+	if book.CoverURL != "" {
+		if cover, err := url.Parse(book.CoverURL); err == nil {
+			item.ImageLink = w.storefront.ResolveReference(cover).String()
+		}
+	}
+	if book.Category != nil {
+		item.ProductType = book.Category.Name
+	}
+
+	return w.encoder.Encode(item)
+}
+
+// Close closes the channel, an empty feed is a channel without items
+func (w *feedCatalogWriter) Close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	for _, name := range []string{"channel", "rss"} {
+		if err := w.encoder.EncodeToken(xml.EndElement{Name: xml.Name{Local: name}}); err != nil {
+			return err
+		}
+	}
+
+	return w.encoder.Flush()
+}
+
+// start writes the XML declaration and opens the channel once
+func (w *feedCatalogWriter) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+
+	if err := w.encoder.EncodeToken(xml.ProcInst{Target: "xml", Inst: []byte(`version="1.0" encoding="UTF-8"`)}); err != nil {
+		return err
+	}
+
+	rss := xml.StartElement{
+		Name: xml.Name{Local: "rss"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "version"}, Value: "2.0"},
+			{Name: xml.Name{Local: "xmlns:g"}, Value: feedNamespace},
+		},
+	}
+	if err := w.encoder.EncodeToken(rss); err != nil {
+		return err
+	}
+	if err := w.encoder.EncodeToken(xml.StartElement{Name: xml.Name{Local: "channel"}}); err != nil {
+		return err
+	}
+
+	// RSS requires the title, link and description of the channel
+	channel := []struct{ name, value string }{
+		{"title", feedTitle},
+		{"link", w.storefront.String()},
+		{"description", feedDescription},
+	}
+	for _, element := range channel {
+		if err := w.encoder.EncodeElement(element.value, xml.StartElement{Name: xml.Name{Local: element.name}}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package book
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/bookshop/api/internal/domain/services"
+)
+
+// Exporter implements services.BookExporter interface.
+// Books are streamed from the repository and written as they are read
+type Exporter struct {
+	bookRepo      repositories.BookRepository
+	pricing       services.PricingService
+	storefrontURL *url.URL
+}
+
+// NewExporter creates a new instance of the catalog exporter.
+// Product feed items link to book pages of the storefront, relative cover URLs are resolved against it
+func NewExporter(
+	bookRepo repositories.BookRepository,
+	pricing services.PricingService,
+	storefrontURL string,
+) (services.BookExporter, error) {
+	base, err := url.Parse(strings.TrimSuffix(storefrontURL, "/") + "/")
+	if err != nil || !base.IsAbs() {
+		return nil, fmt.Errorf("invalid storefront URL %q", storefrontURL)
+	}
+
+	return &Exporter{
+		bookRepo:      bookRepo,
+		pricing:       pricing,
+		storefrontURL: base,
+	}, nil
+}
+
+// catalogWriter writes the books of an export in one format
+type catalogWriter interface {
+	// Write writes a book
+	Write(book *models.Book) error
+
+	// Close writes what follows the last book, it does not close the underlying writer
+	Close() error
+}
+
+// Export writes every book matching the filter to w in the format.
+// Output is buffered, so errors of the query are returned before anything is written to w
+func (e *Exporter) Export(ctx context.Context, format string, filter models.BookFilter, w io.Writer) error {
+	buffered := bufio.NewWriterSize(w, ExportBufferSize)
+
+	var writer catalogWriter
+	switch format {
+	case models.ExportFormatCSV:
+		writer = newCSVCatalogWriter(buffered)
+	case models.ExportFormatNDJSON:
+		writer = newNDJSONCatalogWriter(buffered)
+	case models.ExportFormatXML:
+		writer = newFeedCatalogWriter(buffered, e.storefrontURL)
+	default:
+		return fmt.Errorf("%w: unknown export format %q", domainerrors.ErrInvalidData, format)
+	}
+
+	if err := priceBoundsToBase(ctx, e.pricing, &filter); err != nil {
+		return err
+	}
+
+	if err := e.bookRepo.Stream(ctx, filter, writer.Write); err != nil {
+		return fmt.Errorf("error exporting books: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return buffered.Flush()
+}
@@ -2,23 +2,48 @@ package book
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/models"
 	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/internal/middleware"
+	"github.com/bookshop/api/pkg/money"
 	"github.com/labstack/echo/v4"
 )
 
 // Handler handles HTTP requests related to books
 type Handler struct {
-	bookService services.BookService
+	bookService  services.BookService
+	importer     services.BookImporter
+	exporter     services.BookExporter
+	coverMaxSize int64
 }
 
-// NewHandler creates a new instance of the book handler
-func NewHandler(bookService services.BookService) *Handler {
+// NewHandler creates a new instance of the book handler.
+// Cover uploads larger than coverMaxSize are rejected before they are read
+func NewHandler(
+	bookService services.BookService,
+	importer services.BookImporter,
+	exporter services.BookExporter,
+	coverMaxSize int64,
+) *Handler {
+	if coverMaxSize <= 0 {
+		coverMaxSize = DefaultCoverMaxSize
+	}
+
 	return &Handler{
-		bookService: bookService,
+		bookService:  bookService,
+		importer:     importer,
+		exporter:     exporter,
+		coverMaxSize: coverMaxSize,
 	}
 }
 
@@ -49,30 +74,50 @@ func detailedErrorResponse(message, code, details string) *ErrorResponse {
 func handleError(c echo.Context, err error) error {
 	switch {
 	case errors.Is(err, domainerrors.ErrNotFound),
-		errors.Is(err, domainerrors.ErrBookNotFound):
+		errors.Is(err, domainerrors.ErrBookNotFound),
+		errors.Is(err, domainerrors.ErrAuthorNotFound):
 		return c.JSON(http.StatusNotFound, errorResponse(err.Error()))
-	case errors.Is(err, domainerrors.ErrInvalidData):
+	case errors.Is(err, domainerrors.ErrInvalidData),
+		errors.Is(err, domainerrors.ErrCategoryNotFound),
+		errors.Is(err, domainerrors.ErrUnsupportedCurrency),
+		errors.Is(err, domainerrors.ErrInvalidCursor):
 		return c.JSON(http.StatusBadRequest, errorResponse(err.Error()))
-	case errors.Is(err, domainerrors.ErrDuplicateKey):
+	case errors.Is(err, domainerrors.ErrCoverTooLarge):
+		return c.JSON(http.StatusRequestEntityTooLarge, errorResponse(err.Error()))
+	case errors.Is(err, domainerrors.ErrUnsupportedImage):
+		return c.JSON(http.StatusUnsupportedMediaType, errorResponse(err.Error()))
+	case errors.Is(err, domainerrors.ErrExchangeRateUnavailable):
+		return c.JSON(http.StatusServiceUnavailable, errorResponse(err.Error()))
+	case errors.Is(err, domainerrors.ErrDuplicateKey),
+		errors.Is(err, domainerrors.ErrDuplicateISBN):
 		return c.JSON(http.StatusConflict, errorResponse(err.Error()))
 	default:
 		return c.JSON(http.StatusInternalServerError, errorResponse(err.Error()))
 	}
 }
 
-// RegisterRoutes registers routes for book request handling
+// RegisterRoutes registers public routes for book request handling
 func (h *Handler) RegisterRoutes(router *echo.Group) {
 	books := router.Group("/books")
-
-	// Public routes
 	books.GET("", h.listBooks)
 	books.GET("/:id", h.getBook)
+	books.GET("/isbn/:isbn", h.getBookByISBN)
 
-	// Admin routes
-	admin := router.Group("/admin/books")
-	admin.POST("", h.createBook)
-	admin.PUT("/:id", h.updateBook)
-	admin.DELETE("/:id", h.deleteBook)
+	router.GET("/authors/:id/books", h.listAuthorBooks)
+}
+
+// RegisterAdminRoutes registers book management routes on the admin router group.
+// The middleware is applied to every management route
+func (h *Handler) RegisterAdminRoutes(router *echo.Group, m ...echo.MiddlewareFunc) {
+	books := router.Group("/books", m...)
+	books.POST("", h.createBook)
+	books.PUT("/:id", h.updateBook)
+	books.DELETE("/:id", h.deleteBook)
+	books.GET("/deleted", h.listDeletedBooks)
+	books.POST("/:id/restore", h.restoreBook)
+	books.POST("/:id/cover", h.uploadCover)
+	books.POST("/import", h.importBooks)
+	books.GET("/export", h.exportBooks)
 }
 
 // createBook handles book creation request
@@ -81,10 +126,13 @@ func (h *Handler) RegisterRoutes(router *echo.Group) {
 // @Tags admin,books
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param book body CreateBookRequest true "Book data"
 // @Success 201 {object} BookResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /admin/books [post]
 func (h *Handler) createBook(c echo.Context) error {
@@ -120,10 +168,12 @@ func (h *Handler) createBook(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param id path int true "Book ID"
+// @Param currency query string false "Currency to price the book in, overrides the Accept-Currency header"
 // @Success 200 {object} BookResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
 // @Router /books/{id} [get]
 func (h *Handler) getBook(c echo.Context) error {
 	// Get book ID from request parameters
@@ -133,7 +183,34 @@ func (h *Handler) getBook(c echo.Context) error {
 	}
 
 	// Get the book
-	book, err := h.bookService.GetByID(c.Request().Context(), id)
+	book, err := h.bookService.GetByID(c.Request().Context(), id, middleware.CurrencyFromContext(c))
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	// Convert model to response
+	response := fromModel(book)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// getBookByISBN handles request to get book information by ISBN
+// @Summary Get a book by ISBN
+// @Description Returns book information by ISBN-10 or ISBN-13, hyphens are ignored
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param isbn path string true "ISBN-10 or ISBN-13"
+// @Param currency query string false "Currency to price the book in, overrides the Accept-Currency header"
+// @Success 200 {object} BookResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /books/isbn/{isbn} [get]
+func (h *Handler) getBookByISBN(c echo.Context) error {
+	// Get the book
+	book, err := h.bookService.GetByISBN(c.Request().Context(), c.Param("isbn"), middleware.CurrencyFromContext(c))
 	if err != nil {
 		return handleError(c, err)
 	}
@@ -146,19 +223,35 @@ func (h *Handler) getBook(c echo.Context) error {
 
 // listBooks handles request to get a list of books
 // @Summary Get list of books
-// @Description Returns a list of books with filtering
+// @Description Returns a list of books with filtering. With a search query books are ranked by relevance
 // @Tags books
 // @Accept json
 // @Produce json
+// @Param q query string false "Search over titles and authors, the last word may be a prefix"
 // @Param category_ids query []int false "Category IDs"
-// @Param min_price query number false "Minimum price"
-// @Param max_price query number false "Maximum price"
+// @Param include_subcategories query bool false "Also match books in subcategories of the categories"
+// @Param author_ids query []int false "Author IDs, books any of them contributed to"
+// @Param min_price query string false "Minimum price in the request currency, or with its own, e.g. 12.50 EUR"
+// @Param max_price query string false "Maximum price in the request currency, or with its own, e.g. 12.50 EUR"
 // @Param in_stock query bool false "Only in stock"
-// @Param page query int false "Page number"
+// @Param isbn query string false "ISBN-10 or ISBN-13"
+// @Param publisher query string false "Publisher, case-insensitive"
+// @Param languages query []string false "Language codes, e.g. en"
+// @Param formats query []string false "Formats: hardcover, paperback, ebook, audiobook"
+// @Param min_pages query int false "Minimum page count"
+// @Param max_pages query int false "Maximum page count"
+// @Param sort query string false "Sort keys in priority order, e.g. price:asc,title:asc. Fields: title, author, price, year_published, created_at"
+// @Param page query int false "Page number, ignored with a cursor"
 // @Param page_size query int false "Page size"
+// @Param after query string false "Cursor of the page to read the next page after"
+// @Param before query string false "Cursor of the page to read the previous page before"
+// @Param include_total query bool false "Count the total in cursor mode too, page mode always counts it"
+// @Param facets query bool false "Count the matching books per category, price range, decade and stock"
+// @Param currency query string false "Currency to price books in, overrides the Accept-Currency header"
 // @Success 200 {object} BookListResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
 // @Router /books [get]
 func (h *Handler) listBooks(c echo.Context) error {
 	var req BookListRequest
@@ -167,7 +260,10 @@ func (h *Handler) listBooks(c echo.Context) error {
 	}
 
 	// Convert request to model
-	filter := req.ToModel()
+	filter, err := req.ToModel(middleware.CurrencyFromContext(c))
+	if err != nil {
+		return handleError(c, err)
+	}
 
 	// Get list of books
 	books, err := h.bookService.List(c.Request().Context(), filter)
@@ -181,18 +277,74 @@ func (h *Handler) listBooks(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
+// listAuthorBooks handles request to get a list of books an author contributed to
+// @Summary Get list of books by author
+// @Description Returns a list of books the author contributed to in any role, with the filtering of the book list
+// @Tags books,authors
+// @Accept json
+// @Produce json
+// @Param id path int true "Author ID"
+// @Param q query string false "Search over titles and authors, the last word may be a prefix"
+// @Param category_ids query []int false "Category IDs"
+// @Param include_subcategories query bool false "Also match books in subcategories of the categories"
+// @Param in_stock query bool false "Only in stock"
+// @Param sort query string false "Sort keys in priority order, e.g. price:asc,title:asc. Fields: title, author, price, year_published, created_at"
+// @Param page query int false "Page number, ignored with a cursor"
+// @Param page_size query int false "Page size"
+// @Param after query string false "Cursor of the page to read the next page after"
+// @Param before query string false "Cursor of the page to read the previous page before"
+// @Param currency query string false "Currency to price books in, overrides the Accept-Currency header"
+// @Success 200 {object} BookListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /authors/{id}/books [get]
+func (h *Handler) listAuthorBooks(c echo.Context) error {
+	// Get author ID from request parameters
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid author ID"))
+	}
+
+	var req BookListRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid request format"))
+	}
+
+	// Convert request to model
+	filter, err := req.ToModel(middleware.CurrencyFromContext(c))
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	// Get list of the author's books
+	books, err := h.bookService.ListByAuthor(c.Request().Context(), id, filter)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	// Convert model to response
+	response := fromModelList(books)
+
+	return c.JSON(http.StatusOK, response)
+}
+
 // updateBook handles book update request
 // @Summary Update a book
 // @Description Updates book data
 // @Tags admin,books
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "Book ID"
 // @Param book body UpdateBookRequest true "Book data"
 // @Success 200 {object} BookResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /admin/books/{id} [put]
 func (h *Handler) updateBook(c echo.Context) error {
@@ -227,16 +379,234 @@ func (h *Handler) updateBook(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
+// uploadCover handles book cover upload request
+// @Summary Upload a book cover
+// @Description Stores a JPEG, PNG or GIF cover image with small, medium and large thumbnails, replacing the previous cover
+// @Tags admin,books
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param cover formData file true "Cover image"
+// @Success 200 {object} BookResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/books/{id}/cover [post]
+func (h *Handler) uploadCover(c echo.Context) error {
+	// Get book ID from request parameters
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid book ID"))
+	}
+
+	// Limit the body to the cover with room for the multipart framing
+	req := c.Request()
+	req.Body = http.MaxBytesReader(c.Response(), req.Body, h.coverMaxSize+coverFormOverhead)
+
+	file, err := c.FormFile("cover")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return handleError(c, fmt.Errorf("%w: the limit is %d bytes", domainerrors.ErrCoverTooLarge, h.coverMaxSize))
+		}
+		return c.JSON(http.StatusBadRequest, errorResponse("cover file is required"))
+	}
+	if file.Size > h.coverMaxSize {
+		return handleError(c, fmt.Errorf("%w: the limit is %d bytes", domainerrors.ErrCoverTooLarge, h.coverMaxSize))
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid cover file"))
+	}
+	defer src.Close()
+
+	// Store the cover
+	book, err := h.bookService.SetCover(req.Context(), id, src)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	// Convert model to response
+	response := fromModel(book)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// coverFormOverhead is the room left for multipart boundaries and headers around an uploaded cover
+const coverFormOverhead = 64 << 10
+
+// importBooks handles bulk catalog import request
+// @Summary Import books
+// @Description Creates or updates books by ISBN from a CSV or NDJSON catalog, sent as the body or as the "file" form field.
+// @Description CSV files start with a header naming the columns after the NDJSON fields.
+// @Description Rows that fail are listed in the report while the others are imported. Large catalogs are better imported with the import-books command
+// @Tags admin,books
+// @Accept text/csv,application/x-ndjson,multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param format query string false "Catalog format: csv or ndjson, detected from the content type or file name by default"
+// @Param file formData file false "Catalog file"
+// @Success 200 {object} models.BookImportReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/books/import [post]
+func (h *Handler) importBooks(c echo.Context) error {
+	req := c.Request()
+	req.Body = http.MaxBytesReader(c.Response(), req.Body, MaxImportSize)
+
+	format := strings.ToLower(c.QueryParam("format"))
+	data := io.Reader(req.Body)
+
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get(echo.HeaderContentType))
+	if mediaType == echo.MIMEMultipartForm {
+		file, err := c.FormFile("file")
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				return c.JSON(http.StatusRequestEntityTooLarge, errorResponse(fmt.Sprintf("the limit is %d bytes", MaxImportSize)))
+			}
+			return c.JSON(http.StatusBadRequest, errorResponse("catalog file is required"))
+		}
+
+		src, err := file.Open()
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, errorResponse("invalid catalog file"))
+		}
+		defer src.Close()
+
+		data = src
+		if format == "" {
+			format = importFormatOf(file.Header.Get(echo.HeaderContentType), file.Filename)
+		}
+	} else if format == "" {
+		format = importFormatOf(mediaType, "")
+	}
+
+	if format == "" {
+		return c.JSON(http.StatusBadRequest, errorResponse("unknown catalog format, set the format parameter to csv or ndjson"))
+	}
+
+	report, err := h.importer.Import(req.Context(), format, data)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return c.JSON(http.StatusRequestEntityTooLarge, errorResponse(fmt.Sprintf("the limit is %d bytes", MaxImportSize)))
+		}
+		return handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// exportContentTypes maps the catalog export formats to the content types they are served with
+var exportContentTypes = map[string]string{
+	models.ExportFormatCSV:    "text/csv; charset=utf-8",
+	models.ExportFormatNDJSON: "application/x-ndjson",
+	models.ExportFormatXML:    echo.MIMEApplicationXMLCharsetUTF8,
+}
+
+// exportBooks handles catalog export request
+// @Summary Export books
+// @Description Streams every book matching the filter of the book list as CSV, NDJSON or a Google Merchant product feed, ignoring pages.
+// @Description CSV and NDJSON exports have the columns of imports. Prices are in the base currency
+// @Tags admin,books
+// @Produce text/csv,application/x-ndjson,application/xml
+// @Security BearerAuth
+// @Param format query string false "Export format: csv, ndjson or xml" default(csv)
+// @Param q query string false "Search over titles and authors, the last word may be a prefix"
+// @Param category_ids query []int false "Category IDs"
+// @Param include_subcategories query bool false "Also match books in subcategories of the categories"
+// @Param author_ids query []int false "Author IDs, books any of them contributed to"
+// @Param min_price query string false "Minimum price in the base currency, or with its own, e.g. 12.50 EUR"
+// @Param max_price query string false "Maximum price in the base currency, or with its own, e.g. 12.50 EUR"
+// @Param in_stock query bool false "Only in stock"
+// @Param publisher query string false "Publisher, case-insensitive"
+// @Param languages query []string false "Language codes, e.g. en"
+// @Param formats query []string false "Formats: hardcover, paperback, ebook, audiobook"
+// @Param sort query string false "Sort keys in priority order, e.g. price:asc,title:asc. Fields: title, author, price, year_published, created_at"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/books/export [get]
+func (h *Handler) exportBooks(c echo.Context) error {
+	format := strings.ToLower(c.QueryParam("format"))
+	if format == "" {
+		format = models.ExportFormatCSV
+	}
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		return c.JSON(http.StatusBadRequest, errorResponse(fmt.Sprintf("unknown export format %q", format)))
+	}
+
+	var req BookListRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid request format"))
+	}
+
+	// Convert request to model, bounds without a currency are in the base currency
+	filter, err := req.ToModel(money.DefaultCurrency)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	// A whole catalog takes longer to send than the server write timeout allows
+	res := c.Response()
+	if err := http.NewResponseController(res).SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return handleError(c, err)
+	}
+
+	res.Header().Set(echo.HeaderContentType, contentType)
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="catalog.%s"`, format))
+
+	if err := h.exporter.Export(c.Request().Context(), format, filter, res); err != nil {
+		// Once streaming has started the status is sent, the client gets a truncated file
+		if res.Committed {
+			return err
+		}
+		res.Header().Del(echo.HeaderContentDisposition)
+		return handleError(c, err)
+	}
+
+	return nil
+}
+
+// importFormatOf returns the catalog format of a content type or file name, or "" if it is neither
+func importFormatOf(contentType, filename string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "text/csv", strings.EqualFold(filepath.Ext(filename), ".csv"):
+		return models.ImportFormatCSV
+	case mediaType == "application/x-ndjson", mediaType == "application/jsonl",
+		strings.EqualFold(filepath.Ext(filename), ".ndjson"), strings.EqualFold(filepath.Ext(filename), ".jsonl"):
+		return models.ImportFormatNDJSON
+	default:
+		return ""
+	}
+}
+
 // deleteBook handles book deletion request
 // @Summary Delete a book
-// @Description Deletes a book by ID
+// @Description Soft deletes a book by ID. It is hidden from the catalog and carts but stays in order history and can be restored
 // @Tags admin,books
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "Book ID"
 // @Success 204 "No Content"
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /admin/books/{id} [delete]
@@ -254,3 +624,83 @@ func (h *Handler) deleteBook(c echo.Context) error {
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+// listDeletedBooks handles request to get a list of deleted books
+// @Summary Get list of deleted books
+// @Description Returns a list of soft deleted books with the filtering of the book list
+// @Tags admin,books
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param q query string false "Search over titles and authors, the last word may be a prefix"
+// @Param category_ids query []int false "Category IDs"
+// @Param isbn query string false "ISBN-10 or ISBN-13"
+// @Param sort query string false "Sort keys in priority order, e.g. price:asc,title:asc. Fields: title, author, price, year_published, created_at"
+// @Param page query int false "Page number, ignored with a cursor"
+// @Param page_size query int false "Page size"
+// @Param after query string false "Cursor of the page to read the next page after"
+// @Param before query string false "Cursor of the page to read the previous page before"
+// @Success 200 {object} BookListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/books/deleted [get]
+func (h *Handler) listDeletedBooks(c echo.Context) error {
+	var req BookListRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid request format"))
+	}
+
+	// Convert request to model
+	filter, err := req.ToModel(middleware.CurrencyFromContext(c))
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	// Get list of deleted books
+	books, err := h.bookService.ListDeleted(c.Request().Context(), filter)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	// Convert model to response
+	response := fromModelList(books)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// restoreBook handles request to restore a deleted book
+// @Summary Restore a book
+// @Description Restores a soft deleted book to the catalog. Its category must not be deleted
+// @Tags admin,books
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Success 200 {object} BookResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/books/{id}/restore [post]
+func (h *Handler) restoreBook(c echo.Context) error {
+	// Get book ID from request parameters
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid book ID"))
+	}
+
+	// Restore the book
+	book, err := h.bookService.Restore(c.Request().Context(), id)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	// Convert model to response
+	response := fromModel(book)
+
+	return c.JSON(http.StatusOK, response)
+}
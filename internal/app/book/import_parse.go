@@ -0,0 +1,163 @@
+package book
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/pkg/money"
+)
+
+// rowVisitor receives every row of a catalog file with its line,
+// or the error that made the row unreadable
+type rowVisitor func(line int, row models.BookImportRow, err error) error
+
+// maxNDJSONLine is the longest NDJSON line read, in bytes
+const maxNDJSONLine = 1 << 20
+
+// csvColumns maps the CSV columns to the fields they set
+var csvColumns = map[string]func(row *models.BookImportRow, value string) error{
+	"title":          func(row *models.BookImportRow, v string) error { row.Title = v; return nil },
+	"author":         func(row *models.BookImportRow, v string) error { row.Author = v; return nil },
+	"year_published": func(row *models.BookImportRow, v string) error { return parseIntColumn(&row.YearPublished, v) },
+	"price":          func(row *models.BookImportRow, v string) error { return parsePriceColumn(&row.Price, v) },
+	"stock":          func(row *models.BookImportRow, v string) error { return parseIntColumn(&row.Stock, v) },
+	"category":       func(row *models.BookImportRow, v string) error { row.Category = v; return nil },
+	"isbn":           func(row *models.BookImportRow, v string) error { row.ISBN = v; return nil },
+	"publisher":      func(row *models.BookImportRow, v string) error { row.Publisher = v; return nil },
+	"language":       func(row *models.BookImportRow, v string) error { row.Language = v; return nil },
+	"format":         func(row *models.BookImportRow, v string) error { row.Format = strings.ToLower(v); return nil },
+	"page_count":     func(row *models.BookImportRow, v string) error { return parseIntColumn(&row.PageCount, v) },
+	"description":    func(row *models.BookImportRow, v string) error { row.Description = v; return nil },
+	"cover_url":      func(row *models.BookImportRow, v string) error { row.CoverURL = v; return nil },
+}
+
+// requiredCSVColumns are the columns a CSV catalog must have
+var requiredCSVColumns = []string{"title", "author", "year_published", "price", "category", "isbn"}
+
+// readCSVRows reads a CSV catalog whose first row names the columns, in any order
+func readCSVRows(data io.Reader, visit rowVisitor) error {
+	reader := csv.NewReader(data)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("%w: the file is empty", domainerrors.ErrInvalidData)
+		}
+		return fmt.Errorf("%w: invalid CSV header: %v", domainerrors.ErrInvalidData, err)
+	}
+
+	columns := make([]string, len(header))
+	seen := make(map[string]bool, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := csvColumns[name]; !ok {
+			return fmt.Errorf("%w: unknown CSV column %q", domainerrors.ErrInvalidData, name)
+		}
+		if seen[name] {
+			return fmt.Errorf("%w: duplicate CSV column %q", domainerrors.ErrInvalidData, name)
+		}
+		seen[name] = true
+		columns[i] = name
+	}
+	for _, name := range requiredCSVColumns {
+		if !seen[name] {
+			return fmt.Errorf("%w: missing CSV column %q", domainerrors.ErrInvalidData, name)
+		}
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		var row models.BookImportRow
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			if err := visit(parseErr.StartLine, row, err); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error reading CSV: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		for i, value := range record {
+			if err = csvColumns[columns[i]](&row, strings.TrimSpace(value)); err != nil {
+				err = fmt.Errorf("%s: %w", columns[i], err)
+				break
+			}
+		}
+
+		if err := visit(line, row, err); err != nil {
+			return err
+		}
+	}
+}
+
+// readNDJSONRows reads an NDJSON catalog, blank lines are skipped
+func readNDJSONRows(data io.Reader, visit rowVisitor) error {
+	scanner := bufio.NewScanner(data)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxNDJSONLine)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		var row models.BookImportRow
+		decoder := json.NewDecoder(bytes.NewReader(text))
+		decoder.DisallowUnknownFields()
+		err := decoder.Decode(&row)
+
+		if err := visit(line, row, err); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return fmt.Errorf("%w: NDJSON lines are limited to %d bytes", domainerrors.ErrInvalidData, maxNDJSONLine)
+		}
+		return fmt.Errorf("error reading NDJSON: %w", err)
+	}
+
+	return nil
+}
+
+// parseIntColumn parses an integer column, an empty column is zero
+func parseIntColumn(dst *int, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("%q is not a whole number", value)
+	}
+
+	*dst = n
+	return nil
+}
+
+// parsePriceColumn parses a price column, e.g. "12.50" or "12.50 USD"
+func parsePriceColumn(dst *money.Money, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	return dst.UnmarshalParam(value)
+}
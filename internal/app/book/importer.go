@@ -0,0 +1,257 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/pkg/money"
+	"github.com/bookshop/api/pkg/validator"
+)
+
+// Importer implements services.BookImporter interface.
+// Rows are upserted in chunks, one transaction per chunk. If a chunk fails,
+// its rows are retried one by one so that only the rows at fault are reported
+type Importer struct {
+	bookRepo     repositories.BookRepository
+	categoryRepo repositories.CategoryRepository
+	authorRepo   repositories.AuthorRepository
+	txManager    repositories.TransactionManager
+	validator    *validator.Validator
+}
+
+// NewImporter creates a new instance of the catalog importer
+func NewImporter(
+	bookRepo repositories.BookRepository,
+	categoryRepo repositories.CategoryRepository,
+	authorRepo repositories.AuthorRepository,
+	txManager repositories.TransactionManager,
+) services.BookImporter {
+	v := validator.NewValidator()
+	RegisterValidators(v.Engine())
+
+	return &Importer{
+		bookRepo:     bookRepo,
+		categoryRepo: categoryRepo,
+		authorRepo:   authorRepo,
+		txManager:    txManager,
+		validator:    v,
+	}
+}
+
+// importRow is a parsed row with its line in the file
+type importRow struct {
+	line int
+	row  models.BookImportRow
+}
+
+// importRun holds the state of one import
+type importRun struct {
+	report     *models.BookImportReport
+	chunk      []importRow
+	categories map[string]int // Category IDs by name
+}
+
+// Import upserts the books of a CSV or NDJSON catalog by ISBN.
+// Only unreadable files and unknown or missing CSV columns fail the whole import
+func (i *Importer) Import(ctx context.Context, format string, data io.Reader) (*models.BookImportReport, error) {
+	run := &importRun{
+		report:     &models.BookImportReport{Errors: make([]models.BookImportError, 0)},
+		chunk:      make([]importRow, 0, ImportChunkSize),
+		categories: make(map[string]int),
+	}
+
+	visit := func(line int, row models.BookImportRow, parseErr error) error {
+		run.report.Total++
+
+		if parseErr == nil {
+			parseErr = i.prepare(&row)
+		}
+		if parseErr != nil {
+			run.fail(line, row.ISBN, parseErr)
+			return nil
+		}
+
+		run.chunk = append(run.chunk, importRow{line: line, row: row})
+		if len(run.chunk) == ImportChunkSize {
+			return i.flush(ctx, run)
+		}
+		return nil
+	}
+
+	var err error
+	switch format {
+	case models.ImportFormatCSV:
+		err = readCSVRows(data, visit)
+	case models.ImportFormatNDJSON:
+		err = readNDJSONRows(data, visit)
+	default:
+		return nil, fmt.Errorf("%w: unknown import format %q", domainerrors.ErrInvalidData, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.flush(ctx, run); err != nil {
+		return nil, err
+	}
+
+	return run.report, nil
+}
+
+// prepare validates a row and normalizes it the way books are stored
+func (i *Importer) prepare(row *models.BookImportRow) error {
+	if err := i.validator.Validate(row); err != nil {
+		return err
+	}
+
+	if row.Price.Currency != money.DefaultCurrency {
+		return fmt.Errorf("price must be in %s", money.DefaultCurrency)
+	}
+
+	// Validated above
+	row.ISBN, _ = NormalizeISBN(row.ISBN)
+	row.Title = strings.TrimSpace(row.Title)
+	row.Category = strings.TrimSpace(row.Category)
+	row.Publisher = strings.TrimSpace(row.Publisher)
+	row.Language = strings.ToLower(row.Language)
+
+	return nil
+}
+
+// flush upserts the rows of the chunk in one transaction,
+// or one by one if the chunk fails
+func (i *Importer) flush(ctx context.Context, run *importRun) error {
+	if len(run.chunk) == 0 {
+		return nil
+	}
+	defer func() { run.chunk = run.chunk[:0] }()
+
+	var created, updated int
+	err := i.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		created, updated = 0, 0
+		for _, r := range run.chunk {
+			isNew, err := i.upsert(txCtx, run, r.row)
+			if err != nil {
+				return err
+			}
+			if isNew {
+				created++
+			} else {
+				updated++
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		run.report.Created += created
+		run.report.Updated += updated
+		return nil
+	}
+
+	// Stop when the client has gone away, retrying cannot succeed
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	for _, r := range run.chunk {
+		var isNew bool
+		err := i.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+			var err error
+			isNew, err = i.upsert(txCtx, run, r.row)
+			return err
+		})
+
+		switch {
+		case err != nil:
+			run.fail(r.line, r.row.ISBN, err)
+		case isNew:
+			run.report.Created++
+		default:
+			run.report.Updated++
+		}
+	}
+
+	return nil
+}
+
+// upsert creates or updates the book of a row with its category and authors
+func (i *Importer) upsert(ctx context.Context, run *importRun, row models.BookImportRow) (bool, error) {
+	categoryID, err := i.categoryID(ctx, run, row.Category)
+	if err != nil {
+		return false, err
+	}
+
+	authors, err := resolveAuthors(ctx, i.authorRepo, nil, row.Author)
+	if err != nil {
+		return false, err
+	}
+
+	book := &models.Book{
+		Title:         row.Title,
+		Author:        FormatBookAuthor(row.Author),
+		YearPublished: row.YearPublished,
+		Price:         row.Price,
+		Stock:         row.Stock,
+		CategoryID:    categoryID,
+		ISBN:          row.ISBN,
+		Publisher:     row.Publisher,
+		Language:      row.Language,
+		Format:        row.Format,
+		PageCount:     row.PageCount,
+		Description:   row.Description,
+		CoverURL:      row.CoverURL,
+	}
+
+	created, err := i.bookRepo.UpsertByISBN(ctx, book)
+	if err != nil {
+		return false, err
+	}
+
+	if err := i.authorRepo.SetBookAuthors(ctx, book.ID, authors); err != nil {
+		return false, fmt.Errorf("error setting book authors: %w", err)
+	}
+
+	return created, nil
+}
+
+// categoryID returns the ID of the category with the name, creating the category if there is none.
+// Categories are looked up once per import
+func (i *Importer) categoryID(ctx context.Context, run *importRun, name string) (int, error) {
+	if id, ok := run.categories[name]; ok {
+		return id, nil
+	}
+
+	category, err := i.categoryRepo.GetByName(ctx, name)
+	if errors.Is(err, repositories.ErrNotFound) {
+		now := time.Now()
+		category = &models.Category{Name: name, CreatedAt: now, UpdatedAt: now}
+		if err = i.categoryRepo.Create(ctx, category); err != nil {
+			// Another import may have just created it
+			category, err = i.categoryRepo.GetByName(ctx, name)
+		}
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error resolving category %q: %w", name, err)
+	}
+
+	run.categories[name] = category.ID
+	return category.ID, nil
+}
+
+// fail reports a row that was not imported
+func (run *importRun) fail(line int, isbn string, err error) {
+	run.report.Failed++
+	run.report.Errors = append(run.report.Errors, models.BookImportError{
+		Line:  line,
+		ISBN:  isbn,
+		Error: err.Error(),
+	})
+}
@@ -4,20 +4,31 @@ import (
 	"time"
 
 	domainmodels "github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/pkg/money"
 )
 
 // Book represents a book model for service operations
 type Book struct {
-	ID            int
-	Title         string
-	Author        string
-	YearPublished int
-	Price         float64
-	Stock         int
-	CategoryID    int
-	Category      *Category
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	ID              int
+	Title           string
+	Author          string
+	Authors         []domainmodels.BookAuthor
+	YearPublished   int
+	Price           money.Money
+	Stock           int
+	CategoryID      int
+	Category        *Category
+	ISBN            string
+	Publisher       string
+	Language        string
+	Format          string
+	PageCount       int
+	Description     string
+	CoverURL        string
+	CoverThumbnails map[string]string
+	CoverKey        string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
 // Category represents a book category
@@ -31,9 +42,16 @@ type BookCreate struct {
 	Title         string
 	Author        string
 	YearPublished int
-	Price         float64
+	Price         money.Money
 	Stock         int
 	CategoryID    int
+	ISBN          string
+	Publisher     string
+	Language      string
+	Format        string
+	PageCount     int
+	Description   string
+	CoverURL      string
 }
 
 // BookUpdate represents data for updating a book
@@ -41,15 +59,22 @@ type BookUpdate struct {
 	Title         *string
 	Author        *string
 	YearPublished *int
-	Price         *float64
+	Price         *money.Money
 	CategoryID    *int
+	ISBN          *string
+	Publisher     *string
+	Language      *string
+	Format        *string
+	PageCount     *int
+	Description   *string
+	CoverURL      *string
 }
 
 // BookFilter represents book filtering parameters
 type BookFilter struct {
 	CategoryIDs []int
-	MinPrice    *float64
-	MaxPrice    *float64
+	MinPrice    *money.Money
+	MaxPrice    *money.Money
 	InStock     *bool
 	Page        int
 	PageSize    int
@@ -58,24 +83,37 @@ type BookFilter struct {
 // BookListResponse represents a response with a list of books
 type BookListResponse struct {
 	Books      []Book
-	TotalCount int
+	TotalCount *int
 	Page       int
 	PageSize   int
-	TotalPages int
+	TotalPages *int
+	NextCursor string
+	PrevCursor string
+	Facets     *domainmodels.BookFacets
 }
 
 // ToDomain converts service book model to domain model
 func (b *Book) ToDomain() *domainmodels.Book {
 	domainBook := &domainmodels.Book{
-		ID:            b.ID,
-		Title:         b.Title,
-		Author:        b.Author,
-		YearPublished: b.YearPublished,
-		Price:         b.Price,
-		Stock:         b.Stock,
-		CategoryID:    b.CategoryID,
-		CreatedAt:     b.CreatedAt,
-		UpdatedAt:     b.UpdatedAt,
+		ID:              b.ID,
+		Title:           b.Title,
+		Author:          b.Author,
+		Authors:         b.Authors,
+		YearPublished:   b.YearPublished,
+		Price:           b.Price,
+		Stock:           b.Stock,
+		CategoryID:      b.CategoryID,
+		ISBN:            b.ISBN,
+		Publisher:       b.Publisher,
+		Language:        b.Language,
+		Format:          b.Format,
+		PageCount:       b.PageCount,
+		Description:     b.Description,
+		CoverURL:        b.CoverURL,
+		CoverThumbnails: b.CoverThumbnails,
+		CoverKey:        b.CoverKey,
+		CreatedAt:       b.CreatedAt,
+		UpdatedAt:       b.UpdatedAt,
 	}
 
 	if b.Category != nil {
@@ -91,15 +129,25 @@ func (b *Book) ToDomain() *domainmodels.Book {
 // FromDomain converts domain model to service model
 func FromDomain(book *domainmodels.Book) *Book {
 	serviceBook := &Book{
-		ID:            book.ID,
-		Title:         book.Title,
-		Author:        book.Author,
-		YearPublished: book.YearPublished,
-		Price:         book.Price,
-		Stock:         book.Stock,
-		CategoryID:    book.CategoryID,
-		CreatedAt:     book.CreatedAt,
-		UpdatedAt:     book.UpdatedAt,
+		ID:              book.ID,
+		Title:           book.Title,
+		Author:          book.Author,
+		Authors:         book.Authors,
+		YearPublished:   book.YearPublished,
+		Price:           book.Price,
+		Stock:           book.Stock,
+		CategoryID:      book.CategoryID,
+		ISBN:            book.ISBN,
+		Publisher:       book.Publisher,
+		Language:        book.Language,
+		Format:          book.Format,
+		PageCount:       book.PageCount,
+		Description:     book.Description,
+		CoverURL:        book.CoverURL,
+		CoverThumbnails: book.CoverThumbnails,
+		CoverKey:        book.CoverKey,
+		CreatedAt:       book.CreatedAt,
+		UpdatedAt:       book.UpdatedAt,
 	}
 
 	if book.Category != nil {
@@ -132,6 +180,13 @@ func (bc *BookCreate) ToDomain() domainmodels.BookCreate {
 		Price:         bc.Price,
 		Stock:         bc.Stock,
 		CategoryID:    bc.CategoryID,
+		ISBN:          bc.ISBN,
+		Publisher:     bc.Publisher,
+		Language:      bc.Language,
+		Format:        bc.Format,
+		PageCount:     bc.PageCount,
+		Description:   bc.Description,
+		CoverURL:      bc.CoverURL,
 	}
 }
 
@@ -143,6 +198,13 @@ func (bu *BookUpdate) ToDomain() domainmodels.BookUpdate {
 		YearPublished: bu.YearPublished,
 		Price:         bu.Price,
 		CategoryID:    bu.CategoryID,
+		ISBN:          bu.ISBN,
+		Publisher:     bu.Publisher,
+		Language:      bu.Language,
+		Format:        bu.Format,
+		PageCount:     bu.PageCount,
+		Description:   bu.Description,
+		CoverURL:      bu.CoverURL,
 	}
 }
 
@@ -166,5 +228,8 @@ func BookListResponseFromDomain(dlr *domainmodels.BookListResponse) *BookListRes
 		Page:       dlr.Page,
 		PageSize:   dlr.PageSize,
 		TotalPages: dlr.TotalPages,
+		NextCursor: dlr.NextCursor,
+		PrevCursor: dlr.PrevCursor,
+		Facets:     dlr.Facets,
 	}
 }
@@ -1,6 +1,7 @@
 package book
 
 import (
+	"github.com/bookshop/api/config"
 	"github.com/bookshop/api/internal/domain/repositories"
 	"github.com/bookshop/api/internal/domain/services"
 	"github.com/labstack/echo/v4"
@@ -8,29 +9,52 @@ import (
 
 // Module represents a book management module
 type Module struct {
-	Handler *Handler
-	Service services.BookService
+	Handler  *Handler
+	Service  services.BookService
+	Importer services.BookImporter
+	Exporter services.BookExporter
 }
 
 // NewModule creates a new instance of the book module
 func NewModule(
 	bookRepo repositories.BookRepository,
 	categoryRepo repositories.CategoryRepository,
+	authorRepo repositories.AuthorRepository,
 	txManager repositories.TransactionManager,
-) *Module {
+	pricing services.PricingService,
+	storage services.BlobStorage,
+	catalogConfig config.CatalogConfig,
+) (*Module, error) {
 	// Create service
-	service := NewService(bookRepo, categoryRepo, txManager)
+	service := NewService(bookRepo, categoryRepo, authorRepo, txManager, pricing, storage,
+		catalogConfig.CoverMaxSize, catalogConfig.FacetCacheSize, catalogConfig.FacetCacheTTL)
+
+	// Create catalog importer
+	importer := NewImporter(bookRepo, categoryRepo, authorRepo, txManager)
+
+	// Create catalog exporter
+	exporter, err := NewExporter(bookRepo, pricing, catalogConfig.StorefrontURL)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create handler
-	handler := NewHandler(service)
+	handler := NewHandler(service, importer, exporter, catalogConfig.CoverMaxSize)
 
 	return &Module{
-		Handler: handler,
-		Service: service,
-	}
+		Handler:  handler,
+		Service:  service,
+		Importer: importer,
+		Exporter: exporter,
+	}, nil
 }
 
-// RegisterRoutes registers routes for book request handling
+// RegisterRoutes registers public routes for book request handling
 func (m *Module) RegisterRoutes(router *echo.Group) {
 	m.Handler.RegisterRoutes(router)
 }
+
+// RegisterAdminRoutes registers book management routes on the admin router group
+func (m *Module) RegisterAdminRoutes(router *echo.Group, mw ...echo.MiddlewareFunc) {
+	m.Handler.RegisterAdminRoutes(router, mw...)
+}
@@ -2,32 +2,70 @@ package book
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	servicemodels "github.com/bookshop/api/internal/app/book/models"
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
 	"github.com/bookshop/api/internal/domain/models"
 	"github.com/bookshop/api/internal/domain/repositories"
 	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/internal/pkg/cache"
+	"github.com/bookshop/api/pkg/money"
 )
 
-// Service implements services.BookService interface
+// facetCacheItem represents cached facets of a filter with expiration time
+type facetCacheItem struct {
+	facets     *models.BookFacets
+	expiration time.Time
+}
+
+// Service implements services.BookService interface.
+// Uploaded covers are kept in blob storage with their thumbnails.
+// Facets of hot filters are served from an in-process LRU cache,
+// so changes to the catalog show up in them once the cached entry expires
 type Service struct {
-	bookRepo     repositories.BookRepository
-	categoryRepo repositories.CategoryRepository
-	txManager    repositories.TransactionManager
+	bookRepo      repositories.BookRepository
+	categoryRepo  repositories.CategoryRepository
+	authorRepo    repositories.AuthorRepository
+	txManager     repositories.TransactionManager
+	pricing       services.PricingService
+	storage       services.BlobStorage
+	coverMaxSize  int64
+	facetCache    *cache.LRUCache
+	facetCacheTTL time.Duration
 }
 
 // NewService creates a new instance of the book service
 func NewService(
 	bookRepo repositories.BookRepository,
 	categoryRepo repositories.CategoryRepository,
+	authorRepo repositories.AuthorRepository,
 	txManager repositories.TransactionManager,
+	pricing services.PricingService,
+	storage services.BlobStorage,
+	coverMaxSize int64,
+	facetCacheSize int,
+	facetCacheTTL time.Duration,
 ) services.BookService {
+	if coverMaxSize <= 0 {
+		coverMaxSize = DefaultCoverMaxSize
+	}
+
 	return &Service{
-		bookRepo:     bookRepo,
-		categoryRepo: categoryRepo,
-		txManager:    txManager,
+		bookRepo:      bookRepo,
+		categoryRepo:  categoryRepo,
+		authorRepo:    authorRepo,
+		txManager:     txManager,
+		pricing:       pricing,
+		storage:       storage,
+		coverMaxSize:  coverMaxSize,
+		facetCache:    cache.NewLRUCache(facetCacheSize),
+		facetCacheTTL: facetCacheTTL,
 	}
 }
 
@@ -35,7 +73,16 @@ func NewService(
 func (s *Service) Create(ctx context.Context, input models.BookCreate) (*models.Book, error) {
 	var domainBook *models.Book
 
-	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+	// ISBNs are stored as ISBN-13
+	isbn, err := normalizeOptionalISBN(input.ISBN)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateBasePrice(input.Price); err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
 		// Convert to service model
 		serviceInput := servicemodels.BookCreate{
 			Title:         input.Title,
@@ -44,14 +91,35 @@ func (s *Service) Create(ctx context.Context, input models.BookCreate) (*models.
 			Price:         input.Price,
 			Stock:         input.Stock,
 			CategoryID:    input.CategoryID,
+			ISBN:          isbn,
+			Publisher:     strings.TrimSpace(input.Publisher),
+			Language:      strings.ToLower(input.Language),
+			Format:        input.Format,
+			PageCount:     input.PageCount,
+			Description:   input.Description,
+			CoverURL:      input.CoverURL,
 		}
 
 		// Check if the category exists
 		_, err := s.categoryRepo.GetByID(txCtx, serviceInput.CategoryID)
 		if err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				return domainerrors.ErrCategoryNotFound
+			}
 			return fmt.Errorf("error checking category: %w", err)
 		}
 
+		// Find or create the contributors, the byline is kept in sync with them
+		authors, err := resolveAuthors(txCtx, s.authorRepo, input.Authors, input.Author)
+		if err != nil {
+			return err
+		}
+		if len(input.Authors) > 0 {
+			serviceInput.Author = models.Byline(authors)
+		} else {
+			serviceInput.Author = FormatBookAuthor(serviceInput.Author)
+		}
+
 		// Create a new book
 		now := time.Now()
 		domainBook = &models.Book{
@@ -61,6 +129,13 @@ func (s *Service) Create(ctx context.Context, input models.BookCreate) (*models.
 			Price:         serviceInput.Price,
 			Stock:         serviceInput.Stock,
 			CategoryID:    serviceInput.CategoryID,
+			ISBN:          serviceInput.ISBN,
+			Publisher:     serviceInput.Publisher,
+			Language:      serviceInput.Language,
+			Format:        serviceInput.Format,
+			PageCount:     serviceInput.PageCount,
+			Description:   serviceInput.Description,
+			CoverURL:      serviceInput.CoverURL,
 			CreatedAt:     now,
 			UpdatedAt:     now,
 		}
@@ -70,6 +145,11 @@ func (s *Service) Create(ctx context.Context, input models.BookCreate) (*models.
 			return fmt.Errorf("error creating book: %w", err)
 		}
 
+		if err := s.authorRepo.SetBookAuthors(txCtx, domainBook.ID, authors); err != nil {
+			return fmt.Errorf("error setting book authors: %w", err)
+		}
+		domainBook.Authors = authors
+
 		// Load category information
 		category, err := s.categoryRepo.GetByID(txCtx, domainBook.CategoryID)
 		if err != nil {
@@ -88,14 +168,50 @@ func (s *Service) Create(ctx context.Context, input models.BookCreate) (*models.
 	return domainBook, nil
 }
 
-// GetByID returns a book by its ID
-func (s *Service) GetByID(ctx context.Context, id int) (*models.Book, error) {
+// GetByID returns a book by its ID priced in the currency
+func (s *Service) GetByID(ctx context.Context, id int, currency string) (*models.Book, error) {
 	// Get book from repository
 	domainBook, err := s.bookRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("error getting book: %w", err)
 	}
 
+	return s.completeBook(ctx, domainBook, currency)
+}
+
+// GetByISBN returns a book by its ISBN-10 or ISBN-13 priced in the currency
+func (s *Service) GetByISBN(ctx context.Context, isbn string, currency string) (*models.Book, error) {
+	normalized, ok := NormalizeISBN(isbn)
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid ISBN %q", domainerrors.ErrInvalidData, isbn)
+	}
+
+	// Get book from repository
+	domainBook, err := s.bookRepo.GetByISBN(ctx, normalized)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, domainerrors.ErrBookNotFound
+		}
+		return nil, fmt.Errorf("error getting book: %w", err)
+	}
+
+	return s.completeBook(ctx, domainBook, currency)
+}
+
+// completeBook prices a book read from the repository in the currency and loads its category
+func (s *Service) completeBook(ctx context.Context, domainBook *models.Book, currency string) (*models.Book, error) {
+	// Price the book in the requested currency
+	books := []models.Book{*domainBook}
+	if _, err := s.pricing.PriceBooks(ctx, books, currency); err != nil {
+		return nil, fmt.Errorf("error pricing book: %w", err)
+	}
+	domainBook.Price = books[0].Price
+
+	if err := s.loadAuthors(ctx, books); err != nil {
+		return nil, err
+	}
+	domainBook.Authors = books[0].Authors
+
 	// Load category information if needed
 	if domainBook.Category == nil {
 		category, err := s.categoryRepo.GetByID(ctx, domainBook.CategoryID)
@@ -118,12 +234,29 @@ func (s *Service) List(ctx context.Context, filter models.BookFilter) (*models.B
 		filter.PageSize = 10
 	}
 
+	// Price bounds are compared with base currency prices
+	if err := priceBoundsToBase(ctx, s.pricing, &filter); err != nil {
+		return nil, err
+	}
+
+	// Facets cached for the filter are not counted again
+	var facets *models.BookFacets
+	if filter.Facets {
+		facets = s.cachedFacets(filter)
+		filter.Facets = facets == nil
+	}
+
 	// Get book list from repository
-	books, totalCount, err := s.bookRepo.List(ctx, filter)
+	books, countedFacets, pageInfo, err := s.bookRepo.List(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("error getting book list: %w", err)
 	}
 
+	if countedFacets != nil {
+		s.cacheFacets(filter, countedFacets)
+		facets = countedFacets
+	}
+
 	// Load category information
 	if len(books) > 0 {
 		categoryIDs := make([]int, 0, len(books))
@@ -148,27 +281,104 @@ func (s *Service) List(ctx context.Context, filter models.BookFilter) (*models.B
 		}
 	}
 
-	// Calculate total number of pages
-	totalPages := totalCount / filter.PageSize
-	if totalCount%filter.PageSize > 0 {
-		totalPages++
+	if err := s.loadAuthors(ctx, books); err != nil {
+		return nil, err
+	}
+
+	// Price books in the requested currency
+	rate, err := s.pricing.PriceBooks(ctx, books, filter.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("error pricing books: %w", err)
+	}
+
+	// Price ranges are counted in the base currency, their bounds are given in the requested one
+	if facets != nil {
+		if facets, err = convertPriceFacets(facets, rate); err != nil {
+			return nil, err
+		}
 	}
 
 	// Form the response
 	response := &models.BookListResponse{
 		Books:      books,
-		TotalCount: totalCount,
-		Page:       filter.Page,
+		TotalCount: pageInfo.TotalCount,
 		PageSize:   filter.PageSize,
-		TotalPages: totalPages,
+		TotalPages: pageInfo.TotalPages(filter.PageSize),
+		Facets:     facets,
+	}
+	response.NextCursor, response.PrevCursor = pageInfo.Cursors()
+
+	// Page numbers only make sense in page mode
+	if filter.After == nil && filter.Before == nil {
+		response.Page = filter.Page
 	}
 
 	return response, nil
 }
 
+// convertPriceFacets returns a copy of the facets with the price range bounds converted with the rate,
+// the facets themselves may be cached
+func convertPriceFacets(facets *models.BookFacets, rate money.Rate) (*models.BookFacets, error) {
+	converted := *facets
+	converted.PriceRanges = make([]models.PriceRangeFacet, len(facets.PriceRanges))
+
+	for i, priceRange := range facets.PriceRanges {
+		lower, err := priceRange.Min.Convert(rate)
+		if err != nil {
+			return nil, fmt.Errorf("error converting price range: %w", err)
+		}
+		priceRange.Min = lower
+
+		if priceRange.Max != nil {
+			upper, err := priceRange.Max.Convert(rate)
+			if err != nil {
+				return nil, fmt.Errorf("error converting price range: %w", err)
+			}
+			priceRange.Max = &upper
+		}
+
+		converted.PriceRanges[i] = priceRange
+	}
+
+	return &converted, nil
+}
+
+// priceBoundsToBase converts the price bounds of the filter to the base currency books are priced in
+func priceBoundsToBase(ctx context.Context, pricing services.PricingService, filter *models.BookFilter) error {
+	if filter.MinPrice != nil {
+		minPrice, err := pricing.ToBase(ctx, *filter.MinPrice)
+		if err != nil {
+			return fmt.Errorf("error converting minimum price: %w", err)
+		}
+		filter.MinPrice = &minPrice
+	}
+	if filter.MaxPrice != nil {
+		maxPrice, err := pricing.ToBase(ctx, *filter.MaxPrice)
+		if err != nil {
+			return fmt.Errorf("error converting maximum price: %w", err)
+		}
+		filter.MaxPrice = &maxPrice
+	}
+	return nil
+}
+
+// ListByAuthor returns a list of books the author contributed to with filtering
+func (s *Service) ListByAuthor(ctx context.Context, authorID int, filter models.BookFilter) (*models.BookListResponse, error) {
+	if _, err := s.authorRepo.GetByID(ctx, authorID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, domainerrors.ErrAuthorNotFound
+		}
+		return nil, fmt.Errorf("error getting author: %w", err)
+	}
+
+	filter.AuthorIDs = []int{authorID}
+	return s.List(ctx, filter)
+}
+
 // Update updates book data
 func (s *Service) Update(ctx context.Context, id int, input models.BookUpdate) (*models.Book, error) {
 	var updatedBook *models.Book
+	var replacedCoverKey string
 
 	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
 		// Get current book
@@ -181,19 +391,68 @@ func (s *Service) Update(ctx context.Context, id int, input models.BookUpdate) (
 		if input.Title != nil {
 			book.Title = *input.Title
 		}
-		if input.Author != nil {
-			book.Author = *input.Author
+
+		// Contributors are replaced when they or the byline are given
+		var authors []models.BookAuthor
+		if len(input.Authors) > 0 || input.Author != nil {
+			var byline string
+			if input.Author != nil {
+				byline = *input.Author
+			}
+
+			authors, err = resolveAuthors(txCtx, s.authorRepo, input.Authors, byline)
+			if err != nil {
+				return err
+			}
+			if len(input.Authors) > 0 {
+				book.Author = models.Byline(authors)
+			} else {
+				book.Author = FormatBookAuthor(byline)
+			}
 		}
 		if input.YearPublished != nil {
 			book.YearPublished = *input.YearPublished
 		}
 		if input.Price != nil {
+			if err := validateBasePrice(*input.Price); err != nil {
+				return err
+			}
 			book.Price = *input.Price
 		}
+		if input.ISBN != nil {
+			isbn, err := normalizeOptionalISBN(*input.ISBN)
+			if err != nil {
+				return err
+			}
+			book.ISBN = isbn
+		}
+		if input.Publisher != nil {
+			book.Publisher = strings.TrimSpace(*input.Publisher)
+		}
+		if input.Language != nil {
+			book.Language = strings.ToLower(*input.Language)
+		}
+		if input.Format != nil {
+			book.Format = *input.Format
+		}
+		if input.PageCount != nil {
+			book.PageCount = *input.PageCount
+		}
+		if input.Description != nil {
+			book.Description = *input.Description
+		}
+		if input.CoverURL != nil {
+			// An external cover replaces the uploaded one and its thumbnails
+			book.CoverURL = *input.CoverURL
+			replacedCoverKey, book.CoverKey, book.CoverThumbnails = book.CoverKey, "", nil
+		}
 		if input.CategoryID != nil {
 			// Check if the category exists
 			_, err := s.categoryRepo.GetByID(txCtx, *input.CategoryID)
 			if err != nil {
+				if errors.Is(err, repositories.ErrNotFound) {
+					return domainerrors.ErrCategoryNotFound
+				}
 				return fmt.Errorf("error checking category: %w", err)
 			}
 			book.CategoryID = *input.CategoryID
@@ -207,6 +466,19 @@ func (s *Service) Update(ctx context.Context, id int, input models.BookUpdate) (
 			return fmt.Errorf("error updating book: %w", err)
 		}
 
+		if authors != nil {
+			if err := s.authorRepo.SetBookAuthors(txCtx, book.ID, authors); err != nil {
+				return fmt.Errorf("error setting book authors: %w", err)
+			}
+			book.Authors = authors
+		} else {
+			books := []models.Book{*book}
+			if err := s.loadAuthors(txCtx, books); err != nil {
+				return err
+			}
+			book.Authors = books[0].Authors
+		}
+
 		// Load category information
 		category, err := s.categoryRepo.GetByID(txCtx, book.CategoryID)
 		if err != nil {
@@ -224,17 +496,68 @@ func (s *Service) Update(ctx context.Context, id int, input models.BookUpdate) (
 		return nil, err
 	}
 
+	// The replaced cover is no longer referenced, a failed cleanup only leaves unused files
+	if replacedCoverKey != "" {
+		_ = s.storage.DeletePrefix(ctx, replacedCoverKey+"/")
+	}
+
 	return updatedBook, nil
 }
 
-// Delete deletes a book by ID
+// Delete soft deletes a book by ID. The book leaves the catalog and carts but stays in order history.
+// Uploaded covers are kept so that a restored book has its cover back
 func (s *Service) Delete(ctx context.Context, id int) error {
-	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
-		if err := s.bookRepo.Delete(txCtx, id); err != nil {
-			return fmt.Errorf("error deleting book: %w", err)
+	if err := s.bookRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return domainerrors.ErrBookNotFound
+		}
+		return fmt.Errorf("error deleting book: %w", err)
+	}
+
+	return nil
+}
+
+// Restore restores a deleted book to the catalog. Its category must not be deleted
+func (s *Service) Restore(ctx context.Context, id int) (*models.Book, error) {
+	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		books, err := s.bookRepo.GetBooksByIDsWithDeleted(txCtx, []int{id})
+		if err != nil {
+			return fmt.Errorf("error getting book: %w", err)
+		}
+		if len(books) == 0 || books[0].DeletedAt == nil {
+			return domainerrors.ErrBookNotFound
+		}
+
+		if _, err := s.categoryRepo.GetByID(txCtx, books[0].CategoryID); err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				return fmt.Errorf("%w: category %d of the book is deleted, restore it first",
+					domainerrors.ErrCategoryNotFound, books[0].CategoryID)
+			}
+			return fmt.Errorf("error getting category: %w", err)
+		}
+
+		if err := s.bookRepo.Restore(txCtx, id); err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				return domainerrors.ErrBookNotFound
+			}
+			if errors.Is(err, domainerrors.ErrDuplicateISBN) {
+				return err
+			}
+			return fmt.Errorf("error restoring book: %w", err)
 		}
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(ctx, id, "")
+}
+
+// ListDeleted returns a list of deleted books with the filtering of the catalog
+func (s *Service) ListDeleted(ctx context.Context, filter models.BookFilter) (*models.BookListResponse, error) {
+	filter.Deleted = true
+	return s.List(ctx, filter)
 }
 
 // GetBooksByIDs returns books by a list of IDs
@@ -250,3 +573,191 @@ func (s *Service) GetBooksByIDs(ctx context.Context, ids []int) ([]models.Book,
 
 	return books, nil
 }
+
+// resolveAuthors finds or creates the authors of the inputs, in credit order.
+// Without inputs the byline is split into authors.
+// Existing authors are matched regardless of case and keep their spelling
+func resolveAuthors(
+	ctx context.Context,
+	authorRepo repositories.AuthorRepository,
+	inputs []models.BookAuthorInput,
+	byline string,
+) ([]models.BookAuthor, error) {
+	if len(inputs) == 0 {
+		for _, name := range SplitAuthors(byline) {
+			inputs = append(inputs, models.BookAuthorInput{Name: name})
+		}
+	}
+
+	authors := make([]models.BookAuthor, 0, len(inputs))
+	seen := make(map[string]bool, len(inputs))
+	for _, input := range inputs {
+		name := FormatBookAuthor(input.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: author name is required", domainerrors.ErrInvalidData)
+		}
+
+		role := input.Role
+		if role == "" {
+			role = models.AuthorRoleAuthor
+		}
+
+		// A contributor is credited once per role
+		key := strings.ToLower(name) + "|" + role
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		author, err := authorRepo.GetOrCreate(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("error getting author: %w", err)
+		}
+
+		authors = append(authors, models.BookAuthor{
+			AuthorID: author.ID,
+			Name:     author.Name,
+			Role:     role,
+		})
+	}
+
+	if len(authors) == 0 {
+		return nil, fmt.Errorf("%w: at least one author is required", domainerrors.ErrInvalidData)
+	}
+
+	return authors, nil
+}
+
+// loadAuthors sets the contributors of the books
+func (s *Service) loadAuthors(ctx context.Context, books []models.Book) error {
+	if len(books) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(books))
+	for i, book := range books {
+		ids[i] = book.ID
+	}
+
+	authors, err := s.authorRepo.GetByBookIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("error getting book authors: %w", err)
+	}
+
+	for i := range books {
+		books[i].Authors = authors[books[i].ID]
+	}
+
+	return nil
+}
+
+// normalizeOptionalISBN returns an ISBN as ISBN-13, an empty ISBN stays empty
+func normalizeOptionalISBN(isbn string) (string, error) {
+	if strings.TrimSpace(isbn) == "" {
+		return "", nil
+	}
+
+	normalized, ok := NormalizeISBN(isbn)
+	if !ok {
+		return "", fmt.Errorf("%w: invalid ISBN %q", domainerrors.ErrInvalidData, isbn)
+	}
+	return normalized, nil
+}
+
+// validateBasePrice checks that a price is in the base currency, prices are stored without their currency
+func validateBasePrice(price money.Money) error {
+	if price.Currency != money.DefaultCurrency {
+		return fmt.Errorf("%w: price must be in %s", domainerrors.ErrInvalidData, money.DefaultCurrency)
+	}
+	return nil
+}
+
+// cachedFacets returns the cached facets of the filter, nil if they are not cached or expired
+func (s *Service) cachedFacets(filter models.BookFilter) *models.BookFacets {
+	key := facetCacheKey(filter)
+
+	value, found := s.facetCache.Get(key)
+	if !found {
+		return nil
+	}
+
+	item, ok := value.(*facetCacheItem)
+	if !ok || time.Now().After(item.expiration) {
+		s.facetCache.Remove(key)
+		return nil
+	}
+
+	return item.facets
+}
+
+// cacheFacets caches the facets of the filter
+func (s *Service) cacheFacets(filter models.BookFilter, facets *models.BookFacets) {
+	s.facetCache.Put(facetCacheKey(filter), &facetCacheItem{
+		facets:     facets,
+		expiration: time.Now().Add(s.facetCacheTTL),
+	})
+}
+
+// facetCacheKey returns the cache key of the facets of a filter.
+// Only the conditions selecting books are part of the key, sorting and pagination are not,
+// and price bounds are expected in the base currency
+func facetCacheKey(filter models.BookFilter) string {
+	categoryIDs := append([]int(nil), filter.CategoryIDs...)
+	sort.Ints(categoryIDs)
+
+	ids := make([]string, len(categoryIDs))
+	for i, id := range categoryIDs {
+		ids[i] = strconv.Itoa(id)
+	}
+
+	authorIDs := make([]string, len(filter.AuthorIDs))
+	for i, id := range filter.AuthorIDs {
+		authorIDs[i] = strconv.Itoa(id)
+	}
+	sort.Strings(authorIDs)
+
+	var minPrice, maxPrice, inStock, minPages, maxPages, subcategories, deleted string
+	if filter.MinPrice != nil {
+		minPrice = filter.MinPrice.String()
+	}
+	if filter.MaxPrice != nil {
+		maxPrice = filter.MaxPrice.String()
+	}
+	if filter.IncludeSubcategories {
+		subcategories = "sub"
+	}
+	if filter.InStock != nil {
+		inStock = strconv.FormatBool(*filter.InStock)
+	}
+	if filter.Deleted {
+		deleted = "deleted"
+	}
+	if filter.MinPages != nil {
+		minPages = strconv.Itoa(*filter.MinPages)
+	}
+	if filter.MaxPages != nil {
+		maxPages = strconv.Itoa(*filter.MaxPages)
+	}
+
+	languages := append([]string(nil), filter.Languages...)
+	sort.Strings(languages)
+	formats := append([]string(nil), filter.Formats...)
+	sort.Strings(formats)
+
+	return strings.Join([]string{
+		strconv.Quote(filter.Query),
+		strings.Join(ids, ","),
+		subcategories,
+		strings.Join(authorIDs, ","),
+		minPrice,
+		maxPrice,
+		inStock,
+		filter.ISBN,
+		strconv.Quote(strings.ToLower(filter.Publisher)),
+		strings.Join(languages, ","),
+		strings.Join(formats, ","),
+		minPages,
+		maxPages,
+		deleted,
+	}, "|")
+}
@@ -1,9 +1,11 @@
 package book
 
 import (
+	"regexp"
 	"strings"
 
 	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/pkg/money"
 )
 
 // FormatBookTitle formats the book title
@@ -19,23 +21,30 @@ func FormatBookTitle(title string) string {
 	return title
 }
 
-// FormatBookAuthor formats the author's name
+// FormatBookAuthor formats the author's name.
+// Only whitespace is normalized, names keep their own capitalization, e.g. "McDonald" or "de la Cruz"
 func FormatBookAuthor(author string) string {
-	// Remove extra spaces
-	author = strings.TrimSpace(author)
+	return strings.Join(strings.Fields(author), " ")
+}
+
+// authorSeparator matches the separators between names in a byline.
+// Commas are part of names, e.g. "Tolkien, J.R.R." or "Martin Luther King, Jr.",
+// the authors migration splits existing bylines the same way
+var authorSeparator = regexp.MustCompile(`\s*(?:;|&|\sand\s)\s*`)
 
-	// Split the author's name into parts
-	parts := strings.Split(author, " ")
+// SplitAuthors splits a byline into formatted author names,
+// e.g. "Terry Pratchett & Neil Gaiman" into "Terry Pratchett" and "Neil Gaiman"
+func SplitAuthors(byline string) []string {
+	parts := authorSeparator.Split(byline, -1)
 
-	// Format each part
-	for i, part := range parts {
-		if len(part) > 0 {
-			parts[i] = strings.ToUpper(part[:1]) + strings.ToLower(part[1:])
+	names := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if name := FormatBookAuthor(part); name != "" {
+			names = append(names, name)
 		}
 	}
 
-	// Join the parts back together
-	return strings.Join(parts, " ")
+	return names
 }
 
 // CalculateTotalPages calculates the total number of pages
@@ -84,18 +93,23 @@ func FilterBooksByCategory(books []models.Book, categoryID int) []models.Book {
 }
 
 // FilterBooksByPriceRange filters books by price range
-func FilterBooksByPriceRange(books []models.Book, minPrice, maxPrice *float64) []models.Book {
+// Books priced in another currency than the bounds are filtered out
+func FilterBooksByPriceRange(books []models.Book, minPrice, maxPrice *money.Money) []models.Book {
 	filtered := make([]models.Book, 0, len(books))
 
 	for _, book := range books {
 		// Check minimum price
-		if minPrice != nil && book.Price < *minPrice {
-			continue
+		if minPrice != nil {
+			if cmp, err := book.Price.Cmp(*minPrice); err != nil || cmp < 0 {
+				continue
+			}
 		}
 
 		// Check maximum price
-		if maxPrice != nil && book.Price > *maxPrice {
-			continue
+		if maxPrice != nil {
+			if cmp, err := book.Price.Cmp(*maxPrice); err != nil || cmp > 0 {
+				continue
+			}
 		}
 
 		filtered = append(filtered, book)
@@ -1,21 +1,96 @@
 package book
 
 import (
+	"strings"
+
 	"github.com/go-playground/validator/v10"
 )
 
 // RegisterValidators registers custom validators for books
 func RegisterValidators(v *validator.Validate) {
-	// Here you can add custom validators for books
-	// For example, a validator for checking ISBN, author format, etc.
+	// Replaces the built-in isbn tag, which does not accept hyphens or spaces
+	_ = v.RegisterValidation("isbn", validateISBN)
+}
+
+// validateISBN checks that a field is an ISBN-10 or ISBN-13 with a valid check digit
+func validateISBN(fl validator.FieldLevel) bool {
+	_, ok := NormalizeISBN(fl.Field().String())
+	return ok
+}
+
+// NormalizeISBN returns an ISBN-10 or ISBN-13 as ISBN-13 digits, e.g. "0-306-40615-2" as "9780306406157".
+// Hyphens and spaces are ignored. Returns false if the ISBN is malformed or its check digit is wrong
+func NormalizeISBN(isbn string) (string, bool) {
+	digits := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(isbn)))
+
+	switch len(digits) {
+	case 10:
+		if !validISBN10(digits) {
+			return "", false
+		}
+		return isbn10To13(digits), true
+	case 13:
+		if !validISBN13(digits) {
+			return "", false
+		}
+		return digits, true
+	default:
+		return "", false
+	}
+}
+
+// validISBN10 checks the check digit of an ISBN-10, the last character may be X for 10
+func validISBN10(isbn string) bool {
+	sum := 0
+	for i := 0; i < 10; i++ {
+		var digit int
+		switch c := isbn[i]; {
+		case c >= '0' && c <= '9':
+			digit = int(c - '0')
+		case c == 'X' && i == 9:
+			digit = 10
+		default:
+			return false
+		}
+		sum += (10 - i) * digit
+	}
+	return sum%11 == 0
+}
 
-	// Example of registering an ISBN validator
-	// v.RegisterValidation("isbn", validateISBN)
+// validISBN13 checks the check digit of an ISBN-13
+func validISBN13(isbn string) bool {
+	sum := 0
+	for i := 0; i < 13; i++ {
+		c := isbn[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += weight * int(c-'0')
+	}
+	return sum%10 == 0
 }
 
-// validateISBN checks the correctness of an ISBN
-// func validateISBN(fl validator.FieldLevel) bool {
-// 	isbn := fl.Field().String()
-// 	// Implementation of ISBN validation
-// 	return true
-// }
+// isbn10To13 converts a valid ISBN-10 to the ISBN-13 of the same book
+func isbn10To13(isbn string) string {
+	digits := "978" + isbn[:9]
+
+	sum := 0
+	for i := 0; i < 12; i++ {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += weight * int(digits[i]-'0')
+	}
+
+	return digits + string(rune('0'+(10-sum%10)%10))
+}
@@ -0,0 +1,40 @@
+package book
+
+import "testing"
+
+func TestNormalizeISBN(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{name: "isbn-10", input: "0306406152", want: "9780306406157", wantOK: true},
+		{name: "isbn-10 with hyphens", input: "0-306-40615-2", want: "9780306406157", wantOK: true},
+		{name: "isbn-10 with spaces", input: " 0 306 40615 2 ", want: "9780306406157", wantOK: true},
+		{name: "isbn-10 with X checksum", input: "080442957X", want: "9780804429573", wantOK: true},
+		{name: "isbn-10 with lowercase x checksum", input: "0-8044-2957-x", want: "9780804429573", wantOK: true},
+		{name: "another isbn-10 with X checksum", input: "043942089X", want: "9780439420891", wantOK: true},
+		{name: "isbn-13", input: "9780306406157", want: "9780306406157", wantOK: true},
+		{name: "isbn-13 with hyphens", input: "978-0-306-40615-7", want: "9780306406157", wantOK: true},
+		{name: "isbn-10 with wrong check digit", input: "0306406153"},
+		{name: "isbn-10 with X instead of digit", input: "0306406150X"},
+		{name: "isbn-10 with X not last", input: "03064X6152"},
+		{name: "isbn-10 with X where check digit is a digit", input: "030640615X"},
+		{name: "isbn-13 with wrong check digit", input: "9780306406158"},
+		{name: "isbn-13 with X checksum", input: "978030640615X"},
+		{name: "letters", input: "03064O6152"},
+		{name: "too short", input: "030640615"},
+		{name: "too long", input: "97803064061570"},
+		{name: "empty", input: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NormalizeISBN(tt.input)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("NormalizeISBN(%q) = %q, %v, want %q, %v", tt.input, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package cart
 
-import "time"
+import (
+	"time"
+
+	"github.com/bookshop/api/pkg/money"
+)
 
 // CartItemRequest represents a request to add an item to the cart
 type CartItemRequest struct {
@@ -10,22 +14,22 @@ type CartItemRequest struct {
 
 // CartItemResponse represents a cart item in the API response
 type CartItemResponse struct {
-	ID        int       `json:"id"`
-	BookID    int       `json:"book_id"`
-	Title     string    `json:"title"`
-	Author    string    `json:"author"`
-	Price     float64   `json:"price"`
-	Count     int       `json:"count"`
-	Total     float64   `json:"total"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        int         `json:"id"`
+	BookID    int         `json:"book_id"`
+	Title     string      `json:"title"`
+	Author    string      `json:"author"`
+	Price     money.Money `json:"price"`
+	Count     int         `json:"count"`
+	Total     money.Money `json:"total"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
 }
 
 // CartResponse represents a cart in the API response
 type CartResponse struct {
 	Items      []CartItemResponse `json:"items"`
 	TotalItems int                `json:"total_items"`
-	TotalPrice float64            `json:"total_price"`
+	TotalPrice money.Money        `json:"total_price"`
 }
 
 // UpdateCartItemRequest represents a request to update the quantity of an item in the cart
@@ -5,12 +5,14 @@ import (
 
 	bookmodels "github.com/bookshop/api/internal/app/book/models"
 	domainmodels "github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/pkg/money"
 )
 
 // CartItem represents a cart item for service operations
 type CartItem struct {
 	BookID    int
 	Book      *bookmodels.Book
+	Quantity  int
 	AddedAt   time.Time
 	ExpiresAt time.Time
 }
@@ -23,13 +25,15 @@ type Cart struct {
 
 // CartItemRequest represents a request to add an item to the cart
 type CartItemRequest struct {
-	BookID int
+	BookID   int
+	Quantity int
 }
 
 // CartResponse represents a cart response
 type CartResponse struct {
-	Items     []CartItemResponse
-	TotalCost float64
+	Items         []CartItemResponse
+	TotalQuantity int
+	TotalCost     money.Money
 }
 
 // CartItemResponse represents a cart item in API response
@@ -37,7 +41,9 @@ type CartItemResponse struct {
 	BookID    int
 	Title     string
 	Author    string
-	Price     float64
+	Price     money.Money
+	Quantity  int
+	Subtotal  money.Money
 	AddedAt   time.Time
 	ExpiresAt time.Time
 }
@@ -46,6 +52,7 @@ type CartItemResponse struct {
 func (ci *CartItem) ToDomain() domainmodels.CartItem {
 	domainItem := domainmodels.CartItem{
 		BookID:    ci.BookID,
+		Quantity:  ci.Quantity,
 		AddedAt:   ci.AddedAt,
 		ExpiresAt: ci.ExpiresAt,
 	}
@@ -62,6 +69,7 @@ func (ci *CartItem) ToDomain() domainmodels.CartItem {
 func CartItemFromDomain(item domainmodels.CartItem) CartItem {
 	serviceItem := CartItem{
 		BookID:    item.BookID,
+		Quantity:  item.Quantity,
 		AddedAt:   item.AddedAt,
 		ExpiresAt: item.ExpiresAt,
 	}
@@ -104,6 +112,7 @@ func CartFromDomain(cart *domainmodels.Cart) *Cart {
 // CartItemRequestToDomain converts service request to domain model
 func (cir *CartItemRequest) ToDomain() domainmodels.CartItemRequest {
 	return domainmodels.CartItemRequest{
-		BookID: cir.BookID,
+		BookID:   cir.BookID,
+		Quantity: cir.Quantity,
 	}
 }
@@ -19,10 +19,11 @@ func NewModule(
 	cartRepo repositories.CartRepository,
 	bookRepo repositories.BookRepository,
 	txManager repositories.TransactionManager,
+	pricing services.PricingService,
 	logger logger.Logger,
 ) *Module {
 	// Create service
-	service := NewService(cartRepo, bookRepo, txManager, logger)
+	service := NewService(cartRepo, bookRepo, txManager, pricing, logger)
 
 	// Create handler
 	handler := handlers.NewCartHandler(service)
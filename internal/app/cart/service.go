@@ -11,6 +11,7 @@ import (
 	"github.com/bookshop/api/internal/domain/repositories"
 	"github.com/bookshop/api/internal/domain/services"
 	"github.com/bookshop/api/pkg/logger"
+	"github.com/bookshop/api/pkg/money"
 )
 
 const (
@@ -23,6 +24,7 @@ type Service struct {
 	cartRepo  repositories.CartRepository
 	bookRepo  repositories.BookRepository
 	txManager repositories.TransactionManager
+	pricing   services.PricingService
 	logger    logger.Logger
 }
 
@@ -31,53 +33,152 @@ func NewService(
 	cartRepo repositories.CartRepository,
 	bookRepo repositories.BookRepository,
 	txManager repositories.TransactionManager,
+	pricing services.PricingService,
 	logger logger.Logger,
 ) services.CartService {
 	return &Service{
 		cartRepo:  cartRepo,
 		bookRepo:  bookRepo,
 		txManager: txManager,
+		pricing:   pricing,
 		logger:    logger,
 	}
 }
 
-// AddItem adds an item to the user's cart
+// AddItem adds copies of a book to the user's cart
 func (s *Service) AddItem(ctx context.Context, userID int, input models.CartItemRequest) error {
-	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
-		// Check if the book exists
-		book, err := s.bookRepo.GetByID(txCtx, input.BookID)
-		if err != nil {
-			if errors.Is(err, repositories.ErrNotFound) {
-				return domainerrors.ErrBookNotFound
-			}
-			return fmt.Errorf("error getting book: %w", err)
+	quantity := input.Quantity
+	if quantity <= 0 {
+		quantity = 1
+	}
+
+	// Copies already in the cart are added to
+	item, err := s.cartRepo.GetItem(ctx, userID, input.BookID)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrNotFound) {
+			return fmt.Errorf("error getting cart item: %w", err)
+		}
+		item = &models.CartItem{
+			BookID:  input.BookID,
+			AddedAt: time.Now(),
 		}
+	}
+
+	return s.saveItem(ctx, userID, item, item.Quantity+quantity)
+}
 
-		// Check if the book is in stock
-		if book.Stock <= 0 {
-			return domainerrors.ErrOutOfStock
+// SetItemQuantity sets the number of copies of a book in the user's cart
+func (s *Service) SetItemQuantity(ctx context.Context, userID int, bookID int, quantity int) error {
+	if quantity <= 0 {
+		return fmt.Errorf("%w: quantity must be positive", domainerrors.ErrInvalidData)
+	}
+
+	item, err := s.getItem(ctx, userID, bookID)
+	if err != nil {
+		return err
+	}
+
+	return s.saveItem(ctx, userID, item, quantity)
+}
+
+// IncrementItem changes the number of copies of a book in the user's cart by delta
+func (s *Service) IncrementItem(ctx context.Context, userID int, bookID int, delta int) error {
+	item, err := s.getItem(ctx, userID, bookID)
+	if err != nil {
+		return err
+	}
+
+	quantity := item.Quantity + delta
+	if quantity <= 0 {
+		return s.RemoveItem(ctx, userID, bookID)
+	}
+
+	return s.saveItem(ctx, userID, item, quantity)
+}
+
+// getItem returns the cart item for the book or ErrItemNotFound
+func (s *Service) getItem(ctx context.Context, userID int, bookID int) (*models.CartItem, error) {
+	item, err := s.cartRepo.GetItem(ctx, userID, bookID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, domainerrors.ErrItemNotFound
 		}
+		return nil, fmt.Errorf("error getting cart item: %w", err)
+	}
+	return item, nil
+}
 
-		// Add item to cart
-		expiresAt := time.Now().Add(ItemExpirationTime)
-		if err := s.cartRepo.AddItem(txCtx, userID, input.BookID, expiresAt); err != nil {
-			return fmt.Errorf("error adding item to cart: %w", err)
+// saveItem checks that the requested quantity is in stock and saves the item
+func (s *Service) saveItem(ctx context.Context, userID int, item *models.CartItem, quantity int) error {
+	// Check if the book exists
+	book, err := s.bookRepo.GetByID(ctx, item.BookID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return domainerrors.ErrBookNotFound
 		}
+		return fmt.Errorf("error getting book: %w", err)
+	}
+
+	// Check if enough copies are in stock
+	if book.Stock < quantity {
+		return domainerrors.ErrOutOfStock
+	}
+
+	item.Quantity = quantity
+	item.ExpiresAt = time.Now().Add(ItemExpirationTime)
+
+	if err := s.cartRepo.SaveItem(ctx, userID, *item); err != nil {
+		return fmt.Errorf("error saving cart item: %w", err)
+	}
 
-		return nil
-	})
+	return nil
 }
 
-// GetCart returns the user's cart
-func (s *Service) GetCart(ctx context.Context, userID int) (*models.CartResponse, error) {
+// GetCart returns the user's cart priced in the currency
+func (s *Service) GetCart(ctx context.Context, userID int, currency string) (*models.CartResponse, error) {
 	// Get user's cart
 	cart, err := s.cartRepo.GetCart(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("error getting cart: %w", err)
 	}
 
+	// Load book details for prices
+	bookIDs := make([]int, len(cart.Items))
+	for i, item := range cart.Items {
+		bookIDs[i] = item.BookID
+	}
+
+	books, err := s.bookRepo.GetBooksByIDs(ctx, bookIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error getting books: %w", err)
+	}
+
+	// Price books in the requested currency
+	rate, err := s.pricing.PriceBooks(ctx, books, currency)
+	if err != nil {
+		return nil, fmt.Errorf("error pricing books: %w", err)
+	}
+
+	booksByID := make(map[int]*models.Book, len(books))
+	for i := range books {
+		booksByID[books[i].ID] = &books[i]
+	}
+
+	for i := range cart.Items {
+		cart.Items[i].Book = booksByID[cart.Items[i].BookID]
+	}
+
 	// Convert model to response
-	response := cart.ToResponse()
+	response, err := cart.ToResponse()
+	if err != nil {
+		return nil, fmt.Errorf("error building cart response: %w", err)
+	}
+
+	response.Currency = rate.To
+	if response.TotalCost.Currency == "" {
+		response.TotalCost = money.Zero(rate.To)
+	}
+
 	return &response, nil
 }
 
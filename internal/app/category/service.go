@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
 	"github.com/bookshop/api/internal/domain/models"
 	"github.com/bookshop/api/internal/domain/repositories"
 	"github.com/bookshop/api/internal/domain/services"
@@ -14,8 +15,8 @@ import (
 
 // Error definitions
 var (
-	ErrCategoryNotFound = errors.New("category not found")
-	ErrCategoryExists   = errors.New("category with this name already exists")
+	ErrCategoryNotFound = domainerrors.ErrCategoryNotFound
+	ErrCategoryExists   = domainerrors.ErrCategoryExists
 )
 
 // Service implements services.CategoryService interface
@@ -44,9 +45,17 @@ func (s *Service) Create(ctx context.Context, input models.CategoryCreate) (*mod
 		return nil, fmt.Errorf("error checking if category exists: %w", err)
 	}
 
+	// Check if the parent exists
+	if input.ParentID != nil {
+		if err := s.checkParent(ctx, *input.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
 	// Create category
 	category := &models.Category{
 		Name:      input.Name,
+		ParentID:  input.ParentID,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -84,6 +93,100 @@ func (s *Service) List(ctx context.Context) ([]models.Category, error) {
 	return categories, nil
 }
 
+// Tree returns all categories as a tree of root categories, siblings are ordered by name
+func (s *Service) Tree(ctx context.Context) ([]models.CategoryNode, error) {
+	// Categories are listed ordered by name, so children are added in order
+	categories, err := s.categoryRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting category list: %w", err)
+	}
+
+	children := make(map[int][]models.Category, len(categories))
+	var roots []models.Category
+	for _, category := range categories {
+		if category.ParentID == nil {
+			roots = append(roots, category)
+		} else {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		}
+	}
+
+	return buildTree(roots, children), nil
+}
+
+// buildTree returns the nodes of the categories with their descendants
+func buildTree(categories []models.Category, children map[int][]models.Category) []models.CategoryNode {
+	nodes := make([]models.CategoryNode, len(categories))
+	for i, category := range categories {
+		nodes[i] = models.CategoryNode{
+			Category: category,
+			Children: buildTree(children[category.ID], children),
+		}
+	}
+	return nodes
+}
+
+// Path returns the breadcrumb path of a category from the root down to the category itself
+func (s *Service) Path(ctx context.Context, id int) ([]models.Category, error) {
+	path, err := s.categoryRepo.GetPath(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("error getting category path: %w", err)
+	}
+
+	return path, nil
+}
+
+// Move moves a category under another parent or to the root.
+// A category cannot be moved under itself or one of its descendants
+func (s *Service) Move(ctx context.Context, id int, input models.CategoryMove) (*models.Category, error) {
+	// Check if the category exists
+	if _, err := s.categoryRepo.GetByID(ctx, id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("error getting category: %w", err)
+	}
+
+	// Check if the parent exists
+	if input.ParentID != nil {
+		if err := s.checkParent(ctx, *input.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.categoryRepo.Move(ctx, id, input.ParentID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrCategoryNotFound
+		}
+		if errors.Is(err, domainerrors.ErrCategoryCycle) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error moving category: %w", err)
+	}
+
+	if input.ParentID != nil {
+		s.logger.Info("Category moved", "categoryID", id, "parentID", *input.ParentID)
+	} else {
+		s.logger.Info("Category moved to the root", "categoryID", id)
+	}
+
+	return s.GetByID(ctx, id)
+}
+
+// checkParent checks that the parent category of a category exists
+func (s *Service) checkParent(ctx context.Context, parentID int) error {
+	if _, err := s.categoryRepo.GetByID(ctx, parentID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return fmt.Errorf("%w: parent category %d not found", domainerrors.ErrInvalidData, parentID)
+		}
+		return fmt.Errorf("error getting parent category: %w", err)
+	}
+	return nil
+}
+
 // Update updates a category
 func (s *Service) Update(ctx context.Context, id int, input models.CategoryUpdate) (*models.Category, error) {
 	// Get category
@@ -114,20 +217,46 @@ func (s *Service) Update(ctx context.Context, id int, input models.CategoryUpdat
 	return category, nil
 }
 
-// Delete deletes a category
+// Delete soft deletes a category, categories with subcategories or books are kept
 func (s *Service) Delete(ctx context.Context, id int) error {
-	// Check if the category exists
-	if _, err := s.categoryRepo.GetByID(ctx, id); err != nil {
+	if err := s.categoryRepo.Delete(ctx, id); err != nil {
 		if errors.Is(err, repositories.ErrNotFound) {
 			return ErrCategoryNotFound
 		}
-		return fmt.Errorf("error getting category: %w", err)
-	}
-
-	// Delete category
-	if err := s.categoryRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, domainerrors.ErrCategoryHasChildren) || errors.Is(err, domainerrors.ErrCategoryHasBooks) {
+			return err
+		}
 		return fmt.Errorf("error deleting category: %w", err)
 	}
 
+	s.logger.Info("Category deleted", "categoryID", id)
+
 	return nil
 }
+
+// Restore restores a deleted category in its place in the tree
+func (s *Service) Restore(ctx context.Context, id int) (*models.Category, error) {
+	if err := s.categoryRepo.Restore(ctx, id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrCategoryNotFound
+		}
+		if errors.Is(err, domainerrors.ErrCategoryParentDeleted) || errors.Is(err, domainerrors.ErrCategoryExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error restoring category: %w", err)
+	}
+
+	s.logger.Info("Category restored", "categoryID", id)
+
+	return s.GetByID(ctx, id)
+}
+
+// ListDeleted returns a list of deleted categories, the most recently deleted first
+func (s *Service) ListDeleted(ctx context.Context) ([]models.Category, error) {
+	categories, err := s.categoryRepo.ListDeleted(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting deleted category list: %w", err)
+	}
+
+	return categories, nil
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/pkg/money"
 )
 
 // CreateOrderRequest represents an order creation request
@@ -14,17 +15,19 @@ type CreateOrderRequest struct {
 
 // OrderItemResponse represents an order item in the API response
 type OrderItemResponse struct {
-	BookID int     `json:"book_id"`
-	Title  string  `json:"title"`
-	Author string  `json:"author"`
-	Price  float64 `json:"price"`
+	BookID       int         `json:"book_id"`
+	Title        string      `json:"title"`
+	Author       string      `json:"author"`
+	ISBN         string      `json:"isbn,omitempty"`
+	CategoryName string      `json:"category_name,omitempty"`
+	Price        money.Money `json:"price"`
 }
 
 // OrderResponse represents an order in the API response
 type OrderResponse struct {
 	ID         int                 `json:"id"`
 	Status     string              `json:"status"`
-	TotalPrice float64             `json:"total_price"`
+	TotalPrice money.Money         `json:"total_price"`
 	Items      []OrderItemResponse `json:"items"`
 	CreatedAt  time.Time           `json:"created_at"`
 }
@@ -40,14 +43,14 @@ func fromModel(order *models.Order) *OrderResponse {
 	}
 
 	for _, item := range order.Items {
-		if item.Book != nil {
-			response.Items = append(response.Items, OrderItemResponse{
-				BookID: item.BookID,
-				Title:  item.Book.Title,
-				Author: item.Book.Author,
-				Price:  item.Price,
-			})
-		}
+		response.Items = append(response.Items, OrderItemResponse{
+			BookID:       item.BookID,
+			Title:        item.Title,
+			Author:       item.Author,
+			ISBN:         item.ISBN,
+			CategoryName: item.CategoryName,
+			Price:        item.Price,
+		})
 	}
 
 	return response
@@ -5,16 +5,22 @@ import (
 
 	bookmodels "github.com/bookshop/api/internal/app/book/models"
 	domainmodels "github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/pkg/money"
 )
 
 // OrderItem represents an order item for service operations
 type OrderItem struct {
-	ID        int
-	OrderID   int
-	BookID    int
-	Book      *bookmodels.Book
-	Price     float64
-	CreatedAt time.Time
+	ID           int
+	OrderID      int
+	BookID       int
+	Book         *bookmodels.Book
+	Title        string
+	Author       string
+	ISBN         string
+	CategoryName string
+	Price        money.Money
+	Quantity     int
+	CreatedAt    time.Time
 }
 
 // Order represents an order model for service operations
@@ -22,7 +28,7 @@ type Order struct {
 	ID         int
 	UserID     int
 	Status     string
-	TotalPrice float64
+	TotalPrice money.Money
 	Items      []OrderItem
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
@@ -32,27 +38,36 @@ type Order struct {
 type OrderResponse struct {
 	ID         int
 	Status     string
-	TotalPrice float64
+	TotalPrice money.Money
 	Items      []OrderItemResponse
 	CreatedAt  time.Time
 }
 
 // OrderItemResponse represents an order item in API response
 type OrderItemResponse struct {
-	BookID int
-	Title  string
-	Author string
-	Price  float64
+	BookID       int
+	Title        string
+	Author       string
+	ISBN         string
+	CategoryName string
+	Price        money.Money
+	Quantity     int
+	Subtotal     money.Money
 }
 
 // OrderItemToDomain converts service order item to domain model
 func (oi *OrderItem) ToDomain() domainmodels.OrderItem {
 	domainItem := domainmodels.OrderItem{
-		ID:        oi.ID,
-		OrderID:   oi.OrderID,
-		BookID:    oi.BookID,
-		Price:     oi.Price,
-		CreatedAt: oi.CreatedAt,
+		ID:           oi.ID,
+		OrderID:      oi.OrderID,
+		BookID:       oi.BookID,
+		Title:        oi.Title,
+		Author:       oi.Author,
+		ISBN:         oi.ISBN,
+		CategoryName: oi.CategoryName,
+		Price:        oi.Price,
+		Quantity:     oi.Quantity,
+		CreatedAt:    oi.CreatedAt,
 	}
 
 	if oi.Book != nil {
@@ -66,11 +81,16 @@ func (oi *OrderItem) ToDomain() domainmodels.OrderItem {
 // OrderItemFromDomain converts domain order item to service model
 func OrderItemFromDomain(item domainmodels.OrderItem) OrderItem {
 	serviceItem := OrderItem{
-		ID:        item.ID,
-		OrderID:   item.OrderID,
-		BookID:    item.BookID,
-		Price:     item.Price,
-		CreatedAt: item.CreatedAt,
+		ID:           item.ID,
+		OrderID:      item.OrderID,
+		BookID:       item.BookID,
+		Title:        item.Title,
+		Author:       item.Author,
+		ISBN:         item.ISBN,
+		CategoryName: item.CategoryName,
+		Price:        item.Price,
+		Quantity:     item.Quantity,
+		CreatedAt:    item.CreatedAt,
 	}
 
 	if item.Book != nil {
@@ -21,11 +21,13 @@ func NewModule(
 	cartRepo repositories.CartRepository,
 	bookRepo repositories.BookRepository,
 	txManager repositories.TransactionManager,
+	pricing services.PricingService,
 	logger logger.Logger,
 	profileCacheService *service.ProfileCacheService,
+	events services.EventBus,
 ) *Module {
 	// Create service
-	service := NewService(orderRepo, cartRepo, bookRepo, txManager, logger, profileCacheService)
+	service := NewService(orderRepo, cartRepo, bookRepo, txManager, pricing, logger, profileCacheService, events)
 
 	// Create handler
 	handler := handlers.NewCheckoutHandler(service)
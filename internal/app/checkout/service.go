@@ -12,15 +12,10 @@ import (
 	"github.com/bookshop/api/internal/domain/services"
 	"github.com/bookshop/api/internal/service"
 	"github.com/bookshop/api/pkg/logger"
+	"github.com/bookshop/api/pkg/money"
 )
 
 const (
-	// OrderStatusNew status for new orders
-	OrderStatusNew = "new"
-	// OrderStatusPaid status for paid orders
-	OrderStatusPaid = "paid"
-	// OrderStatusCanceled status for canceled orders
-	OrderStatusCanceled = "canceled"
 	// CartLockDuration duration of cart lock during checkout
 	CartLockDuration = 5 * time.Minute
 )
@@ -31,8 +26,10 @@ type Service struct {
 	cartRepo            repositories.CartRepository
 	bookRepo            repositories.BookRepository
 	txManager           repositories.TransactionManager
+	pricing             services.PricingService
 	logger              logger.Logger
 	profileCacheService *service.ProfileCacheService
+	events              services.EventBus
 }
 
 // NewService creates a new instance of the checkout service
@@ -41,21 +38,25 @@ func NewService(
 	cartRepo repositories.CartRepository,
 	bookRepo repositories.BookRepository,
 	txManager repositories.TransactionManager,
+	pricing services.PricingService,
 	logger logger.Logger,
 	profileCacheService *service.ProfileCacheService,
+	events services.EventBus,
 ) services.CheckoutService {
 	return &Service{
 		orderRepo:           orderRepo,
 		cartRepo:            cartRepo,
 		bookRepo:            bookRepo,
 		txManager:           txManager,
+		pricing:             pricing,
 		logger:              logger,
 		profileCacheService: profileCacheService,
+		events:              events,
 	}
 }
 
-// Checkout processes an order from the user's cart
-func (s *Service) Checkout(ctx context.Context, userID int) (*models.Order, error) {
+// Checkout processes an order from the user's cart priced in the currency
+func (s *Service) Checkout(ctx context.Context, userID int, currency string) (*models.Order, error) {
 	var order *models.Order
 
 	// Execute the checkout in a transaction
@@ -82,23 +83,37 @@ func (s *Service) Checkout(ctx context.Context, userID int) (*models.Order, erro
 			return fmt.Errorf("error getting books: %w", err)
 		}
 
-		// Check if all books are in stock
+		// Deleted books are no longer sold, their cart items are left out of the order
+		if len(books) == 0 {
+			return domainerrors.ErrEmptyCart
+		}
+
+		// Check if enough copies of every book are in stock
+		quantities := cart.Quantities()
 		for _, book := range books {
-			if book.Stock <= 0 {
+			if book.Stock < quantities[book.ID] {
 				return domainerrors.ErrOutOfStock
 			}
 		}
 
+		// Price books in the order currency, the rate is frozen in the order
+		rate, err := s.pricing.PriceBooks(txCtx, books, currency)
+		if err != nil {
+			return fmt.Errorf("error pricing books: %w", err)
+		}
+
 		// Create order
 		order = &models.Order{
-			UserID:     userID,
-			Status:     OrderStatusNew,
-			TotalPrice: 0,
-			Items:      make([]models.OrderItem, len(cart.Items)),
+			UserID:       userID,
+			Status:       models.OrderStatusPending,
+			Currency:     rate.To,
+			ExchangeRate: rate,
+			TotalPrice:   money.Zero(rate.To),
+			Items:        make([]models.OrderItem, 0, len(books)),
 		}
 
 		// Calculate total price and create order items
-		for i, item := range cart.Items {
+		for _, item := range cart.Items {
 			var book *models.Book
 			for _, b := range books {
 				if b.ID == item.BookID {
@@ -108,14 +123,17 @@ func (s *Service) Checkout(ctx context.Context, userID int) (*models.Order, erro
 			}
 
 			if book == nil {
-				return domainerrors.ErrBookNotFound
+				continue
 			}
 
-			order.Items[i] = models.OrderItem{
-				BookID: book.ID,
-				Price:  book.Price,
+			// Book data is copied into the item, the order does not change with the book
+			orderItem := models.NewOrderItem(book, item.Quantity)
+			order.Items = append(order.Items, orderItem)
+			totalPrice, err := order.TotalPrice.Add(orderItem.Subtotal())
+			if err != nil {
+				return fmt.Errorf("error calculating order total: %w", err)
 			}
-			order.TotalPrice += book.Price
+			order.TotalPrice = totalPrice
 		}
 
 		// Save order
@@ -123,11 +141,14 @@ func (s *Service) Checkout(ctx context.Context, userID int) (*models.Order, erro
 			return fmt.Errorf("error creating order: %w", err)
 		}
 
-		// Update book stock
-		for _, item := range cart.Items {
-			if err := s.bookRepo.DecrementStock(txCtx, item.BookID, 1); err != nil {
-				return fmt.Errorf("error updating book stock: %w", err)
-			}
+		// Update book stock of the ordered books, they are reserved in a stable order
+		// so concurrent checkouts cannot deadlock
+		reserved := make(map[int]int, len(order.Items))
+		for _, item := range order.Items {
+			reserved[item.BookID] += item.Quantity
+		}
+		if err := s.bookRepo.ReserveBooks(txCtx, reserved); err != nil {
+			return fmt.Errorf("error updating book stock: %w", err)
 		}
 
 		// Clear cart
@@ -157,14 +178,14 @@ func (s *Service) GetOrderByID(ctx context.Context, orderID int, userID int) (*m
 	order, err := s.orderRepo.GetByID(ctx, orderID)
 	if err != nil {
 		if errors.Is(err, repositories.ErrNotFound) {
-			return nil, fmt.Errorf("order not found")
+			return nil, domainerrors.ErrOrderNotFound
 		}
 		return nil, fmt.Errorf("error getting order: %w", err)
 	}
 
 	// Check if the order belongs to the user
 	if order.UserID != userID {
-		return nil, fmt.Errorf("order not found")
+		return nil, domainerrors.ErrOrderNotFound
 	}
 
 	return order, nil
@@ -181,9 +202,91 @@ func (s *Service) GetOrdersByUserID(ctx context.Context, userID int) ([]models.O
 	return orders, nil
 }
 
-// UpdateOrderStatus updates order status
-func (s *Service) UpdateOrderStatus(ctx context.Context, orderID int, status string) error {
+// ListOrdersByUserID returns a page of user's order history
+func (s *Service) ListOrdersByUserID(ctx context.Context, filter models.OrderFilter) (*models.OrderListResponse, error) {
+	// Set default values for pagination
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = 10
+	}
+
+	orders, pageInfo, err := s.orderRepo.ListByUserID(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error getting order list: %w", err)
+	}
+
+	response := &models.OrderListResponse{
+		Orders:     orders,
+		TotalCount: pageInfo.TotalCount,
+		PageSize:   filter.PageSize,
+		TotalPages: pageInfo.TotalPages(filter.PageSize),
+	}
+	response.NextCursor, response.PrevCursor = pageInfo.Cursors()
+
+	// Page numbers only make sense in page mode
+	if filter.After == nil && filter.Before == nil {
+		response.Page = filter.Page
+	}
+
+	return response, nil
+}
+
+// UpdateOrderStatus moves an order to a new status allowed by the order lifecycle
+// and records who changed it in the order history
+func (s *Service) UpdateOrderStatus(ctx context.Context, orderID int, status string, changedBy *int, reason string) error {
+	order, change, err := s.changeOrderStatus(ctx, orderID, nil, status, changedBy, reason)
+	if err != nil {
+		return err
+	}
+
+	// Update the cache after successful status update
+	// Use non-blocking async version to avoid blocking the request
+	if s.profileCacheService != nil {
+		// Update the specific order in cache asynchronously
+		s.profileCacheService.UpdateOrderInCacheAsync(order.UserID, order)
+	}
+
+	if status == models.OrderStatusCanceled {
+		s.publishOrderCanceled(ctx, order, change)
+	}
+
+	return nil
+}
+
+// CancelOrder cancels an order of the user that is not paid yet and returns its books to stock
+func (s *Service) CancelOrder(ctx context.Context, orderID int, userID int, reason string) (*models.Order, error) {
+	order, change, err := s.changeOrderStatus(ctx, orderID, &userID, models.OrderStatusCanceled, &userID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Order canceled", "orderID", orderID, "userID", userID)
+
+	// Invalidate user profile cache, it lists the order with its old status
+	// Use non-blocking async version to avoid blocking the request
+	if s.profileCacheService != nil {
+		s.profileCacheService.InvalidateUserCacheAsync(userID)
+	}
+
+	s.publishOrderCanceled(ctx, order, change)
+
+	return order, nil
+}
+
+// changeOrderStatus moves an order to the status in a transaction and returns it with the recorded change.
+// If ownerID is set the order must belong to that user. Books of canceled orders are returned to stock
+func (s *Service) changeOrderStatus(
+	ctx context.Context,
+	orderID int,
+	ownerID *int,
+	status string,
+	changedBy *int,
+	reason string,
+) (*models.Order, *models.OrderStatusChange, error) {
 	var order *models.Order
+	var change *models.OrderStatusChange
 
 	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
 		// Check if the order exists
@@ -191,40 +294,91 @@ func (s *Service) UpdateOrderStatus(ctx context.Context, orderID int, status str
 		order, err = s.orderRepo.GetByID(txCtx, orderID)
 		if err != nil {
 			if errors.Is(err, repositories.ErrNotFound) {
-				return fmt.Errorf("order not found")
+				return domainerrors.ErrOrderNotFound
 			}
 			return fmt.Errorf("error getting order: %w", err)
 		}
 
-		// Check status validity
-		switch status {
-		case OrderStatusPaid, OrderStatusCanceled:
-			// Valid status
-		default:
-			return fmt.Errorf("invalid order status")
+		// Orders of other users are not found
+		if ownerID != nil && order.UserID != *ownerID {
+			return domainerrors.ErrOrderNotFound
+		}
+
+		// Check that the lifecycle allows the change
+		if status == models.OrderStatusCanceled && order.Status == models.OrderStatusCanceled {
+			return domainerrors.ErrOrderCanceled
+		}
+		if err := models.ValidateOrderTransition(order.Status, status); err != nil {
+			return err
 		}
 
-		// Update status
-		if err := s.orderRepo.UpdateStatus(txCtx, orderID, status); err != nil {
+		// Update status, the order must not have been changed in the meantime
+		change = &models.OrderStatusChange{
+			OrderID:    orderID,
+			FromStatus: order.Status,
+			ToStatus:   status,
+			ChangedBy:  changedBy,
+			Reason:     reason,
+		}
+		if err := s.orderRepo.UpdateStatus(txCtx, change); err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				return fmt.Errorf("%w: the order status has changed, reload the order",
+					domainerrors.ErrInvalidOrderTransition)
+			}
 			return fmt.Errorf("error updating order status: %w", err)
 		}
 
+		// Books of a canceled order go back to stock along with the status change
+		if status == models.OrderStatusCanceled {
+			quantities := make(map[int]int, len(order.Items))
+			for _, item := range order.Items {
+				quantities[item.BookID] += item.Quantity
+			}
+			if err := s.bookRepo.ReleaseBooks(txCtx, quantities); err != nil {
+				return fmt.Errorf("error returning books to stock: %w", err)
+			}
+		}
+
 		// Update order status in our local variable
 		order.Status = status
+		order.UpdatedAt = change.CreatedAt
 
 		return nil
 	})
 
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	// Update the cache after successful status update
-	// Use non-blocking async version to avoid blocking the request
-	if s.profileCacheService != nil && order != nil {
-		// Update the specific order in cache asynchronously
-		s.profileCacheService.UpdateOrderInCacheAsync(order.UserID, order)
+	return order, change, nil
+}
+
+// publishOrderCanceled publishes the cancellation of an order
+func (s *Service) publishOrderCanceled(ctx context.Context, order *models.Order, change *models.OrderStatusChange) {
+	s.events.Publish(ctx, models.OrderCanceledEvent{
+		OrderID:    order.ID,
+		UserID:     order.UserID,
+		CanceledBy: change.ChangedBy,
+		Reason:     change.Reason,
+		Items:      order.Items,
+		CanceledAt: change.CreatedAt,
+	})
+}
+
+// GetOrderStatusHistory returns the status changes of an order, oldest first
+func (s *Service) GetOrderStatusHistory(ctx context.Context, orderID int) ([]models.OrderStatusChange, error) {
+	// Check if the order exists
+	if _, err := s.orderRepo.GetByID(ctx, orderID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, domainerrors.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("error getting order: %w", err)
 	}
 
-	return nil
+	history, err := s.orderRepo.GetStatusHistory(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting order status history: %w", err)
+	}
+
+	return history, nil
 }
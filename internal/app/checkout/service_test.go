@@ -0,0 +1,146 @@
+package checkout
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/pkg/logger"
+	"github.com/bookshop/api/pkg/money"
+	"go.uber.org/zap"
+)
+
+var errClearCart = errors.New("clear cart failed")
+
+// fakeTx holds the orders written within a transaction until it is committed
+type fakeTx struct {
+	orders []models.Order
+}
+
+type fakeTxKey struct{}
+
+// fakeTxManager commits the orders of a transaction to the store when its function succeeds
+type fakeTxManager struct {
+	repositories.TransactionManager
+	store *fakeStore
+}
+
+func (m *fakeTxManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx := &fakeTx{}
+	if err := fn(context.WithValue(ctx, fakeTxKey{}, tx)); err != nil {
+		return err
+	}
+	m.store.orders = append(m.store.orders, tx.orders...)
+	return nil
+}
+
+// fakeStore holds the committed orders
+type fakeStore struct {
+	orders []models.Order
+}
+
+type fakeOrderRepo struct {
+	repositories.OrderRepository
+	store *fakeStore
+}
+
+func (r *fakeOrderRepo) Create(ctx context.Context, order *models.Order) error {
+	if tx, ok := ctx.Value(fakeTxKey{}).(*fakeTx); ok {
+		tx.orders = append(tx.orders, *order)
+		return nil
+	}
+	r.store.orders = append(r.store.orders, *order)
+	return nil
+}
+
+type fakeCartRepo struct {
+	repositories.CartRepository
+	cart     *models.Cart
+	clearErr error
+}
+
+func (r *fakeCartRepo) GetCart(ctx context.Context, userID int) (*models.Cart, error) {
+	return r.cart, nil
+}
+
+func (r *fakeCartRepo) ClearCart(ctx context.Context, userID int) error {
+	return r.clearErr
+}
+
+type fakeBookRepo struct {
+	repositories.BookRepository
+	books      []models.Book
+	reserveErr error
+}
+
+func (r *fakeBookRepo) GetBooksByIDs(ctx context.Context, ids []int) ([]models.Book, error) {
+	return r.books, nil
+}
+
+func (r *fakeBookRepo) ReserveBooks(ctx context.Context, quantities map[int]int) error {
+	return r.reserveErr
+}
+
+type fakePricing struct {
+	services.PricingService
+}
+
+func (p *fakePricing) PriceBooks(ctx context.Context, books []models.Book, currency string) (money.Rate, error) {
+	return money.IdentityRate(money.DefaultCurrency), nil
+}
+
+func TestCheckoutTransaction(t *testing.T) {
+	tests := []struct {
+		name       string
+		reserveErr error
+		clearErr   error
+		wantErr    error
+		wantOrders int
+	}{
+		{name: "success", wantOrders: 1},
+		{name: "book sold out while reserving", reserveErr: domainerrors.ErrOutOfStock, wantErr: domainerrors.ErrOutOfStock},
+		{name: "cart not cleared", clearErr: errClearCart, wantErr: errClearCart},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeStore{}
+			s := NewService(
+				&fakeOrderRepo{store: store},
+				&fakeCartRepo{
+					cart:     &models.Cart{UserID: 1, Items: []models.CartItem{{BookID: 7, Quantity: 2}}},
+					clearErr: tt.clearErr,
+				},
+				&fakeBookRepo{
+					books:      []models.Book{{ID: 7, Title: "Dune", Price: money.New(1000, "USD"), Stock: 5}},
+					reserveErr: tt.reserveErr,
+				},
+				&fakeTxManager{store: store},
+				&fakePricing{},
+				logger.Logger{Logger: zap.NewNop()},
+				nil,
+				nil,
+			)
+
+			order, err := s.Checkout(context.Background(), 1, "USD")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Checkout() error = %v, want %v", err, tt.wantErr)
+				}
+				if order != nil {
+					t.Errorf("Checkout() order = %+v, want nil", order)
+				}
+			} else if err != nil {
+				t.Fatalf("Checkout() unexpected error: %v", err)
+			}
+
+			if len(store.orders) != tt.wantOrders {
+				t.Errorf("committed orders = %d, want %d", len(store.orders), tt.wantOrders)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package order
+
+// Constants for pagination
+const (
+	// DefaultPageSize - default page size
+	DefaultPageSize = 10
+
+	// MaxPageSize - maximum page size
+	MaxPageSize = 100
+)
+
+// Constants for order exports
+const (
+	// ExportBufferSize - size of the buffer order exports are written through in bytes
+	ExportBufferSize = 64 << 10
+)
@@ -0,0 +1,157 @@
+package order
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/pkg/money"
+)
+
+// dateLayout is the layout of dates without a time in date range bounds
+const dateLayout = "2006-01-02"
+
+// OrderListRequest represents a request for getting a list of orders of all users.
+// Dates are RFC 3339 times or days, a day as the upper bound includes the whole day.
+// Total bounds are in the base currency unless they name one, e.g. "12.50 EUR".
+// Pages are selected by number, or by the after or before cursor of a previous page
+type OrderListRequest struct {
+	Statuses     []string `query:"status"`
+	UserID       int      `query:"user_id"`
+	From         string   `query:"from"`
+	To           string   `query:"to"`
+	MinTotal     string   `query:"min_total"`
+	MaxTotal     string   `query:"max_total"`
+	Page         int      `query:"page"`
+	PageSize     int      `query:"page_size"`
+	After        string   `query:"after"`
+	Before       string   `query:"before"`
+	IncludeTotal bool     `query:"include_total"`
+}
+
+// ToModel converts OrderListRequest to OrderFilter model
+func (r *OrderListRequest) ToModel() (models.OrderFilter, error) {
+	statuses := make([]string, 0, len(r.Statuses))
+	for _, value := range r.Statuses {
+		// Statuses may be repeated or comma-separated
+		for _, status := range strings.Split(value, ",") {
+			status = strings.ToLower(strings.TrimSpace(status))
+			if status == "" {
+				continue
+			}
+			if !models.IsOrderStatus(status) {
+				return models.OrderFilter{}, fmt.Errorf("%w: %q", domainerrors.ErrInvalidOrderStatus, status)
+			}
+			statuses = append(statuses, status)
+		}
+	}
+
+	if r.UserID < 0 {
+		return models.OrderFilter{}, fmt.Errorf("%w: invalid user ID %d", domainerrors.ErrInvalidData, r.UserID)
+	}
+
+	from, err := parseDateBound(r.From, false)
+	if err != nil {
+		return models.OrderFilter{}, err
+	}
+
+	to, err := parseDateBound(r.To, true)
+	if err != nil {
+		return models.OrderFilter{}, err
+	}
+
+	minTotal, err := parseTotalBound(r.MinTotal)
+	if err != nil {
+		return models.OrderFilter{}, err
+	}
+
+	maxTotal, err := parseTotalBound(r.MaxTotal)
+	if err != nil {
+		return models.OrderFilter{}, err
+	}
+
+	after, err := models.DecodeCursor(r.After)
+	if err != nil {
+		return models.OrderFilter{}, err
+	}
+
+	before, err := models.DecodeCursor(r.Before)
+	if err != nil {
+		return models.OrderFilter{}, err
+	}
+	if after != nil && before != nil {
+		return models.OrderFilter{}, fmt.Errorf("%w: after and before cannot be used together", domainerrors.ErrInvalidCursor)
+	}
+
+	page := r.Page
+	if page <= 0 {
+		page = 1
+	}
+
+	pageSize := r.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	return models.OrderFilter{
+		UserID:       r.UserID,
+		Statuses:     statuses,
+		CreatedFrom:  from,
+		CreatedTo:    to,
+		MinTotal:     minTotal,
+		MaxTotal:     maxTotal,
+		Page:         page,
+		PageSize:     pageSize,
+		After:        after,
+		Before:       before,
+		IncludeTotal: r.IncludeTotal,
+	}, nil
+}
+
+// parseDateBound parses an RFC 3339 time or a day, nil if the value is empty.
+// A day as the upper bound is the start of the next day, as upper bounds are exclusive
+func parseDateBound(value string, upper bool) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	day, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid date %q", domainerrors.ErrInvalidData, value)
+	}
+	if upper {
+		day = day.AddDate(0, 0, 1)
+	}
+	return &day, nil
+}
+
+// parseTotalBound parses an amount in the base currency or with its own, nil if the value is empty
+func parseTotalBound(value string) (*money.Money, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	currency := money.DefaultCurrency
+	amount, explicit, found := strings.Cut(value, " ")
+	if found {
+		currency = strings.ToUpper(strings.TrimSpace(explicit))
+	}
+
+	total, err := money.Parse(amount, currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidData, err)
+	}
+
+	return &total, nil
+}
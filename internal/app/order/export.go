@@ -0,0 +1,88 @@
+package order
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/bookshop/api/internal/domain/models"
+)
+
+// csvExportColumns are the columns of order exports, every row is an order item with its order
+var csvExportColumns = []string{
+	"order_id", "created_at", "status", "customer_id", "customer_email",
+	"currency", "exchange_rate", "order_total",
+	"book_id", "isbn", "title", "author", "category", "price", "quantity", "subtotal",
+}
+
+// csvOrderWriter writes orders as CSV rows under a header row
+type csvOrderWriter struct {
+	writer *csv.Writer
+	header bool
+}
+
+func newCSVOrderWriter(w io.Writer) *csvOrderWriter {
+	return &csvOrderWriter{writer: csv.NewWriter(w)}
+}
+
+// writeHeader writes the header row unless it is written already
+func (w *csvOrderWriter) writeHeader() error {
+	if w.header {
+		return nil
+	}
+	w.header = true
+	return w.writer.Write(csvExportColumns)
+}
+
+// Write writes a row per item of the order, the header row is written before the first one.
+// Amounts are in the order currency
+func (w *csvOrderWriter) Write(order *models.Order) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	var email string
+	if order.Customer != nil {
+		email = order.Customer.Email
+	}
+
+	orderColumns := []string{
+		strconv.Itoa(order.ID),
+		order.CreatedAt.UTC().Format(time.RFC3339),
+		order.Status,
+		strconv.Itoa(order.UserID),
+		email,
+		order.Currency,
+		order.ExchangeRate.Decimal(),
+		order.TotalPrice.Decimal(),
+	}
+
+	for _, item := range order.Items {
+		row := append(orderColumns[:len(orderColumns):len(orderColumns)],
+			strconv.Itoa(item.BookID),
+			item.ISBN,
+			item.Title,
+			item.Author,
+			item.CategoryName,
+			item.Price.Decimal(),
+			strconv.Itoa(item.Quantity),
+			item.Subtotal().Decimal(),
+		)
+		if err := w.writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close writes the header row if there were no orders and flushes the rows
+func (w *csvOrderWriter) Close() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	w.writer.Flush()
+	return w.writer.Error()
+}
@@ -0,0 +1,272 @@
+package order
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/pkg/money"
+	"github.com/labstack/echo/v4"
+)
+
+// Handler handles HTTP requests of order management
+type Handler struct {
+	orderService services.OrderService
+}
+
+// NewHandler creates a new instance of the order handler
+func NewHandler(orderService services.OrderService) *Handler {
+	return &Handler{
+		orderService: orderService,
+	}
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// errorResponse creates a consistent error response with just an error message
+func errorResponse(message string) *ErrorResponse {
+	return &ErrorResponse{
+		Error: message,
+	}
+}
+
+// handleError maps domain errors to appropriate HTTP responses
+func handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domainerrors.ErrOrderNotFound):
+		return c.JSON(http.StatusNotFound, errorResponse(err.Error()))
+	case errors.Is(err, domainerrors.ErrInvalidOrderStatus),
+		errors.Is(err, domainerrors.ErrInvalidData),
+		errors.Is(err, domainerrors.ErrInvalidCursor),
+		errors.Is(err, domainerrors.ErrUnsupportedCurrency),
+		errors.Is(err, money.ErrInvalidAmount),
+		errors.Is(err, money.ErrInvalidCurrency):
+		return c.JSON(http.StatusBadRequest, errorResponse(err.Error()))
+	case errors.Is(err, domainerrors.ErrInvalidOrderTransition),
+		errors.Is(err, domainerrors.ErrOrderCanceled):
+		return c.JSON(http.StatusConflict, errorResponse(err.Error()))
+	case errors.Is(err, domainerrors.ErrExchangeRateUnavailable):
+		return c.JSON(http.StatusServiceUnavailable, errorResponse(err.Error()))
+	case errors.Is(err, domainerrors.ErrPaymentGateway):
+		return c.JSON(http.StatusBadGateway, errorResponse(err.Error()))
+	default:
+		return c.JSON(http.StatusInternalServerError, errorResponse("internal server error"))
+	}
+}
+
+// RegisterAdminRoutes registers order management routes on the admin router group.
+// Reading orders requires canRead and changing their status canWrite
+func (h *Handler) RegisterAdminRoutes(router *echo.Group, canRead, canWrite echo.MiddlewareFunc) {
+	orders := router.Group("/orders")
+	{
+		orders.GET("", h.listOrders, canRead)
+		orders.GET("/export", h.exportOrders, canRead)
+		orders.GET("/:id", h.getOrder, canRead)
+		orders.PUT("/:id/status", h.updateOrderStatus, canWrite)
+		orders.GET("/:id/status-history", h.getOrderStatusHistory, canRead)
+	}
+}
+
+// listOrders handles the request to get a list of orders of all users
+// @Summary List orders
+// @Description Returns a page of orders of all users with their customers newest first, without items
+// @Tags admin,orders
+// @Produce json
+// @Security BearerAuth
+// @Param status query []string false "Statuses, repeated or comma-separated"
+// @Param user_id query int false "Customer ID"
+// @Param from query string false "Placed at or after, an RFC 3339 time or a day, e.g. 2024-05-01"
+// @Param to query string false "Placed before, an RFC 3339 time or a day, which is included"
+// @Param min_total query string false "Minimum total in the base currency, or with its own, e.g. 12.50 EUR"
+// @Param max_total query string false "Maximum total in the base currency, or with its own, e.g. 12.50 EUR"
+// @Param page query int false "Page number, ignored with a cursor"
+// @Param page_size query int false "Page size"
+// @Param after query string false "Cursor of the page to read the next page after"
+// @Param before query string false "Cursor of the page to read the previous page before"
+// @Param include_total query bool false "Count the total in cursor mode too, page mode always counts it"
+// @Success 200 {object} models.OrderListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/orders [get]
+func (h *Handler) listOrders(c echo.Context) error {
+	var req OrderListRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid request format"))
+	}
+
+	filter, err := req.ToModel()
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	orders, err := h.orderService.List(c.Request().Context(), filter)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, orders)
+}
+
+// exportOrders handles the request to export orders
+// @Summary Export orders
+// @Description Streams every order matching the filter of the order list as CSV, ignoring pages.
+// @Description Every row is an order item with its order and customer. Amounts are in the order currency
+// @Tags admin,orders
+// @Produce text/csv
+// @Security BearerAuth
+// @Param status query []string false "Statuses, repeated or comma-separated"
+// @Param user_id query int false "Customer ID"
+// @Param from query string false "Placed at or after, an RFC 3339 time or a day, e.g. 2024-05-01"
+// @Param to query string false "Placed before, an RFC 3339 time or a day, which is included"
+// @Param min_total query string false "Minimum total in the base currency, or with its own, e.g. 12.50 EUR"
+// @Param max_total query string false "Maximum total in the base currency, or with its own, e.g. 12.50 EUR"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/orders/export [get]
+func (h *Handler) exportOrders(c echo.Context) error {
+	var req OrderListRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid request format"))
+	}
+
+	filter, err := req.ToModel()
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	// All orders take longer to send than the server write timeout allows
+	res := c.Response()
+	if err := http.NewResponseController(res).SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return handleError(c, err)
+	}
+
+	res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="orders.csv"`)
+
+	if err := h.orderService.Export(c.Request().Context(), filter, res); err != nil {
+		// Once streaming has started the status is sent, the client gets a truncated file
+		if res.Committed {
+			return err
+		}
+		res.Header().Del(echo.HeaderContentDisposition)
+		return handleError(c, err)
+	}
+
+	return nil
+}
+
+// getOrder handles the request to get an order by ID
+// @Summary Get order
+// @Description Returns an order of any user with its items, customer and status history
+// @Tags admin,orders
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Success 200 {object} models.Order
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/orders/{id} [get]
+func (h *Handler) getOrder(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid order ID"))
+	}
+
+	order, err := h.orderService.GetByID(c.Request().Context(), id)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, order)
+}
+
+// updateOrderStatus handles the request to change the status of an order
+// @Summary Change order status
+// @Description Moves an order to a new status of its lifecycle:
+// @Description pending → awaiting_payment → paid → fulfilling → shipped → delivered.
+// @Description Orders become paid when their payment is captured, not by this request.
+// @Description Unpaid orders can be canceled, returning their books to stock, and paid ones refunded,
+// @Description returning their captured payment. The change is recorded in the order history with the reason as a note
+// @Tags admin,orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Param status body models.OrderStatusRequest true "New status"
+// @Success 200 {object} models.Order
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Router /admin/orders/{id}/status [put]
+func (h *Handler) updateOrderStatus(c echo.Context) error {
+	// Get user ID of the admin from context
+	userID := c.Get("userID").(int)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid order ID"))
+	}
+
+	var req models.OrderStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid request format"))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse(err.Error()))
+	}
+
+	order, err := h.orderService.UpdateStatus(c.Request().Context(), id, req.Status, userID, req.Reason)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, order)
+}
+
+// getOrderStatusHistory handles the request to get the status history of an order
+// @Summary Get order status history
+// @Description Returns the status changes of an order oldest first, with the user who made each change
+// @Tags admin,orders
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Success 200 {array} models.OrderStatusChange
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/orders/{id}/status-history [get]
+func (h *Handler) getOrderStatusHistory(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid order ID"))
+	}
+
+	history, err := h.orderService.GetStatusHistory(c.Request().Context(), id)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, history)
+}
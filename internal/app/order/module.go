@@ -0,0 +1,41 @@
+package order
+
+import (
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+// Module represents an order management module
+type Module struct {
+	Handler *Handler
+	Service services.OrderService
+}
+
+// NewModule creates a new instance of the order module
+func NewModule(
+	orderRepo repositories.OrderRepository,
+	userRepo repositories.UserRepository,
+	checkout services.CheckoutService,
+	payments services.PaymentService,
+	pricing services.PricingService,
+	logger logger.Logger,
+) *Module {
+	// Create service
+	service := NewService(orderRepo, userRepo, checkout, payments, pricing, logger)
+
+	// Create handler
+	handler := NewHandler(service)
+
+	return &Module{
+		Handler: handler,
+		Service: service,
+	}
+}
+
+// RegisterAdminRoutes registers order management routes on the admin router group.
+// Reading orders requires canRead and changing their status canWrite
+func (m *Module) RegisterAdminRoutes(router *echo.Group, canRead, canWrite echo.MiddlewareFunc) {
+	m.Handler.RegisterAdminRoutes(router, canRead, canWrite)
+}
@@ -0,0 +1,166 @@
+package order
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/pkg/logger"
+)
+
+// Service implements services.OrderService interface.
+// Status changes go through the checkout service, so cancellations still restock books and publish events.
+// Refunds go through the payment service, so the customer gets the money back
+type Service struct {
+	orderRepo repositories.OrderRepository
+	userRepo  repositories.UserRepository
+	checkout  services.CheckoutService
+	payments  services.PaymentService
+	pricing   services.PricingService
+	logger    logger.Logger
+}
+
+// NewService creates a new instance of the order management service
+func NewService(
+	orderRepo repositories.OrderRepository,
+	userRepo repositories.UserRepository,
+	checkout services.CheckoutService,
+	payments services.PaymentService,
+	pricing services.PricingService,
+	logger logger.Logger,
+) services.OrderService {
+	return &Service{
+		orderRepo: orderRepo,
+		userRepo:  userRepo,
+		checkout:  checkout,
+		payments:  payments,
+		pricing:   pricing,
+		logger:    logger,
+	}
+}
+
+// List returns a page of orders matching the filter with their customers, without items
+func (s *Service) List(ctx context.Context, filter models.OrderFilter) (*models.OrderListResponse, error) {
+	if err := s.totalBoundsToBase(ctx, &filter); err != nil {
+		return nil, err
+	}
+
+	orders, pageInfo, err := s.orderRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error getting order list: %w", err)
+	}
+
+	response := &models.OrderListResponse{
+		Orders:     orders,
+		TotalCount: pageInfo.TotalCount,
+		PageSize:   filter.PageSize,
+		TotalPages: pageInfo.TotalPages(filter.PageSize),
+	}
+	response.NextCursor, response.PrevCursor = pageInfo.Cursors()
+
+	// Page numbers only make sense in page mode
+	if filter.After == nil && filter.Before == nil {
+		response.Page = filter.Page
+	}
+
+	return response, nil
+}
+
+// totalBoundsToBase converts the total bounds of the filter to the base currency orders are compared in
+func (s *Service) totalBoundsToBase(ctx context.Context, filter *models.OrderFilter) error {
+	if filter.MinTotal != nil {
+		minTotal, err := s.pricing.ToBase(ctx, *filter.MinTotal)
+		if err != nil {
+			return fmt.Errorf("error converting minimum total: %w", err)
+		}
+		filter.MinTotal = &minTotal
+	}
+	if filter.MaxTotal != nil {
+		maxTotal, err := s.pricing.ToBase(ctx, *filter.MaxTotal)
+		if err != nil {
+			return fmt.Errorf("error converting maximum total: %w", err)
+		}
+		filter.MaxTotal = &maxTotal
+	}
+	return nil
+}
+
+// GetByID returns an order with its items, customer and status history
+func (s *Service) GetByID(ctx context.Context, id int) (*models.Order, error) {
+	order, err := s.orderRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, domainerrors.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("error getting order: %w", err)
+	}
+
+	// Customers may have been deleted since, the order is still shown
+	user, err := s.userRepo.GetByID(ctx, order.UserID)
+	switch {
+	case err == nil:
+		order.Customer = &models.OrderCustomer{ID: user.ID, Email: user.Email}
+	case !errors.Is(err, domainerrors.ErrUserNotFound):
+		return nil, fmt.Errorf("error getting customer: %w", err)
+	}
+
+	order.StatusHistory, err = s.orderRepo.GetStatusHistory(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting order status history: %w", err)
+	}
+
+	return order, nil
+}
+
+// UpdateStatus moves an order to a new status and returns the updated order.
+// Orders are only paid by capturing a payment, refunding one returns its captured payment
+func (s *Service) UpdateStatus(ctx context.Context, id int, status string, changedBy int, note string) (*models.Order, error) {
+	switch status {
+	case models.OrderStatusPaid:
+		return nil, fmt.Errorf("%w: orders are paid when their payment is captured", domainerrors.ErrInvalidOrderTransition)
+	case models.OrderStatusRefunded:
+		if err := s.payments.RefundOrder(ctx, id, changedBy, note); err != nil {
+			return nil, err
+		}
+	default:
+		if err := s.checkout.UpdateOrderStatus(ctx, id, status, &changedBy, note); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.Info("Order status changed by admin", "order_id", id, "status", status, "admin_id", changedBy)
+
+	return s.GetByID(ctx, id)
+}
+
+// GetStatusHistory returns the status changes of an order, oldest first
+func (s *Service) GetStatusHistory(ctx context.Context, id int) ([]models.OrderStatusChange, error) {
+	return s.checkout.GetOrderStatusHistory(ctx, id)
+}
+
+// Export writes every order matching the filter to w as CSV.
+// Output is buffered, so errors of the query are returned before anything is written to w
+func (s *Service) Export(ctx context.Context, filter models.OrderFilter, w io.Writer) error {
+	if err := s.totalBoundsToBase(ctx, &filter); err != nil {
+		return err
+	}
+
+	buffered := bufio.NewWriterSize(w, ExportBufferSize)
+	writer := newCSVOrderWriter(buffered)
+
+	if err := s.orderRepo.Stream(ctx, filter, writer.Write); err != nil {
+		return fmt.Errorf("error exporting orders: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return buffered.Flush()
+}
@@ -0,0 +1,14 @@
+package payment
+
+import "time"
+
+const (
+	// SignatureHeader - header of payment provider webhooks carrying the payload signature
+	SignatureHeader = "X-Payment-Signature"
+
+	// MaxWebhookSize - maximum size of a webhook payload in bytes
+	MaxWebhookSize = 64 << 10
+
+	// WebhookClaimTimeout - time after which a webhook event whose processing never finished is processed again
+	WebhookClaimTimeout = 5 * time.Minute
+)
@@ -0,0 +1,6 @@
+package payment
+
+// PayOrderRequest represents a request to pay an order
+type PayOrderRequest struct {
+	Token string `json:"token" validate:"required,max=255"` // Payment method token issued to the client by the provider
+}
@@ -0,0 +1,185 @@
+package payment
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/labstack/echo/v4"
+)
+
+// Handler handles HTTP requests related to payments
+type Handler struct {
+	paymentService services.PaymentService
+}
+
+// NewHandler creates a new instance of the payment handler
+func NewHandler(paymentService services.PaymentService) *Handler {
+	return &Handler{
+		paymentService: paymentService,
+	}
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// errorResponse creates a consistent error response with just an error message
+func errorResponse(message string) *ErrorResponse {
+	return &ErrorResponse{
+		Error: message,
+	}
+}
+
+// handleError maps domain errors to appropriate HTTP responses
+func handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domainerrors.ErrOrderNotFound),
+		errors.Is(err, domainerrors.ErrPaymentNotFound),
+		errors.Is(err, domainerrors.ErrUnknownPaymentProvider):
+		return c.JSON(http.StatusNotFound, errorResponse(err.Error()))
+	case errors.Is(err, domainerrors.ErrInvalidData):
+		return c.JSON(http.StatusBadRequest, errorResponse(err.Error()))
+	case errors.Is(err, domainerrors.ErrInvalidWebhookSignature):
+		return c.JSON(http.StatusUnauthorized, errorResponse(err.Error()))
+	case errors.Is(err, domainerrors.ErrPaymentDeclined):
+		return c.JSON(http.StatusPaymentRequired, errorResponse(err.Error()))
+	case errors.Is(err, domainerrors.ErrOrderAlreadyPaid),
+		errors.Is(err, domainerrors.ErrOrderCanceled),
+		errors.Is(err, domainerrors.ErrInvalidOrderTransition),
+		errors.Is(err, domainerrors.ErrPaymentInProgress),
+		errors.Is(err, domainerrors.ErrInvalidPaymentTransition),
+		errors.Is(err, domainerrors.ErrWebhookInProgress):
+		return c.JSON(http.StatusConflict, errorResponse(err.Error()))
+	case errors.Is(err, domainerrors.ErrPaymentGateway):
+		return c.JSON(http.StatusBadGateway, errorResponse(err.Error()))
+	default:
+		return c.JSON(http.StatusInternalServerError, errorResponse("internal server error"))
+	}
+}
+
+// RegisterRoutes registers the payment webhook routes, webhooks are authenticated by their signature
+func (h *Handler) RegisterRoutes(router *echo.Group) {
+	router.POST("/webhooks/payments/:provider", h.handleWebhook)
+}
+
+// RegisterProtectedRoutes registers order payment routes, the router group is expected to require authentication
+func (h *Handler) RegisterProtectedRoutes(router *echo.Group) {
+	orders := router.Group("/orders")
+	{
+		orders.POST("/:id/pay", h.payOrder)
+		orders.GET("/:id/payments", h.listPayments)
+	}
+}
+
+// payOrder handles the request to pay an order
+// @Summary Pay order
+// @Description Charges the order total to the payment method and marks the order paid once the payment is captured.
+// @Description A pending order awaits payment from the first attempt on. Declined orders can be paid again
+// @Tags orders,payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Param request body PayOrderRequest true "Payment method"
+// @Success 200 {object} models.Payment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 402 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Router /orders/{id}/pay [post]
+func (h *Handler) payOrder(c echo.Context) error {
+	// Get user ID from context
+	userID := c.Get("userID").(int)
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid order ID"))
+	}
+
+	var req PayOrderRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid request format"))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse(err.Error()))
+	}
+
+	payment, err := h.paymentService.PayOrder(c.Request().Context(), orderID, userID, req.Token)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, payment)
+}
+
+// listPayments handles the request to get the payments of an order
+// @Summary List order payments
+// @Description Returns every attempt to pay an order of the current user, oldest first
+// @Tags orders,payments
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Success 200 {array} models.Payment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /orders/{id}/payments [get]
+func (h *Handler) listPayments(c echo.Context) error {
+	// Get user ID from context
+	userID := c.Get("userID").(int)
+
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid order ID"))
+	}
+
+	payments, err := h.paymentService.ListPayments(c.Request().Context(), orderID, userID)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, payments)
+}
+
+// handleWebhook handles a notification of a payment provider
+// @Summary Payment provider webhook
+// @Description Applies a payment change made on the side of the provider to the payment and its order.
+// @Description The payload must be signed by the provider in the X-Payment-Signature header.
+// @Description Every event is stored and applied once, redeliveries and events overtaken by later ones are acknowledged.
+// @Description Events that cannot be applied yet get a 404 or 409 so the provider delivers them again
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param provider path string true "Payment provider, e.g. fake"
+// @Param X-Payment-Signature header string true "Payload signature"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /webhooks/payments/{provider} [post]
+func (h *Handler) handleWebhook(c echo.Context) error {
+	// The signature covers the exact payload, so it is read as it is
+	payload, err := io.ReadAll(http.MaxBytesReader(c.Response(), c.Request().Body, MaxWebhookSize))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid request body"))
+	}
+
+	err = h.paymentService.HandleWebhook(c.Request().Context(), c.Param("provider"), payload, c.Request().Header.Get(SignatureHeader))
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,45 @@
+package payment
+
+import (
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+// Module represents an order payment module
+type Module struct {
+	Handler *Handler
+	Service services.PaymentService
+}
+
+// NewModule creates a new instance of the payment module charging through the gateway
+func NewModule(
+	orderRepo repositories.OrderRepository,
+	paymentRepo repositories.PaymentRepository,
+	webhookRepo repositories.WebhookEventRepository,
+	gateway services.PaymentGateway,
+	checkout services.CheckoutService,
+	logger logger.Logger,
+) *Module {
+	// Create service
+	service := NewService(orderRepo, paymentRepo, webhookRepo, gateway, checkout, logger)
+
+	// Create handler
+	handler := NewHandler(service)
+
+	return &Module{
+		Handler: handler,
+		Service: service,
+	}
+}
+
+// RegisterRoutes registers the public payment webhook routes
+func (m *Module) RegisterRoutes(router *echo.Group) {
+	m.Handler.RegisterRoutes(router)
+}
+
+// RegisterProtectedRoutes registers order payment routes on the authenticated router group
+func (m *Module) RegisterProtectedRoutes(router *echo.Group) {
+	m.Handler.RegisterProtectedRoutes(router)
+}
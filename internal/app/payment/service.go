@@ -0,0 +1,424 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/pkg/logger"
+)
+
+// Service implements services.PaymentService interface.
+// Order status changes go through the checkout service, so they are recorded in the order history
+type Service struct {
+	orderRepo   repositories.OrderRepository
+	paymentRepo repositories.PaymentRepository
+	webhookRepo repositories.WebhookEventRepository
+	gateway     services.PaymentGateway
+	checkout    services.CheckoutService
+	logger      logger.Logger
+}
+
+// NewService creates a new instance of the payment service
+func NewService(
+	orderRepo repositories.OrderRepository,
+	paymentRepo repositories.PaymentRepository,
+	webhookRepo repositories.WebhookEventRepository,
+	gateway services.PaymentGateway,
+	checkout services.CheckoutService,
+	logger logger.Logger,
+) services.PaymentService {
+	return &Service{
+		orderRepo:   orderRepo,
+		paymentRepo: paymentRepo,
+		webhookRepo: webhookRepo,
+		gateway:     gateway,
+		checkout:    checkout,
+		logger:      logger,
+	}
+}
+
+// PayOrder authorizes and captures the order total with the payment method, then marks the order paid
+func (s *Service) PayOrder(ctx context.Context, orderID int, userID int, token string) (*models.Payment, error) {
+	order, err := s.checkout.GetOrderByID(ctx, orderID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch order.Status {
+	case models.OrderStatusPending:
+		// The order waits for its payment from the first attempt on
+		if err := s.checkout.UpdateOrderStatus(ctx, orderID, models.OrderStatusAwaitingPayment, &userID, ""); err != nil {
+			return nil, err
+		}
+	case models.OrderStatusAwaitingPayment:
+	case models.OrderStatusCanceled:
+		return nil, domainerrors.ErrOrderCanceled
+	default:
+		return nil, domainerrors.ErrOrderAlreadyPaid
+	}
+
+	payment := &models.Payment{
+		OrderID:  orderID,
+		Provider: s.gateway.Name(),
+		Status:   models.PaymentStatusPending,
+		Amount:   order.TotalPrice,
+	}
+	if err := s.paymentRepo.Create(ctx, payment); err != nil {
+		if errors.Is(err, repositories.ErrDuplicateKey) {
+			return nil, domainerrors.ErrPaymentInProgress
+		}
+		return nil, fmt.Errorf("error creating payment: %w", err)
+	}
+
+	// Once the provider is called the payment is seen through even if the client goes away,
+	// the customer may be charged already
+	ctx = context.WithoutCancel(ctx)
+
+	ref, err := s.gateway.Authorize(ctx, models.PaymentAuthorization{
+		PaymentID: payment.ID,
+		OrderID:   orderID,
+		Amount:    payment.Amount,
+		Token:     token,
+	})
+	if err != nil {
+		status := models.PaymentStatusFailed
+		if errors.Is(err, domainerrors.ErrPaymentDeclined) {
+			status = models.PaymentStatusDeclined
+		}
+		s.failPayment(ctx, payment, status, err)
+		return nil, err
+	}
+
+	payment.ProviderRef = ref
+	if err := s.setPaymentStatus(ctx, payment, models.PaymentStatusAuthorized, ""); err != nil {
+		s.voidPayment(ctx, payment, err)
+		return nil, err
+	}
+
+	if err := s.gateway.Capture(ctx, ref, payment.Amount); err != nil {
+		// The authorization is released, the order can be paid again
+		s.voidPayment(ctx, payment, err)
+		return nil, err
+	}
+
+	if err := s.setPaymentStatus(ctx, payment, models.PaymentStatusCaptured, ""); err != nil {
+		// The webhook of the capture may have been applied first, paying the order
+		if current, getErr := s.paymentRepo.GetByProviderRef(ctx, payment.Provider, ref); getErr == nil &&
+			current.Status != models.PaymentStatusAuthorized {
+			return current, nil
+		}
+
+		// Otherwise the provider notifies the capture by webhook, which records it
+		s.logger.Error("Captured payment not recorded", "error", err, "payment_id", payment.ID, "provider_ref", ref)
+		return nil, err
+	}
+
+	if err := s.markOrderPaid(ctx, payment); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Order paid", "order_id", orderID, "payment_id", payment.ID)
+
+	return payment, nil
+}
+
+// markOrderPaid moves the order of a captured payment to paid.
+// If the order can no longer be paid, e.g. it was canceled meanwhile, the payment is refunded.
+// Other errors leave the payment captured, the order is paid when the capture is delivered again
+func (s *Service) markOrderPaid(ctx context.Context, payment *models.Payment) error {
+	err := s.checkout.UpdateOrderStatus(ctx, payment.OrderID, models.OrderStatusPaid, nil, "payment captured")
+	if err == nil {
+		return nil
+	}
+
+	refused, err := s.orderRefusesPayment(ctx, payment.OrderID, err)
+	if !refused {
+		if err != nil {
+			s.logger.Error("Error marking order of a captured payment paid", "error", err, "order_id", payment.OrderID, "payment_id", payment.ID)
+		}
+		return err
+	}
+
+	s.logger.Error("Order of a captured payment cannot be paid, refunding", "error", err, "order_id", payment.OrderID, "payment_id", payment.ID)
+
+	if refundErr := s.refundPayment(ctx, payment, "order could not be paid"); refundErr != nil {
+		s.logger.Error("Error refunding payment", "error", refundErr, "payment_id", payment.ID)
+	}
+
+	return err
+}
+
+// orderRefusesPayment reports whether the error of marking an order paid is a refusal by the order lifecycle.
+// A refused transition may come from a concurrent change of the order, so the order is read again:
+// orders paid meanwhile return no error and orders that can still be paid return the error unrefused
+func (s *Service) orderRefusesPayment(ctx context.Context, orderID int, err error) (bool, error) {
+	switch {
+	case errors.Is(err, domainerrors.ErrOrderNotFound), errors.Is(err, domainerrors.ErrOrderCanceled):
+		return true, err
+	case !errors.Is(err, domainerrors.ErrInvalidOrderTransition):
+		return false, err
+	}
+
+	order, getErr := s.orderRepo.GetByID(ctx, orderID)
+	if getErr != nil {
+		if errors.Is(getErr, repositories.ErrNotFound) {
+			return true, domainerrors.ErrOrderNotFound
+		}
+		return false, err
+	}
+
+	switch {
+	case models.IsOrderPaid(order.Status):
+		return false, nil
+	case order.Status == models.OrderStatusCanceled || order.Status == models.OrderStatusRefunded:
+		return true, err
+	default:
+		return false, err
+	}
+}
+
+// RefundOrder refunds the captured payment of an order and moves the order to refunded.
+// Orders paid before payments were recorded only change their status
+func (s *Service) RefundOrder(ctx context.Context, orderID int, changedBy int, reason string) error {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return domainerrors.ErrOrderNotFound
+		}
+		return fmt.Errorf("error getting order: %w", err)
+	}
+
+	// Money is only returned for orders that can be refunded
+	if err := models.ValidateOrderTransition(order.Status, models.OrderStatusRefunded); err != nil {
+		return err
+	}
+
+	payment, err := s.paymentRepo.GetCapturedByOrderID(ctx, orderID)
+	switch {
+	case err == nil:
+		ctx = context.WithoutCancel(ctx)
+		if err := s.refundPayment(ctx, payment, reason); err != nil {
+			return err
+		}
+	case !errors.Is(err, repositories.ErrNotFound):
+		return fmt.Errorf("error getting payment: %w", err)
+	}
+
+	return s.checkout.UpdateOrderStatus(ctx, orderID, models.OrderStatusRefunded, &changedBy, reason)
+}
+
+// refundPayment refunds a captured payment with the provider and records it
+func (s *Service) refundPayment(ctx context.Context, payment *models.Payment, reason string) error {
+	if err := s.gateway.Refund(ctx, payment.ProviderRef, payment.Amount); err != nil {
+		return err
+	}
+
+	return s.setPaymentStatus(ctx, payment, models.PaymentStatusRefunded, reason)
+}
+
+// voidPayment releases an authorization that will not be captured because of the cause
+// and records the payment as voided
+func (s *Service) voidPayment(ctx context.Context, payment *models.Payment, cause error) {
+	if err := s.gateway.Void(ctx, payment.ProviderRef); err != nil {
+		s.logger.Error("Error voiding payment", "error", err, "payment_id", payment.ID)
+		s.failPayment(ctx, payment, models.PaymentStatusFailed, err)
+		return
+	}
+
+	if err := s.setPaymentStatus(ctx, payment, models.PaymentStatusVoided, cause.Error()); err != nil {
+		s.logger.Error("Error recording voided payment", "error", err, "payment_id", payment.ID)
+	}
+}
+
+// failPayment records that a payment did not go through because of the cause
+func (s *Service) failPayment(ctx context.Context, payment *models.Payment, status string, cause error) {
+	if err := s.setPaymentStatus(ctx, payment, status, cause.Error()); err != nil {
+		s.logger.Error("Error recording failed payment", "error", err, "payment_id", payment.ID)
+	}
+}
+
+// setPaymentStatus moves a payment to the status allowed by the payment lifecycle.
+// The payment is left as it was if it cannot be updated
+func (s *Service) setPaymentStatus(ctx context.Context, payment *models.Payment, status string, reason string) error {
+	from := payment.Status
+	if !models.CanPaymentTransition(from, status) {
+		return fmt.Errorf("%w: from %s to %s", domainerrors.ErrInvalidPaymentTransition, from, status)
+	}
+
+	updated := *payment
+	updated.Status = status
+	updated.FailureReason = reason
+	if err := s.paymentRepo.UpdateStatus(ctx, &updated, from); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return fmt.Errorf("%w: the payment status has changed", domainerrors.ErrInvalidPaymentTransition)
+		}
+		return fmt.Errorf("error updating payment status: %w", err)
+	}
+
+	*payment = updated
+	return nil
+}
+
+// ListPayments returns the payments of an order of the user, oldest first
+func (s *Service) ListPayments(ctx context.Context, orderID int, userID int) ([]models.Payment, error) {
+	// Payments of orders of other users are not found
+	if _, err := s.checkout.GetOrderByID(ctx, orderID, userID); err != nil {
+		return nil, err
+	}
+
+	payments, err := s.paymentRepo.ListByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting payments: %w", err)
+	}
+
+	return payments, nil
+}
+
+// HandleWebhook stores a verified event of the provider and applies it unless a delivery of it was applied before
+func (s *Service) HandleWebhook(ctx context.Context, provider string, payload []byte, signature string) error {
+	if provider != s.gateway.Name() {
+		return domainerrors.ErrUnknownPaymentProvider
+	}
+
+	event, err := s.gateway.VerifyWebhook(payload, signature)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvalidWebhookSignature) {
+			s.logger.Warn("Payment webhook with an invalid signature rejected")
+		}
+		return err
+	}
+
+	stored := &models.StoredWebhookEvent{
+		Provider:    provider,
+		EventID:     event.ID,
+		EventType:   event.Type,
+		ProviderRef: event.ProviderRef,
+		Payload:     payload,
+		Signature:   signature,
+	}
+	if err := s.webhookRepo.Record(ctx, stored); err != nil {
+		return fmt.Errorf("error storing webhook event: %w", err)
+	}
+
+	// Redeliveries of a finished event are acknowledged without applying it again
+	if stored.Status == models.WebhookEventProcessed || stored.Status == models.WebhookEventIgnored {
+		s.logger.Info("Duplicate payment webhook", "event_id", event.ID, "deliveries", stored.Deliveries)
+		return nil
+	}
+
+	// Concurrent deliveries of the event are turned away, the provider delivers it again
+	if err := s.webhookRepo.Claim(ctx, stored.ID, time.Now().Add(-WebhookClaimTimeout)); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return domainerrors.ErrWebhookInProgress
+		}
+		return fmt.Errorf("error claiming webhook event: %w", err)
+	}
+
+	// The event is seen through even if the provider hangs up
+	ctx = context.WithoutCancel(ctx)
+
+	ignored, err := s.applyWebhookEvent(ctx, event)
+	status, message := models.WebhookEventProcessed, ignored
+	switch {
+	case err != nil:
+		status, message = models.WebhookEventFailed, err.Error()
+	case ignored != "":
+		status = models.WebhookEventIgnored
+	}
+
+	if finishErr := s.webhookRepo.Finish(ctx, stored.ID, status, message); finishErr != nil {
+		s.logger.Error("Error finishing webhook event", "error", finishErr, "event_id", event.ID)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Payment webhook handled", "event_id", event.ID, "type", event.Type, "status", status)
+
+	return nil
+}
+
+// applyWebhookEvent moves the payment of an event to the status it reports, along with its order.
+// Events that were overtaken by later ones or have nothing to change are not applied,
+// the reason is returned. Events for payments that are still being made fail, so they are delivered again
+func (s *Service) applyWebhookEvent(ctx context.Context, event *models.PaymentWebhookEvent) (string, error) {
+	status := event.Status()
+	if status == "" {
+		return fmt.Sprintf("event type %q is not handled", event.Type), nil
+	}
+
+	payment, err := s.paymentRepo.GetByProviderRef(ctx, s.gateway.Name(), event.ProviderRef)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return "", domainerrors.ErrPaymentNotFound
+		}
+		return "", fmt.Errorf("error getting payment: %w", err)
+	}
+
+	switch {
+	case payment.Status == models.PaymentStatusCaptured && status == models.PaymentStatusCaptured:
+		// The capture may have been recorded without paying the order, e.g. the database was unavailable
+		return s.payCapturedOrder(ctx, payment)
+	case payment.Status == status:
+		return "payment is " + status + " already", nil
+	case payment.Status == models.PaymentStatusPending:
+		return "", domainerrors.ErrPaymentInProgress
+	case models.PaymentPath(status, payment.Status) != nil:
+		// Events delivered out of order are older than the status the payment has reached
+		return fmt.Sprintf("payment is %s, the event is outdated", payment.Status), nil
+	}
+
+	// Events may skip statuses whose events are still to come, the payment passes through them in order
+	path := models.PaymentPath(payment.Status, status)
+	if path == nil {
+		return fmt.Sprintf("payment is %s and cannot become %s", payment.Status, status), nil
+	}
+
+	for _, step := range path {
+		if err := s.setPaymentStatus(ctx, payment, step, event.Reason); err != nil {
+			return "", err
+		}
+
+		switch step {
+		case models.PaymentStatusCaptured:
+			if err := s.markOrderPaid(ctx, payment); err != nil {
+				return "", err
+			}
+		case models.PaymentStatusRefunded:
+			reason := event.Reason
+			if reason == "" {
+				reason = "payment refunded by the provider"
+			}
+			if err := s.checkout.UpdateOrderStatus(ctx, payment.OrderID, models.OrderStatusRefunded, nil, reason); err != nil {
+				return "", err
+			}
+		}
+		// Voided and failed payments leave the order awaiting payment, it can be paid again
+	}
+
+	return "", nil
+}
+
+// payCapturedOrder marks the order of a payment recorded as captured paid unless it is paid already
+func (s *Service) payCapturedOrder(ctx context.Context, payment *models.Payment) (string, error) {
+	order, err := s.orderRepo.GetByID(ctx, payment.OrderID)
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return "", fmt.Errorf("error getting order: %w", err)
+	}
+	if err == nil && (models.IsOrderPaid(order.Status) || order.Status == models.OrderStatusRefunded) {
+		return "payment is captured already", nil
+	}
+
+	if err := s.markOrderPaid(ctx, payment); err != nil {
+		return "", err
+	}
+
+	return "", nil
+}
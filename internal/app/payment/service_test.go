@@ -0,0 +1,103 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/bookshop/api/internal/pkg/payments"
+	"github.com/bookshop/api/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const testWebhookSecret = "test-webhook-secret"
+
+// fakeWebhookRepo records the stored events, every event is stored as already processed
+// so that verified webhooks are acknowledged without being applied
+type fakeWebhookRepo struct {
+	repositories.WebhookEventRepository
+	recorded []models.StoredWebhookEvent
+}
+
+func (r *fakeWebhookRepo) Record(ctx context.Context, event *models.StoredWebhookEvent) error {
+	event.ID = len(r.recorded) + 1
+	event.Status = models.WebhookEventProcessed
+	event.Deliveries = 1
+	r.recorded = append(r.recorded, *event)
+	return nil
+}
+
+func TestHandleWebhookSignature(t *testing.T) {
+	payload := []byte(`{"id":"evt_1","type":"payment.captured","payment_ref":"pay_1"}`)
+	valid := payments.SignWebhook(testWebhookSecret, payload)
+
+	tests := []struct {
+		name      string
+		provider  string
+		payload   []byte
+		signature string
+		wantErr   error
+	}{
+		{name: "valid signature", payload: payload, signature: valid},
+		{name: "uppercase hex signature", payload: payload, signature: strings.ToUpper(valid)},
+		{name: "missing signature", payload: payload, signature: "", wantErr: domainerrors.ErrInvalidWebhookSignature},
+		{name: "signature not hex", payload: payload, signature: "not-a-signature", wantErr: domainerrors.ErrInvalidWebhookSignature},
+		{name: "truncated signature", payload: payload, signature: valid[:len(valid)-2], wantErr: domainerrors.ErrInvalidWebhookSignature},
+		{
+			name:      "signed with another secret",
+			payload:   payload,
+			signature: payments.SignWebhook("another-secret", payload),
+			wantErr:   domainerrors.ErrInvalidWebhookSignature,
+		},
+		{
+			name:      "tampered payload",
+			payload:   []byte(`{"id":"evt_1","type":"payment.captured","payment_ref":"pay_2"}`),
+			signature: valid,
+			wantErr:   domainerrors.ErrInvalidWebhookSignature,
+		},
+		{
+			name:      "unknown provider",
+			provider:  "stripe",
+			payload:   payload,
+			signature: valid,
+			wantErr:   domainerrors.ErrUnknownPaymentProvider,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway, err := payments.NewFakeGateway(payments.FakeOutcomeSuccess, 0, testWebhookSecret)
+			if err != nil {
+				t.Fatalf("NewFakeGateway() unexpected error: %v", err)
+			}
+			webhooks := &fakeWebhookRepo{}
+			s := NewService(nil, nil, webhooks, gateway, nil, logger.Logger{Logger: zap.NewNop()})
+
+			provider := tt.provider
+			if provider == "" {
+				provider = payments.FakeProviderName
+			}
+
+			err = s.HandleWebhook(context.Background(), provider, tt.payload, tt.signature)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("HandleWebhook() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(webhooks.recorded) != 0 {
+					t.Errorf("HandleWebhook() stored %d events of a rejected webhook", len(webhooks.recorded))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("HandleWebhook() unexpected error: %v", err)
+			}
+			if len(webhooks.recorded) != 1 || webhooks.recorded[0].EventID != "evt_1" {
+				t.Errorf("HandleWebhook() stored events = %+v, want evt_1", webhooks.recorded)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package pricing
+
+// SetPriceOverrideRequest represents a request to set the book price in a currency
+type SetPriceOverrideRequest struct {
+	Amount string `json:"amount" validate:"required"` // Decimal amount in major units, e.g. "12.50"
+}
+
+// RateResponse represents the exchange rate from the base currency
+type RateResponse struct {
+	Base     string `json:"base"`
+	Currency string `json:"currency"`
+	Rate     string `json:"rate"`
+}
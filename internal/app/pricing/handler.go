@@ -0,0 +1,164 @@
+package pricing
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/pkg/money"
+	"github.com/labstack/echo/v4"
+)
+
+// Handler handles HTTP requests related to pricing
+type Handler struct {
+	pricingService services.PricingService
+}
+
+// NewHandler creates a new instance of the pricing handler
+func NewHandler(pricingService services.PricingService) *Handler {
+	return &Handler{
+		pricingService: pricingService,
+	}
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// errorResponse creates a consistent error response with just an error message
+func errorResponse(message string) *ErrorResponse {
+	return &ErrorResponse{
+		Error: message,
+	}
+}
+
+// handleError maps domain errors to appropriate HTTP responses
+func handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domainerrors.ErrBookNotFound),
+		errors.Is(err, domainerrors.ErrNotFound):
+		return c.JSON(http.StatusNotFound, errorResponse(err.Error()))
+	case errors.Is(err, domainerrors.ErrUnsupportedCurrency),
+		errors.Is(err, domainerrors.ErrInvalidData),
+		errors.Is(err, money.ErrInvalidAmount),
+		errors.Is(err, money.ErrInvalidCurrency):
+		return c.JSON(http.StatusBadRequest, errorResponse(err.Error()))
+	case errors.Is(err, domainerrors.ErrExchangeRateUnavailable):
+		return c.JSON(http.StatusServiceUnavailable, errorResponse(err.Error()))
+	default:
+		return c.JSON(http.StatusInternalServerError, errorResponse("internal server error"))
+	}
+}
+
+// RegisterRoutes registers public pricing routes
+func (h *Handler) RegisterRoutes(router *echo.Group) {
+	router.GET("/exchange-rates/:currency", h.getRate)
+}
+
+// RegisterAdminRoutes registers price override routes on the admin router group.
+// The middleware is applied to every route
+func (h *Handler) RegisterAdminRoutes(router *echo.Group, m ...echo.MiddlewareFunc) {
+	prices := router.Group("/books/:id/prices", m...)
+	prices.PUT("/:currency", h.setPriceOverride)
+	prices.DELETE("/:currency", h.deletePriceOverride)
+}
+
+// getRate handles the request to get the exchange rate from the base currency
+// @Summary Get exchange rate
+// @Description Returns the rate used to convert catalog prices to the currency
+// @Tags pricing
+// @Produce json
+// @Param currency path string true "ISO 4217 currency code"
+// @Success 200 {object} RateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /exchange-rates/{currency} [get]
+func (h *Handler) getRate(c echo.Context) error {
+	rate, err := h.pricingService.GetRate(c.Request().Context(), c.Param("currency"))
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, RateResponse{
+		Base:     rate.From,
+		Currency: rate.To,
+		Rate:     rate.Decimal(),
+	})
+}
+
+// setPriceOverride handles the request to set the book price in a currency
+// @Summary Set book price in a currency
+// @Description Overrides the converted base price of the book in the currency
+// @Tags admin,pricing
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param currency path string true "ISO 4217 currency code"
+// @Param price body SetPriceOverrideRequest true "Price"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/books/{id}/prices/{currency} [put]
+func (h *Handler) setPriceOverride(c echo.Context) error {
+	bookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid book ID"))
+	}
+
+	var req SetPriceOverrideRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid request format"))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse(err.Error()))
+	}
+
+	price, err := money.Parse(req.Amount, strings.ToUpper(c.Param("currency")))
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	if err := h.pricingService.SetPriceOverride(c.Request().Context(), bookID, price); err != nil {
+		return handleError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// deletePriceOverride handles the request to remove the book price in a currency
+// @Summary Delete book price in a currency
+// @Description Removes the price override so the converted base price is used again
+// @Tags admin,pricing
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Book ID"
+// @Param currency path string true "ISO 4217 currency code"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/books/{id}/prices/{currency} [delete]
+func (h *Handler) deletePriceOverride(c echo.Context) error {
+	bookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid book ID"))
+	}
+
+	if err := h.pricingService.DeletePriceOverride(c.Request().Context(), bookID, c.Param("currency")); err != nil {
+		return handleError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,43 @@
+package pricing
+
+import (
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+// Module represents a multi-currency pricing module
+type Module struct {
+	Handler *Handler
+	Service services.PricingService
+}
+
+// NewModule creates a new instance of the pricing module
+func NewModule(
+	priceRepo repositories.BookPriceRepository,
+	rates services.ExchangeRateProvider,
+	supported []string,
+	logger logger.Logger,
+) *Module {
+	// Create service
+	service := NewService(priceRepo, rates, supported, logger)
+
+	// Create handler
+	handler := NewHandler(service)
+
+	return &Module{
+		Handler: handler,
+		Service: service,
+	}
+}
+
+// RegisterRoutes registers public pricing routes
+func (m *Module) RegisterRoutes(router *echo.Group) {
+	m.Handler.RegisterRoutes(router)
+}
+
+// RegisterAdminRoutes registers price override routes on the admin router group
+func (m *Module) RegisterAdminRoutes(router *echo.Group, mw ...echo.MiddlewareFunc) {
+	m.Handler.RegisterAdminRoutes(router, mw...)
+}
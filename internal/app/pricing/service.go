@@ -0,0 +1,184 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/pkg/logger"
+	"github.com/bookshop/api/pkg/money"
+)
+
+// Service implements services.PricingService interface
+type Service struct {
+	priceRepo repositories.BookPriceRepository
+	rates     services.ExchangeRateProvider
+	supported map[string]struct{}
+	logger    logger.Logger
+}
+
+// NewService creates a new instance of the pricing service.
+// The base currency is always supported
+func NewService(
+	priceRepo repositories.BookPriceRepository,
+	rates services.ExchangeRateProvider,
+	supported []string,
+	logger logger.Logger,
+) services.PricingService {
+	set := map[string]struct{}{money.DefaultCurrency: {}}
+	for _, currency := range supported {
+		set[strings.ToUpper(currency)] = struct{}{}
+	}
+
+	return &Service{
+		priceRepo: priceRepo,
+		rates:     rates,
+		supported: set,
+		logger:    logger,
+	}
+}
+
+// BaseCurrency returns the currency catalog prices are stored in
+func (s *Service) BaseCurrency() string {
+	return money.DefaultCurrency
+}
+
+// IsSupported checks if the shop sells in the currency
+func (s *Service) IsSupported(currency string) bool {
+	_, ok := s.supported[strings.ToUpper(currency)]
+	return ok
+}
+
+// GetRate returns the rate from the base currency to the currency
+func (s *Service) GetRate(ctx context.Context, currency string) (money.Rate, error) {
+	currency, err := s.resolve(currency)
+	if err != nil {
+		return money.Rate{}, err
+	}
+
+	if currency == money.DefaultCurrency {
+		return money.IdentityRate(currency), nil
+	}
+
+	rate, err := s.rates.GetRate(ctx, money.DefaultCurrency, currency)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrExchangeRateUnavailable) {
+			return money.Rate{}, err
+		}
+		return money.Rate{}, fmt.Errorf("%w: %v", domainerrors.ErrExchangeRateUnavailable, err)
+	}
+
+	return rate, nil
+}
+
+// PriceBooks replaces the book prices with prices in the currency and returns the rate used.
+// Books with a price override in the currency get the override, others the converted base price
+func (s *Service) PriceBooks(ctx context.Context, books []models.Book, currency string) (money.Rate, error) {
+	rate, err := s.GetRate(ctx, currency)
+	if err != nil {
+		return money.Rate{}, err
+	}
+
+	if rate.To == money.DefaultCurrency || len(books) == 0 {
+		return rate, nil
+	}
+
+	bookIDs := make([]int, len(books))
+	for i, book := range books {
+		bookIDs[i] = book.ID
+	}
+
+	overrides, err := s.priceRepo.GetOverrides(ctx, bookIDs, rate.To)
+	if err != nil {
+		return money.Rate{}, fmt.Errorf("error getting price overrides: %w", err)
+	}
+
+	for i := range books {
+		if price, ok := overrides[books[i].ID]; ok {
+			books[i].Price = price
+			continue
+		}
+
+		price, err := books[i].Price.Convert(rate)
+		if err != nil {
+			return money.Rate{}, fmt.Errorf("error converting price of book %d: %w", books[i].ID, err)
+		}
+		books[i].Price = price
+	}
+
+	return rate, nil
+}
+
+// ToBase converts an amount to the base currency
+func (s *Service) ToBase(ctx context.Context, amount money.Money) (money.Money, error) {
+	if amount.Currency == "" || amount.Currency == money.DefaultCurrency {
+		return money.New(amount.Amount, money.DefaultCurrency), nil
+	}
+
+	rate, err := s.GetRate(ctx, amount.Currency)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	return amount.Convert(rate.Inverse())
+}
+
+// SetPriceOverride sets the book price in the currency of price
+func (s *Service) SetPriceOverride(ctx context.Context, bookID int, price money.Money) error {
+	currency, err := s.resolve(price.Currency)
+	if err != nil {
+		return err
+	}
+	if currency == money.DefaultCurrency {
+		return fmt.Errorf("%w: the base currency price is set on the book", domainerrors.ErrInvalidData)
+	}
+	if !price.IsPositive() {
+		return fmt.Errorf("%w: price must be positive", domainerrors.ErrInvalidData)
+	}
+
+	if err := s.priceRepo.SetOverride(ctx, bookID, price); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return domainerrors.ErrBookNotFound
+		}
+		return fmt.Errorf("error setting price override: %w", err)
+	}
+
+	s.logger.Info("Book price override set", "bookID", bookID, "price", price.String())
+	return nil
+}
+
+// DeletePriceOverride removes the book price in the currency
+func (s *Service) DeletePriceOverride(ctx context.Context, bookID int, currency string) error {
+	currency, err := s.resolve(currency)
+	if err != nil {
+		return err
+	}
+
+	if err := s.priceRepo.DeleteOverride(ctx, bookID, currency); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return domainerrors.ErrNotFound
+		}
+		return fmt.Errorf("error deleting price override: %w", err)
+	}
+
+	s.logger.Info("Book price override deleted", "bookID", bookID, "currency", currency)
+	return nil
+}
+
+// resolve normalizes the currency code, defaulting to the base currency,
+// and checks that the shop sells in it
+func (s *Service) resolve(currency string) (string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return money.DefaultCurrency, nil
+	}
+	if !s.IsSupported(currency) {
+		return "", fmt.Errorf("%w: %s", domainerrors.ErrUnsupportedCurrency, currency)
+	}
+	return currency, nil
+}
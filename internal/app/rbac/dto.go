@@ -0,0 +1,41 @@
+package rbac
+
+import (
+	"github.com/bookshop/api/internal/domain/models"
+)
+
+// SetUserRolesRequest represents a request to replace the roles of a user
+type SetUserRolesRequest struct {
+	Roles []string `json:"roles" validate:"required,dive,required"`
+}
+
+// RoleResponse represents a role in API responses
+type RoleResponse struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// UserRolesResponse represents the roles assigned to a user
+type UserRolesResponse struct {
+	UserID int      `json:"user_id"`
+	Roles  []string `json:"roles"`
+}
+
+// fromRoleModel converts Role model to RoleResponse
+func fromRoleModel(role models.Role) RoleResponse {
+	return RoleResponse{
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: role.Permissions,
+	}
+}
+
+// fromRoleModels converts a slice of Role models to RoleResponse slice
+func fromRoleModels(roles []models.Role) []RoleResponse {
+	result := make([]RoleResponse, len(roles))
+	for i, role := range roles {
+		result[i] = fromRoleModel(role)
+	}
+	return result
+}
@@ -0,0 +1,148 @@
+package rbac
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/internal/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// Handler handles HTTP requests related to roles
+type Handler struct {
+	roleService services.RoleService
+}
+
+// NewHandler creates a new instance of the RBAC handler
+func NewHandler(roleService services.RoleService) *Handler {
+	return &Handler{
+		roleService: roleService,
+	}
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// errorResponse creates a consistent error response with just an error message
+func errorResponse(message string) *ErrorResponse {
+	return &ErrorResponse{
+		Error: message,
+	}
+}
+
+// handleError maps domain errors to appropriate HTTP responses
+func handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domainerrors.ErrUserNotFound):
+		return c.JSON(http.StatusNotFound, errorResponse(err.Error()))
+	case errors.Is(err, domainerrors.ErrRoleNotFound):
+		return c.JSON(http.StatusBadRequest, errorResponse(err.Error()))
+	default:
+		return c.JSON(http.StatusInternalServerError, errorResponse("internal server error"))
+	}
+}
+
+// RegisterRoutes registers routes for role management.
+// The router group is expected to require authentication
+func (h *Handler) RegisterRoutes(router *echo.Group) {
+	canReadUsers := middleware.RequirePermission(h.roleService, models.PermissionUsersRead)
+	canWriteRoles := middleware.RequirePermission(h.roleService, models.PermissionRolesWrite)
+
+	router.GET("/roles", h.listRoles, canWriteRoles)
+	router.GET("/users/:id/roles", h.getUserRoles, canReadUsers)
+	router.PUT("/users/:id/roles", h.setUserRoles, canWriteRoles)
+}
+
+// listRoles handles the request to list roles
+// @Summary List roles
+// @Description Returns all roles with the permissions they grant
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} RoleResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/roles [get]
+func (h *Handler) listRoles(c echo.Context) error {
+	roles, err := h.roleService.ListRoles(c.Request().Context())
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, fromRoleModels(roles))
+}
+
+// getUserRoles handles the request to get the roles of a user
+// @Summary Get user roles
+// @Description Returns the roles assigned to a user
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} UserRolesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users/{id}/roles [get]
+func (h *Handler) getUserRoles(c echo.Context) error {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid user ID"))
+	}
+
+	roles, err := h.roleService.GetUserRoles(c.Request().Context(), userID)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, UserRolesResponse{UserID: userID, Roles: roles})
+}
+
+// setUserRoles handles the request to replace the roles of a user
+// @Summary Set user roles
+// @Description Replaces the roles assigned to a user. All tokens of the user are revoked
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param roles body SetUserRolesRequest true "Role names"
+// @Success 200 {object} UserRolesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users/{id}/roles [put]
+func (h *Handler) setUserRoles(c echo.Context) error {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid user ID"))
+	}
+
+	var req SetUserRolesRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse("invalid request format"))
+	}
+
+	// Request validation
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, errorResponse(err.Error()))
+	}
+
+	roles, err := h.roleService.SetUserRoles(c.Request().Context(), userID, req.Roles)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, UserRolesResponse{UserID: userID, Roles: roles})
+}
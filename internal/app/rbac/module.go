@@ -0,0 +1,38 @@
+package rbac
+
+import (
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+// Module represents a role-based access control module
+type Module struct {
+	Handler *Handler
+	Service services.RoleService
+}
+
+// NewModule creates a new instance of the RBAC module
+func NewModule(
+	roleRepo repositories.RoleRepository,
+	userRepo repositories.UserRepository,
+	revocations services.TokenRevocationService,
+	logger logger.Logger,
+) *Module {
+	// Create service
+	service := NewService(roleRepo, userRepo, revocations, logger)
+
+	// Create handler
+	handler := NewHandler(service)
+
+	return &Module{
+		Handler: handler,
+		Service: service,
+	}
+}
+
+// RegisterRoutes registers routes for role management
+func (m *Module) RegisterRoutes(router *echo.Group) {
+	m.Handler.RegisterRoutes(router)
+}
@@ -0,0 +1,162 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/pkg/logger"
+)
+
+const (
+	// permissionsCacheTTL is how long the role to permissions mapping is kept in memory.
+	// Roles and their permissions are only changed by migrations
+	permissionsCacheTTL = time.Minute
+)
+
+// Service implements services.RoleService interface
+type Service struct {
+	roleRepo    repositories.RoleRepository
+	userRepo    repositories.UserRepository
+	revocations services.TokenRevocationService
+	logger      logger.Logger
+
+	mu          sync.RWMutex
+	permissions map[string]map[string]struct{} // Role name to set of permissions
+	loadedAt    time.Time
+}
+
+// NewService creates a new instance of the RBAC service
+func NewService(
+	roleRepo repositories.RoleRepository,
+	userRepo repositories.UserRepository,
+	revocations services.TokenRevocationService,
+	logger logger.Logger,
+) services.RoleService {
+	return &Service{
+		roleRepo:    roleRepo,
+		userRepo:    userRepo,
+		revocations: revocations,
+		logger:      logger,
+	}
+}
+
+// ListRoles returns all roles with their permissions
+func (s *Service) ListRoles(ctx context.Context) ([]models.Role, error) {
+	roles, err := s.roleRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting roles: %w", err)
+	}
+	return roles, nil
+}
+
+// GetUserRoles returns the roles assigned to the user
+func (s *Service) GetUserRoles(ctx context.Context, userID int) ([]string, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrUserNotFound) {
+			return nil, domainerrors.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+	return user.Roles, nil
+}
+
+// SetUserRoles replaces the roles assigned to the user and revokes the user's tokens
+// so that the new roles are picked up on the next login
+func (s *Service) SetUserRoles(ctx context.Context, userID int, roles []string) ([]string, error) {
+	roles = uniqueRoles(roles)
+
+	if err := s.roleRepo.SetUserRoles(ctx, userID, roles); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrNotFound):
+			return nil, domainerrors.ErrRoleNotFound
+		case errors.Is(err, domainerrors.ErrUserNotFound):
+			return nil, domainerrors.ErrUserNotFound
+		default:
+			return nil, fmt.Errorf("error setting user roles: %w", err)
+		}
+	}
+
+	// Tokens carry roles, so the ones issued before the change must not be used anymore
+	if err := s.revocations.RevokeUserTokens(ctx, userID); err != nil {
+		return nil, fmt.Errorf("error revoking user tokens: %w", err)
+	}
+
+	s.logger.Info("User roles changed", "userID", userID, "roles", roles)
+	return roles, nil
+}
+
+// HasPermission checks if any of the roles grants the permission
+func (s *Service) HasPermission(ctx context.Context, roles []string, permission string) (bool, error) {
+	if len(roles) == 0 {
+		return false, nil
+	}
+
+	permissions, err := s.rolePermissions(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, role := range roles {
+		if _, ok := permissions[role][permission]; ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// rolePermissions returns the role to permissions mapping, reloading it when stale
+func (s *Service) rolePermissions(ctx context.Context) (map[string]map[string]struct{}, error) {
+	s.mu.RLock()
+	permissions, loadedAt := s.permissions, s.loadedAt
+	s.mu.RUnlock()
+
+	if permissions != nil && time.Since(loadedAt) < permissionsCacheTTL {
+		return permissions, nil
+	}
+
+	roles, err := s.roleRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error loading role permissions: %w", err)
+	}
+
+	permissions = make(map[string]map[string]struct{}, len(roles))
+	for _, role := range roles {
+		set := make(map[string]struct{}, len(role.Permissions))
+		for _, permission := range role.Permissions {
+			set[permission] = struct{}{}
+		}
+		permissions[role.Name] = set
+	}
+
+	s.mu.Lock()
+	s.permissions = permissions
+	s.loadedAt = time.Now()
+	s.mu.Unlock()
+
+	return permissions, nil
+}
+
+// uniqueRoles returns sorted role names without duplicates
+func uniqueRoles(roles []string) []string {
+	seen := make(map[string]struct{}, len(roles))
+	result := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if _, ok := seen[role]; ok {
+			continue
+		}
+		seen[role] = struct{}{}
+		result = append(result, role)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -11,4 +11,6 @@ var (
 	ErrInvalidToken = errors.New("invalid token")
 	// ErrTokenExpired indicates that the provided token has expired
 	ErrTokenExpired = errors.New("token has expired")
+	// ErrTokenReused indicates that an already rotated refresh token was presented again
+	ErrTokenReused = errors.New("refresh token reuse detected")
 )
@@ -15,6 +15,18 @@ var (
 	// ErrInvalidBookData indicates that book data is invalid
 	ErrInvalidBookData = errors.New("invalid book data")
 
+	// ErrDuplicateISBN indicates that another book already has the ISBN
+	ErrDuplicateISBN = errors.New("book with this ISBN already exists")
+
+	// ErrCoverTooLarge indicates that an uploaded cover image exceeds the size limit
+	ErrCoverTooLarge = errors.New("cover image is too large")
+
+	// ErrUnsupportedImage indicates that an uploaded file is not an image in a supported format
+	ErrUnsupportedImage = errors.New("unsupported image format, use JPEG, PNG or GIF")
+
+	// ErrAuthorNotFound indicates that a requested author was not found
+	ErrAuthorNotFound = errors.New("author not found")
+
 	// ErrCategoryNotFound indicates that a book's category was not found
 	ErrCategoryNotFound = errors.New("category not found")
 )
@@ -5,4 +5,16 @@ import "errors"
 var (
 	// ErrCategoryExists indicates that a category with the given name already exists
 	ErrCategoryExists = errors.New("category with this name already exists")
+
+	// ErrCategoryCycle indicates that a category would become its own ancestor
+	ErrCategoryCycle = errors.New("category cannot be moved under itself or its subcategories")
+
+	// ErrCategoryHasChildren indicates that a category with subcategories was to be deleted
+	ErrCategoryHasChildren = errors.New("category has subcategories")
+
+	// ErrCategoryHasBooks indicates that a category with books was to be deleted
+	ErrCategoryHasBooks = errors.New("category has books")
+
+	// ErrCategoryParentDeleted indicates that a category was to be restored under a deleted parent
+	ErrCategoryParentDeleted = errors.New("parent category is deleted, restore it first")
 )
@@ -17,4 +17,7 @@ var (
 
 	// ErrEmptyCart indicates that the cart is empty
 	ErrEmptyCart = errors.New("cart is empty")
+
+	// ErrInvalidCursor indicates that a pagination cursor is malformed or belongs to another sort order
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
 )
@@ -9,6 +9,9 @@ var (
 	// ErrInvalidOrderStatus indicates that the order status is invalid
 	ErrInvalidOrderStatus = errors.New("invalid order status")
 
+	// ErrInvalidOrderTransition indicates that the order cannot move from its status to the requested one
+	ErrInvalidOrderTransition = errors.New("invalid order status transition")
+
 	// ErrOrderAlreadyPaid indicates that the order has already been paid
 	ErrOrderAlreadyPaid = errors.New("order has already been paid")
 
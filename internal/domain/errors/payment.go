@@ -0,0 +1,29 @@
+package errors
+
+import "errors"
+
+var (
+	// ErrPaymentDeclined indicates that the payment provider declined the payment
+	ErrPaymentDeclined = errors.New("payment declined")
+
+	// ErrPaymentInProgress indicates that the order is already being paid by another payment
+	ErrPaymentInProgress = errors.New("payment of the order is already in progress")
+
+	// ErrInvalidPaymentTransition indicates that the payment cannot move from its status to the notified one
+	ErrInvalidPaymentTransition = errors.New("invalid payment status transition")
+
+	// ErrPaymentNotFound indicates that a requested payment was not found
+	ErrPaymentNotFound = errors.New("payment not found")
+
+	// ErrPaymentGateway indicates that the payment provider failed to process a request
+	ErrPaymentGateway = errors.New("payment provider error")
+
+	// ErrInvalidWebhookSignature indicates that a webhook was not signed by the payment provider
+	ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+
+	// ErrUnknownPaymentProvider indicates that a webhook was sent for a provider the shop does not use
+	ErrUnknownPaymentProvider = errors.New("unknown payment provider")
+
+	// ErrWebhookInProgress indicates that another delivery of a webhook event is being processed
+	ErrWebhookInProgress = errors.New("webhook event is being processed")
+)
@@ -0,0 +1,10 @@
+package errors
+
+import "errors"
+
+var (
+	// ErrUnsupportedCurrency indicates that the shop does not sell in the requested currency
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+	// ErrExchangeRateUnavailable indicates that no exchange rate could be obtained for the currency
+	ErrExchangeRateUnavailable = errors.New("exchange rate unavailable")
+)
@@ -0,0 +1,10 @@
+package errors
+
+import "errors"
+
+var (
+	// ErrRoleNotFound indicates that a role with the given name does not exist
+	ErrRoleNotFound = errors.New("role not found")
+	// ErrPermissionDenied indicates that the user lacks the required permission
+	ErrPermissionDenied = errors.New("permission denied")
+)
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Author roles on a book
+const (
+	AuthorRoleAuthor      = "author"
+	AuthorRoleEditor      = "editor"
+	AuthorRoleTranslator  = "translator"
+	AuthorRoleIllustrator = "illustrator"
+)
+
+// Author represents an author model.
+// Names keep the author's own capitalization, e.g. "bell hooks" or "Ursula K. Le Guin"
+type Author struct {
+	ID        int       `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	BookCount int       `json:"book_count" db:"-"` // Set in author lists only
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// BookAuthor represents a contributor to a book
+type BookAuthor struct {
+	AuthorID int    `json:"id" db:"author_id"`
+	Name     string `json:"name" db:"name"`
+	Role     string `json:"role" db:"role"`
+}
+
+// BookAuthorInput represents a contributor given when creating or updating a book
+type BookAuthorInput struct {
+	Name string `json:"name" validate:"required,max=255"`
+	Role string `json:"role,omitempty" validate:"omitempty,oneof=author editor translator illustrator"` // Defaults to author
+}
+
+// AuthorFilter represents author filtering parameters
+type AuthorFilter struct {
+	Query    string `json:"q,omitempty" form:"q"` // Matches anywhere in the name, case-insensitively
+	Page     int    `json:"page" form:"page"`
+	PageSize int    `json:"page_size" form:"page_size"`
+}
+
+// AuthorListResponse represents a response with a list of authors
+type AuthorListResponse struct {
+	Authors    []Author `json:"authors"`
+	TotalCount int      `json:"total_count"`
+	Page       int      `json:"page"`
+	PageSize   int      `json:"page_size"`
+	TotalPages int      `json:"total_pages"`
+}
+
+// Byline returns the names credited as authors joined with semicolons,
+// or all names if nobody is credited as an author. Names may contain commas, e.g. "Tolkien, J.R.R."
+func Byline(authors []BookAuthor) string {
+	names := make([]string, 0, len(authors))
+	for _, author := range authors {
+		if author.Role == AuthorRoleAuthor {
+			names = append(names, author.Name)
+		}
+	}
+
+	if len(names) == 0 {
+		for _, author := range authors {
+			names = append(names, author.Name)
+		}
+	}
+
+	return strings.Join(names, "; ")
+}
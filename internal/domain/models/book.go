@@ -1,55 +1,171 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/bookshop/api/pkg/money"
+)
 
 // Book represents a book model
 type Book struct {
-	ID            int       `json:"id" db:"id"`
-	Title         string    `json:"title" db:"title"`
-	Author        string    `json:"author" db:"author"`
-	YearPublished int       `json:"year_published" db:"year_published"`
-	Price         float64   `json:"price" db:"price"`
-	Stock         int       `json:"stock" db:"stock"`
-	CategoryID    int       `json:"category_id" db:"category_id"`
-	Category      *Category `json:"category,omitempty" db:"-"`
-	CreatedAt     time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+	ID              int               `json:"id" db:"id"`
+	Title           string            `json:"title" db:"title"`
+	Author          string            `json:"author" db:"author"` // Byline of the credited authors
+	Authors         []BookAuthor      `json:"authors,omitempty" db:"-"`
+	YearPublished   int               `json:"year_published" db:"year_published"`
+	Price           money.Money       `json:"price" db:"price"`
+	Stock           int               `json:"stock" db:"stock"`
+	CategoryID      int               `json:"category_id" db:"category_id"`
+	Category        *Category         `json:"category,omitempty" db:"-"`
+	ISBN            string            `json:"isbn,omitempty" db:"isbn"` // ISBN-13 digits
+	Publisher       string            `json:"publisher,omitempty" db:"publisher"`
+	Language        string            `json:"language,omitempty" db:"language"` // ISO 639 code, e.g. "en"
+	Format          string            `json:"format,omitempty" db:"format"`     // One of the BookFormat values
+	PageCount       int               `json:"page_count,omitempty" db:"page_count"`
+	Description     string            `json:"description,omitempty" db:"description"`
+	CoverURL        string            `json:"cover_url,omitempty" db:"cover_url"`
+	CoverThumbnails map[string]string `json:"cover_thumbnails,omitempty" db:"cover_thumbnails"` // Thumbnail URLs by size of uploaded covers
+	CoverKey        string            `json:"-" db:"cover_key"`                                 // Storage key prefix of the uploaded cover, empty for external cover URLs
+	Highlight       *Highlight        `json:"highlight,omitempty" db:"-"`                       // Set on full-text search results only
+	CreatedAt       time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at" db:"updated_at"`
+	DeletedAt       *time.Time        `json:"deleted_at,omitempty" db:"deleted_at"` // Set on deleted books, which are only kept for order history
+}
+
+// Book formats
+const (
+	BookFormatHardcover = "hardcover"
+	BookFormatPaperback = "paperback"
+	BookFormatEbook     = "ebook"
+	BookFormatAudiobook = "audiobook"
+)
+
+// Highlight represents the fields of a book matching a search query,
+// as HTML with matched words wrapped in <mark> tags. The text of the fields is HTML-escaped,
+// so the marks are the only markup
+type Highlight struct {
+	Title  string `json:"title"`
+	Author string `json:"author"`
 }
 
 // BookCreate represents data for creating a book
 type BookCreate struct {
-	Title         string  `json:"title" validate:"required"`
-	Author        string  `json:"author" validate:"required"`
-	YearPublished int     `json:"year_published" validate:"required,gt=0"`
-	Price         float64 `json:"price" validate:"required,gt=0"`
-	Stock         int     `json:"stock" validate:"required,gte=0"`
-	CategoryID    int     `json:"category_id" validate:"required,gt=0"`
+	Title         string            `json:"title" validate:"required"`
+	Author        string            `json:"author" validate:"required_without=Authors"` // Split into authors if they are not given
+	Authors       []BookAuthorInput `json:"authors,omitempty" validate:"omitempty,dive"`
+	YearPublished int               `json:"year_published" validate:"required,gt=0"`
+	Price         money.Money       `json:"price" validate:"required,gt=0"` // In the base currency
+	Stock         int               `json:"stock" validate:"required,gte=0"`
+	CategoryID    int               `json:"category_id" validate:"required,gt=0"`
+	ISBN          string            `json:"isbn,omitempty" validate:"omitempty,isbn"`
+	Publisher     string            `json:"publisher,omitempty"`
+	Language      string            `json:"language,omitempty" validate:"omitempty,alpha,min=2,max=3"`
+	Format        string            `json:"format,omitempty" validate:"omitempty,oneof=hardcover paperback ebook audiobook"`
+	PageCount     int               `json:"page_count,omitempty" validate:"gte=0"`
+	Description   string            `json:"description,omitempty"`
+	CoverURL      string            `json:"cover_url,omitempty" validate:"omitempty,url"`
 }
 
 // BookUpdate represents data for updating a book
 type BookUpdate struct {
-	Title         *string  `json:"title,omitempty"`
-	Author        *string  `json:"author,omitempty"`
-	YearPublished *int     `json:"year_published,omitempty" validate:"omitempty,gt=0"`
-	Price         *float64 `json:"price,omitempty" validate:"omitempty,gt=0"`
-	CategoryID    *int     `json:"category_id,omitempty" validate:"omitempty,gt=0"`
+	Title         *string           `json:"title,omitempty"`
+	Author        *string           `json:"author,omitempty"` // Split into authors if they are not given
+	Authors       []BookAuthorInput `json:"authors,omitempty" validate:"omitempty,dive"`
+	YearPublished *int              `json:"year_published,omitempty" validate:"omitempty,gt=0"`
+	Price         *money.Money      `json:"price,omitempty" validate:"omitempty,gt=0"` // In the base currency
+	CategoryID    *int              `json:"category_id,omitempty" validate:"omitempty,gt=0"`
+	ISBN          *string           `json:"isbn,omitempty" validate:"omitempty,isbn"`
+	Publisher     *string           `json:"publisher,omitempty"`
+	Language      *string           `json:"language,omitempty" validate:"omitempty,alpha,min=2,max=3"`
+	Format        *string           `json:"format,omitempty" validate:"omitempty,oneof=hardcover paperback ebook audiobook"`
+	PageCount     *int              `json:"page_count,omitempty" validate:"omitempty,gte=0"`
+	Description   *string           `json:"description,omitempty"`
+	CoverURL      *string           `json:"cover_url,omitempty" validate:"omitempty,url"`
 }
 
 // BookFilter represents book filtering parameters
 type BookFilter struct {
-	CategoryIDs []int    `json:"category_ids" form:"category_ids"`
-	MinPrice    *float64 `json:"min_price,omitempty" form:"min_price"`
-	MaxPrice    *float64 `json:"max_price,omitempty" form:"max_price"`
-	InStock     *bool    `json:"in_stock,omitempty" form:"in_stock"`
-	Page        int      `json:"page" form:"page"`
-	PageSize    int      `json:"page_size" form:"page_size"`
+	Query                string       `json:"q,omitempty" form:"q"` // Full-text search over titles and authors, results are ranked by relevance
+	CategoryIDs          []int        `json:"category_ids" form:"category_ids"`
+	IncludeSubcategories bool         `json:"include_subcategories,omitempty" form:"include_subcategories"` // Also match books in descendants of the categories
+	AuthorIDs            []int        `json:"author_ids,omitempty" form:"author_ids"`                       // Books any of the authors contributed to
+	MinPrice             *money.Money `json:"min_price,omitempty" form:"min_price"`
+	MaxPrice             *money.Money `json:"max_price,omitempty" form:"max_price"`
+	InStock              *bool        `json:"in_stock,omitempty" form:"in_stock"`
+	ISBN                 string       `json:"isbn,omitempty" form:"isbn"`           // ISBN-13 digits
+	Publisher            string       `json:"publisher,omitempty" form:"publisher"` // Matched case-insensitively
+	Languages            []string     `json:"languages,omitempty" form:"languages"`
+	Formats              []string     `json:"formats,omitempty" form:"formats"`
+	MinPages             *int         `json:"min_pages,omitempty" form:"min_pages"`
+	MaxPages             *int         `json:"max_pages,omitempty" form:"max_pages"`
+	Currency             string       `json:"currency,omitempty" form:"currency"` // Currency to price books in, the base currency if empty
+	Sort                 []BookSort   `json:"sort,omitempty" form:"sort"`         // Sort keys in priority order, ties are broken by ID
+	Page                 int          `json:"page" form:"page"`
+	PageSize             int          `json:"page_size" form:"page_size"`
+
+	// Cursor pagination, Page is ignored when a cursor is set
+	After        *Cursor `json:"-"`
+	Before       *Cursor `json:"-"`
+	IncludeTotal bool    `json:"include_total,omitempty" form:"include_total"` // Count the total in cursor mode too
+
+	Facets bool `json:"facets,omitempty" form:"facets"` // Count the matching books per facet
+
+	Deleted bool `json:"-"` // Select deleted books instead of the catalog, for admins only
+}
+
+// BookSort represents a book list sort key
+type BookSort struct {
+	Field      string `json:"field"`
+	Descending bool   `json:"descending"`
 }
 
 // BookListResponse represents a response with a list of books
+// Totals are always set in page mode and only on request in cursor mode
 type BookListResponse struct {
 	Books      []Book `json:"books"`
-	TotalCount int    `json:"total_count"`
-	Page       int    `json:"page"`
+	TotalCount *int   `json:"total_count,omitempty"`
+	Page       int    `json:"page,omitempty"` // Not set in cursor mode
 	PageSize   int    `json:"page_size"`
-	TotalPages int    `json:"total_pages"`
+	TotalPages *int   `json:"total_pages,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+
+	Facets *BookFacets `json:"facets,omitempty"` // Only set when requested
+}
+
+// BookFacets represents the numbers of books matching a filter per facet value.
+// Values without matching books are left out
+type BookFacets struct {
+	Categories  []CategoryFacet   `json:"categories"`
+	PriceRanges []PriceRangeFacet `json:"price_ranges"`
+	Decades     []DecadeFacet     `json:"decades"`
+	Stock       StockFacet        `json:"stock"`
+}
+
+// CategoryFacet represents the number of matching books in a category
+type CategoryFacet struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// PriceRangeFacet represents the number of matching books priced in [Min, Max).
+// Books are counted by their base currency price, the bounds are converted to the requested currency,
+// so they can be sent back as price filters. The last range has no upper bound
+type PriceRangeFacet struct {
+	Min   money.Money  `json:"min"`
+	Max   *money.Money `json:"max,omitempty"`
+	Count int          `json:"count"`
+}
+
+// DecadeFacet represents the number of matching books published in a decade, e.g. 1990
+type DecadeFacet struct {
+	Decade int `json:"decade"`
+	Count  int `json:"count"`
+}
+
+// StockFacet represents the numbers of matching books in and out of stock
+type StockFacet struct {
+	InStock    int `json:"in_stock"`
+	OutOfStock int `json:"out_of_stock"`
 }
@@ -0,0 +1,9 @@
+package models
+
+// Catalog export formats. CSV and NDJSON exports have the columns of
+// imports, so an exported catalog can be imported back
+const (
+	ExportFormatCSV    = ImportFormatCSV
+	ExportFormatNDJSON = ImportFormatNDJSON
+	ExportFormatXML    = "xml" // Google Merchant product feed, an RSS 2.0 channel
+)
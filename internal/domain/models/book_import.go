@@ -0,0 +1,43 @@
+package models
+
+import "github.com/bookshop/api/pkg/money"
+
+// Catalog import file formats
+const (
+	ImportFormatCSV    = "csv"    // Comma-separated values with a header row naming the columns
+	ImportFormatNDJSON = "ndjson" // One JSON object per line
+)
+
+// BookImportRow represents a book in a catalog import.
+// Books are matched by ISBN, so an existing book is updated and a new one is created
+type BookImportRow struct {
+	Title         string      `json:"title" validate:"required,max=255"`
+	Author        string      `json:"author" validate:"required"` // Byline, split into authors
+	YearPublished int         `json:"year_published" validate:"required,gt=0"`
+	Price         money.Money `json:"price" validate:"required,gt=0"` // In the base currency
+	Stock         int         `json:"stock" validate:"gte=0"`
+	Category      string      `json:"category" validate:"required,max=255"` // Category name, created if there is none
+	ISBN          string      `json:"isbn" validate:"required,isbn"`
+	Publisher     string      `json:"publisher,omitempty" validate:"max=255"`
+	Language      string      `json:"language,omitempty" validate:"omitempty,alpha,min=2,max=3"`
+	Format        string      `json:"format,omitempty" validate:"omitempty,oneof=hardcover paperback ebook audiobook"`
+	PageCount     int         `json:"page_count,omitempty" validate:"gte=0"`
+	Description   string      `json:"description,omitempty"`
+	CoverURL      string      `json:"cover_url,omitempty" validate:"omitempty,url,max=2048"`
+}
+
+// BookImportReport represents the outcome of a catalog import
+type BookImportReport struct {
+	Total   int               `json:"total"` // Rows read, not counting the CSV header
+	Created int               `json:"created"`
+	Updated int               `json:"updated"`
+	Failed  int               `json:"failed"`
+	Errors  []BookImportError `json:"errors"`
+}
+
+// BookImportError represents a row that was not imported
+type BookImportError struct {
+	Line  int    `json:"line"` // Line of the row in the file
+	ISBN  string `json:"isbn,omitempty"`
+	Error string `json:"error"`
+}
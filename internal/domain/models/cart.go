@@ -1,11 +1,17 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"time"
+
+	"github.com/bookshop/api/pkg/money"
+)
 
 // CartItem represents a cart item
 type CartItem struct {
 	BookID    int       `json:"book_id" db:"book_id"`
 	Book      *Book     `json:"book,omitempty" db:"-"`
+	Quantity  int       `json:"quantity" db:"quantity"`
 	AddedAt   time.Time `json:"added_at" db:"added_at"`
 	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
 }
@@ -16,47 +22,80 @@ type Cart struct {
 	Items  []CartItem `json:"items" db:"-"`
 }
 
-// CartItemRequest represents a request to add an item to the cart
+// Quantities returns the number of copies per book ID
+func (c *Cart) Quantities() map[int]int {
+	quantities := make(map[int]int, len(c.Items))
+	for _, item := range c.Items {
+		quantities[item.BookID] += item.Quantity
+	}
+	return quantities
+}
+
+// CartItemRequest represents a request to add an item to the cart.
+// If the book is already in the cart the quantities are summed
 type CartItemRequest struct {
-	BookID int `json:"book_id" validate:"required,gt=0"`
+	BookID   int `json:"book_id" validate:"required,gt=0"`
+	Quantity int `json:"quantity" validate:"omitempty,gt=0"` // Defaults to 1
+}
+
+// CartItemQuantityRequest represents a request to set the quantity of a cart item
+type CartItemQuantityRequest struct {
+	Quantity int `json:"quantity" validate:"required,gt=0"`
+}
+
+// CartItemIncrementRequest represents a request to change the quantity of a cart item.
+// A negative delta decreases the quantity; the item is removed when it drops to zero
+type CartItemIncrementRequest struct {
+	Delta int `json:"delta" validate:"required"`
 }
 
 // CartResponse represents a cart response
 type CartResponse struct {
-	Items     []CartItemResponse `json:"items"`
-	TotalCost float64            `json:"total_cost"`
+	Items         []CartItemResponse `json:"items"`
+	Currency      string             `json:"currency"`
+	TotalQuantity int                `json:"total_quantity"`
+	TotalCost     money.Money        `json:"total_cost"`
 }
 
 // CartItemResponse represents a cart item in API response
 type CartItemResponse struct {
-	BookID    int       `json:"book_id"`
-	Title     string    `json:"title"`
-	Author    string    `json:"author"`
-	Price     float64   `json:"price"`
-	AddedAt   time.Time `json:"added_at"`
-	ExpiresAt time.Time `json:"expires_at"`
+	BookID    int         `json:"book_id"`
+	Title     string      `json:"title"`
+	Author    string      `json:"author"`
+	Price     money.Money `json:"price"`
+	Quantity  int         `json:"quantity"`
+	Subtotal  money.Money `json:"subtotal"`
+	AddedAt   time.Time   `json:"added_at"`
+	ExpiresAt time.Time   `json:"expires_at"`
 }
 
 // ToResponse converts a cart to API response
-func (c *Cart) ToResponse() CartResponse {
+func (c *Cart) ToResponse() (CartResponse, error) {
 	var response CartResponse
-	var totalCost float64
 
 	for _, item := range c.Items {
 		if item.Book != nil {
+			subtotal := item.Book.Price.Mul(item.Quantity)
 			cartItem := CartItemResponse{
 				BookID:    item.BookID,
 				Title:     item.Book.Title,
 				Author:    item.Book.Author,
 				Price:     item.Book.Price,
+				Quantity:  item.Quantity,
+				Subtotal:  subtotal,
 				AddedAt:   item.AddedAt,
 				ExpiresAt: item.ExpiresAt,
 			}
 			response.Items = append(response.Items, cartItem)
-			totalCost += item.Book.Price
+			response.TotalQuantity += item.Quantity
+
+			totalCost, err := response.TotalCost.Add(subtotal)
+			if err != nil {
+				return CartResponse{}, fmt.Errorf("error adding book %d to cart total: %w", item.BookID, err)
+			}
+			response.TotalCost = totalCost
 		}
 	}
 
-	response.TotalCost = totalCost
-	return response
+	return response, nil
 }
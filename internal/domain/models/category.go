@@ -4,15 +4,24 @@ import "time"
 
 // Category represents a book category model
 type Category struct {
-	ID        int       `json:"id" db:"id"`
-	Name      string    `json:"name" db:"name"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID        int        `json:"id" db:"id"`
+	Name      string     `json:"name" db:"name"`
+	ParentID  *int       `json:"parent_id,omitempty" db:"parent_id"` // Nil for root categories
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"` // Set on deleted categories
+}
+
+// CategoryNode represents a category with its subcategories
+type CategoryNode struct {
+	Category
+	Children []CategoryNode `json:"children"`
 }
 
 // CategoryCreate represents data for creating a category
 type CategoryCreate struct {
-	Name string `json:"name" validate:"required"`
+	Name     string `json:"name" validate:"required"`
+	ParentID *int   `json:"parent_id,omitempty" validate:"omitempty,gt=0"` // Created as a root category if nil
 }
 
 // CategoryUpdate represents data for updating a category
@@ -20,6 +29,11 @@ type CategoryUpdate struct {
 	Name string `json:"name" validate:"required"`
 }
 
+// CategoryMove represents data for moving a category in the tree
+type CategoryMove struct {
+	ParentID *int `json:"parent_id" validate:"omitempty,gt=0"` // Moved to the root if nil
+}
+
 // CategoryResponse represents a category response
 type CategoryResponse struct {
 	ID   int    `json:"id"`
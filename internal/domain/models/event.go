@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Event names
+const (
+	// EventOrderCanceled is published with an OrderCanceledEvent once an order is canceled
+	EventOrderCanceled = "order.canceled"
+)
+
+// Event is something that happened in the shop other parts of the application can react to
+type Event interface {
+	// EventName returns the name handlers subscribe to the event by
+	EventName() string
+}
+
+// OrderCanceledEvent is published after an order is canceled and its stock is returned
+type OrderCanceledEvent struct {
+	OrderID    int
+	UserID     int  // Customer the order belongs to
+	CanceledBy *int // User who canceled the order, nil for the system
+	Reason     string
+	Items      []OrderItem
+	CanceledAt time.Time
+}
+
+// EventName returns EventOrderCanceled
+func (OrderCanceledEvent) EventName() string {
+	return EventOrderCanceled
+}
@@ -1,43 +1,125 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/bookshop/api/pkg/money"
+)
 
 // Order represents an order model
 type Order struct {
-	ID         int         `json:"id" db:"id"`
-	UserID     int         `json:"user_id" db:"user_id"`
-	Status     string      `json:"status" db:"status"`
-	TotalPrice float64     `json:"total_price" db:"total_price"`
-	Items      []OrderItem `json:"items,omitempty" db:"-"`
-	CreatedAt  time.Time   `json:"created_at" db:"created_at"`
-	UpdatedAt  time.Time   `json:"updated_at" db:"updated_at"`
+	ID           int         `json:"id" db:"id"`
+	UserID       int         `json:"user_id" db:"user_id"`
+	Status       string      `json:"status" db:"status"`
+	Currency     string      `json:"currency" db:"currency"`
+	ExchangeRate money.Rate  `json:"exchange_rate" db:"exchange_rate"` // Rate from the base currency frozen at checkout
+	TotalPrice   money.Money `json:"total_price" db:"total_price"`
+	Items        []OrderItem `json:"items,omitempty" db:"-"`
+	CreatedAt    time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at" db:"updated_at"`
+
+	// Only set for admins
+	Customer      *OrderCustomer      `json:"customer,omitempty" db:"-"`
+	StatusHistory []OrderStatusChange `json:"status_history,omitempty" db:"-"`
+}
+
+// OrderCustomer represents the user who placed an order
+type OrderCustomer struct {
+	ID    int    `json:"id"`
+	Email string `json:"email"`
 }
 
-// OrderItem represents an order item
+// OrderItem represents an order item.
+// The book data is a snapshot taken at checkout, later changes to the book do not affect it
 type OrderItem struct {
-	ID        int       `json:"id" db:"id"`
-	OrderID   int       `json:"order_id" db:"order_id"`
-	BookID    int       `json:"book_id" db:"book_id"`
-	Book      *Book     `json:"book,omitempty" db:"-"`
-	Price     float64   `json:"price" db:"price"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID           int         `json:"id" db:"id"`
+	OrderID      int         `json:"order_id" db:"order_id"`
+	BookID       int         `json:"book_id" db:"book_id"`
+	Book         *Book       `json:"book,omitempty" db:"-"`
+	Title        string      `json:"title" db:"title"`
+	Author       string      `json:"author" db:"author"`
+	ISBN         string      `json:"isbn,omitempty" db:"isbn"`
+	CategoryName string      `json:"category_name,omitempty" db:"category_name"`
+	Price        money.Money `json:"price" db:"price"` // Price of a single copy
+	Quantity     int         `json:"quantity" db:"quantity"`
+	CreatedAt    time.Time   `json:"created_at" db:"created_at"`
+}
+
+// NewOrderItem returns an order item of copies of the book with a snapshot of its data.
+// The book must be priced in the order currency
+func NewOrderItem(book *Book, quantity int) OrderItem {
+	item := OrderItem{
+		BookID:   book.ID,
+		Title:    book.Title,
+		Author:   book.Author,
+		ISBN:     book.ISBN,
+		Price:    book.Price,
+		Quantity: quantity,
+	}
+	if book.Category != nil {
+		item.CategoryName = book.Category.Name
+	}
+	return item
+}
+
+// Subtotal returns the price of all copies of the item
+func (i *OrderItem) Subtotal() money.Money {
+	return i.Price.Mul(i.Quantity)
+}
+
+// OrderFilter represents parameters of a page of orders, of a user's order history
+// or of all orders for admins. Orders are listed newest first
+type OrderFilter struct {
+	UserID   int // Required for a user's order history, 0 matches every user for admins
+	Page     int
+	PageSize int
+
+	// Admin filters, zero values match every order
+	Statuses    []string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time   // Exclusive
+	MinTotal    *money.Money // Totals are compared in the base currency
+	MaxTotal    *money.Money
+
+	// Cursor pagination, Page is ignored when a cursor is set
+	After        *Cursor
+	Before       *Cursor
+	IncludeTotal bool // Count the total in cursor mode too
+}
+
+// OrderListResponse represents a page of a user's order history.
+// Totals are always set in page mode and only on request in cursor mode
+type OrderListResponse struct {
+	Orders     []Order `json:"orders"`
+	TotalCount *int    `json:"total_count,omitempty"`
+	Page       int     `json:"page,omitempty"` // Not set in cursor mode
+	PageSize   int     `json:"page_size"`
+	TotalPages *int    `json:"total_pages,omitempty"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+	PrevCursor string  `json:"prev_cursor,omitempty"`
 }
 
 // OrderResponse represents an order response
 type OrderResponse struct {
-	ID         int                 `json:"id"`
-	Status     string              `json:"status"`
-	TotalPrice float64             `json:"total_price"`
-	Items      []OrderItemResponse `json:"items"`
-	CreatedAt  time.Time           `json:"created_at"`
+	ID           int                 `json:"id"`
+	Status       string              `json:"status"`
+	Currency     string              `json:"currency"`
+	ExchangeRate string              `json:"exchange_rate"`
+	TotalPrice   money.Money         `json:"total_price"`
+	Items        []OrderItemResponse `json:"items"`
+	CreatedAt    time.Time           `json:"created_at"`
 }
 
 // OrderItemResponse represents an order item in API response
 type OrderItemResponse struct {
-	BookID int     `json:"book_id"`
-	Title  string  `json:"title"`
-	Author string  `json:"author"`
-	Price  float64 `json:"price"`
+	BookID       int         `json:"book_id"`
+	Title        string      `json:"title"`
+	Author       string      `json:"author"`
+	ISBN         string      `json:"isbn,omitempty"`
+	CategoryName string      `json:"category_name,omitempty"`
+	Price        money.Money `json:"price"`
+	Quantity     int         `json:"quantity"`
+	Subtotal     money.Money `json:"subtotal"`
 }
 
 // ToResponse converts an order to API response
@@ -45,19 +127,23 @@ func (o *Order) ToResponse() OrderResponse {
 	var response OrderResponse
 	response.ID = o.ID
 	response.Status = o.Status
+	response.Currency = o.Currency
+	response.ExchangeRate = o.ExchangeRate.Decimal()
 	response.TotalPrice = o.TotalPrice
 	response.CreatedAt = o.CreatedAt
 
 	for _, item := range o.Items {
-		if item.Book != nil {
-			orderItem := OrderItemResponse{
-				BookID: item.BookID,
-				Title:  item.Book.Title,
-				Author: item.Book.Author,
-				Price:  item.Price,
-			}
-			response.Items = append(response.Items, orderItem)
+		orderItem := OrderItemResponse{
+			BookID:       item.BookID,
+			Title:        item.Title,
+			Author:       item.Author,
+			ISBN:         item.ISBN,
+			CategoryName: item.CategoryName,
+			Price:        item.Price,
+			Quantity:     item.Quantity,
+			Subtotal:     item.Subtotal(),
 		}
+		response.Items = append(response.Items, orderItem)
 	}
 
 	return response
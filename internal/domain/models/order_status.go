@@ -0,0 +1,96 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+)
+
+// Order statuses. An order is placed pending and moves forward through the lifecycle
+// pending → awaiting_payment → paid → fulfilling → shipped → delivered.
+// Unpaid orders can be canceled and paid ones refunded
+const (
+	// OrderStatusPending is the status of a placed order that is not confirmed yet
+	OrderStatusPending = "pending"
+	// OrderStatusAwaitingPayment is the status of a confirmed order waiting for its payment
+	OrderStatusAwaitingPayment = "awaiting_payment"
+	// OrderStatusPaid is the status of a paid order
+	OrderStatusPaid = "paid"
+	// OrderStatusFulfilling is the status of an order being picked and packed
+	OrderStatusFulfilling = "fulfilling"
+	// OrderStatusShipped is the status of an order handed to the carrier
+	OrderStatusShipped = "shipped"
+	// OrderStatusDelivered is the status of an order the customer received
+	OrderStatusDelivered = "delivered"
+	// OrderStatusCanceled is the status of an order canceled before payment
+	OrderStatusCanceled = "canceled"
+	// OrderStatusRefunded is the status of a paid order whose payment was returned
+	OrderStatusRefunded = "refunded"
+)
+
+// orderTransitions lists the statuses an order can move to from each status.
+// Canceled and refunded orders are final
+var orderTransitions = map[string][]string{
+	OrderStatusPending:         {OrderStatusAwaitingPayment, OrderStatusCanceled},
+	OrderStatusAwaitingPayment: {OrderStatusPaid, OrderStatusCanceled},
+	OrderStatusPaid:            {OrderStatusFulfilling, OrderStatusRefunded},
+	OrderStatusFulfilling:      {OrderStatusShipped, OrderStatusRefunded},
+	OrderStatusShipped:         {OrderStatusDelivered, OrderStatusRefunded},
+	OrderStatusDelivered:       {OrderStatusRefunded},
+	OrderStatusCanceled:        {},
+	OrderStatusRefunded:        {},
+}
+
+// IsOrderStatus reports whether the status is one of the order lifecycle
+func IsOrderStatus(status string) bool {
+	_, ok := orderTransitions[status]
+	return ok
+}
+
+// IsOrderPaid reports whether an order with the status has been paid and not refunded
+func IsOrderPaid(status string) bool {
+	switch status {
+	case OrderStatusPaid, OrderStatusFulfilling, OrderStatusShipped, OrderStatusDelivered:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidateOrderTransition checks that an order can move from one status to another.
+// Returns ErrInvalidOrderStatus for an unknown status and ErrInvalidOrderTransition
+// if the lifecycle does not allow the move
+func ValidateOrderTransition(from, to string) error {
+	if !IsOrderStatus(to) {
+		return fmt.Errorf("%w: %q", domainerrors.ErrInvalidOrderStatus, to)
+	}
+	if !slices.Contains(orderTransitions[from], to) {
+		return fmt.Errorf("%w: from %s to %s", domainerrors.ErrInvalidOrderTransition, from, to)
+	}
+	return nil
+}
+
+// OrderStatusChange represents a change of an order status in its history.
+// The first change of an order has no previous status
+type OrderStatusChange struct {
+	ID         int       `json:"id" db:"id"`
+	OrderID    int       `json:"order_id" db:"order_id"`
+	FromStatus string    `json:"from_status,omitempty" db:"from_status"`
+	ToStatus   string    `json:"to_status" db:"to_status"`
+	ChangedBy  *int      `json:"changed_by,omitempty" db:"changed_by"` // User who made the change, nil for the system
+	Reason     string    `json:"reason,omitempty" db:"reason"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// OrderStatusRequest represents a request to change the status of an order
+type OrderStatusRequest struct {
+	Status string `json:"status" validate:"required"`
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+// OrderCancelRequest represents a customer's request to cancel an order
+type OrderCancelRequest struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
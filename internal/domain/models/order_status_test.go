@@ -0,0 +1,70 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+)
+
+func TestValidateOrderTransition(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		wantErr error
+	}{
+		{name: "confirm", from: OrderStatusPending, to: OrderStatusAwaitingPayment},
+		{name: "cancel pending", from: OrderStatusPending, to: OrderStatusCanceled},
+		{name: "pay", from: OrderStatusAwaitingPayment, to: OrderStatusPaid},
+		{name: "cancel unpaid", from: OrderStatusAwaitingPayment, to: OrderStatusCanceled},
+		{name: "fulfil", from: OrderStatusPaid, to: OrderStatusFulfilling},
+		{name: "ship", from: OrderStatusFulfilling, to: OrderStatusShipped},
+		{name: "deliver", from: OrderStatusShipped, to: OrderStatusDelivered},
+		{name: "refund paid", from: OrderStatusPaid, to: OrderStatusRefunded},
+		{name: "refund delivered", from: OrderStatusDelivered, to: OrderStatusRefunded},
+		{name: "pay pending", from: OrderStatusPending, to: OrderStatusPaid, wantErr: domainerrors.ErrInvalidOrderTransition},
+		{name: "skip shipping", from: OrderStatusPaid, to: OrderStatusDelivered, wantErr: domainerrors.ErrInvalidOrderTransition},
+		{name: "move backwards", from: OrderStatusShipped, to: OrderStatusPaid, wantErr: domainerrors.ErrInvalidOrderTransition},
+		{name: "cancel paid", from: OrderStatusPaid, to: OrderStatusCanceled, wantErr: domainerrors.ErrInvalidOrderTransition},
+		{name: "refund unpaid", from: OrderStatusAwaitingPayment, to: OrderStatusRefunded, wantErr: domainerrors.ErrInvalidOrderTransition},
+		{name: "same status", from: OrderStatusPaid, to: OrderStatusPaid, wantErr: domainerrors.ErrInvalidOrderTransition},
+		{name: "reopen canceled", from: OrderStatusCanceled, to: OrderStatusPending, wantErr: domainerrors.ErrInvalidOrderTransition},
+		{name: "pay canceled", from: OrderStatusCanceled, to: OrderStatusPaid, wantErr: domainerrors.ErrInvalidOrderTransition},
+		{name: "cancel canceled", from: OrderStatusCanceled, to: OrderStatusCanceled, wantErr: domainerrors.ErrInvalidOrderTransition},
+		{name: "ship refunded", from: OrderStatusRefunded, to: OrderStatusShipped, wantErr: domainerrors.ErrInvalidOrderTransition},
+		{name: "pay refunded", from: OrderStatusRefunded, to: OrderStatusPaid, wantErr: domainerrors.ErrInvalidOrderTransition},
+		{name: "refund refunded", from: OrderStatusRefunded, to: OrderStatusRefunded, wantErr: domainerrors.ErrInvalidOrderTransition},
+		{name: "unknown target", from: OrderStatusPending, to: "completed", wantErr: domainerrors.ErrInvalidOrderStatus},
+		{name: "unknown target from terminal", from: OrderStatusCanceled, to: "", wantErr: domainerrors.ErrInvalidOrderStatus},
+		{name: "unknown source", from: "completed", to: OrderStatusPaid, wantErr: domainerrors.ErrInvalidOrderTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateOrderTransition(tt.from, tt.to)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("ValidateOrderTransition(%q, %q) unexpected error: %v", tt.from, tt.to, err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ValidateOrderTransition(%q, %q) error = %v, want %v", tt.from, tt.to, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTerminalOrderStatuses(t *testing.T) {
+	for _, from := range []string{OrderStatusCanceled, OrderStatusRefunded} {
+		for to := range orderTransitions {
+			t.Run(from+" to "+to, func(t *testing.T) {
+				err := ValidateOrderTransition(from, to)
+				if !errors.Is(err, domainerrors.ErrInvalidOrderTransition) {
+					t.Errorf("ValidateOrderTransition(%q, %q) error = %v, want %v", from, to, err, domainerrors.ErrInvalidOrderTransition)
+				}
+			})
+		}
+	}
+}
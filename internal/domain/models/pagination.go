@@ -0,0 +1,79 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+)
+
+// Cursor is a position in a keyset-paginated list: the sort key values and the ID of a row.
+// Clients get cursors as opaque strings and pass them back unchanged
+type Cursor struct {
+	Sort   string   `json:"s"`           // Sort order the cursor was created for
+	Values []string `json:"v,omitempty"` // Sort key values of the row, without the ID
+	ID     int      `json:"id"`
+}
+
+// Encode returns the opaque cursor string
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c) // Marshaling strings and ints cannot fail
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses an opaque cursor string.
+// Returns nil for an empty string
+func DecodeCursor(value string) (*Cursor, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidCursor, err)
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidCursor, err)
+	}
+	if cursor.ID <= 0 {
+		return nil, domainerrors.ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+// PageInfo describes where a page is in its list
+type PageInfo struct {
+	TotalCount *int    // Nil if the list was not counted
+	NextCursor *Cursor // Nil on the last page
+	PrevCursor *Cursor // Nil on the first page
+}
+
+// Cursors returns the encoded next and previous page cursors, empty when there is no such page
+func (p PageInfo) Cursors() (next, prev string) {
+	if p.NextCursor != nil {
+		next = p.NextCursor.Encode()
+	}
+	if p.PrevCursor != nil {
+		prev = p.PrevCursor.Encode()
+	}
+	return next, prev
+}
+
+// TotalPages returns the number of pages of the size, nil if the list was not counted
+func (p PageInfo) TotalPages(pageSize int) *int {
+	if p.TotalCount == nil || pageSize <= 0 {
+		return nil
+	}
+
+	totalPages := *p.TotalCount / pageSize
+	if *p.TotalCount%pageSize > 0 {
+		totalPages++
+	}
+	return &totalPages
+}
@@ -0,0 +1,151 @@
+package models
+
+import (
+	"time"
+
+	"github.com/bookshop/api/pkg/money"
+)
+
+// Payment statuses. A payment is authorized and then captured, which pays its order.
+// Declined and failed payments can be retried with a new payment
+const (
+	PaymentStatusPending    = "pending"
+	PaymentStatusAuthorized = "authorized"
+	PaymentStatusCaptured   = "captured"
+	PaymentStatusDeclined   = "declined"
+	PaymentStatusFailed     = "failed"
+	PaymentStatusVoided     = "voided"
+	PaymentStatusRefunded   = "refunded"
+)
+
+// paymentTransitions lists the statuses each payment status can move to
+var paymentTransitions = map[string][]string{
+	PaymentStatusPending:    {PaymentStatusAuthorized, PaymentStatusDeclined, PaymentStatusFailed, PaymentStatusVoided},
+	PaymentStatusAuthorized: {PaymentStatusCaptured, PaymentStatusVoided, PaymentStatusFailed},
+	PaymentStatusCaptured:   {PaymentStatusRefunded},
+	PaymentStatusDeclined:   {},
+	PaymentStatusFailed:     {},
+	PaymentStatusVoided:     {},
+	PaymentStatusRefunded:   {},
+}
+
+// CanPaymentTransition checks if a payment can move from one status to another
+func CanPaymentTransition(from, to string) bool {
+	for _, status := range paymentTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// PaymentPath returns the statuses a payment passes through to move from one status to another, ending with to.
+// Returns nil if the lifecycle does not lead from one to the other
+func PaymentPath(from, to string) []string {
+	if from == to {
+		return nil
+	}
+
+	// Breadth-first search over the lifecycle, previous maps a status to the status it is reached from
+	previous := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		status := queue[0]
+		queue = queue[1:]
+
+		for _, next := range paymentTransitions[status] {
+			if _, seen := previous[next]; seen {
+				continue
+			}
+			previous[next] = status
+
+			if next == to {
+				var path []string
+				for step := to; step != from; step = previous[step] {
+					path = append([]string{step}, path...)
+				}
+				return path
+			}
+			queue = append(queue, next)
+		}
+	}
+
+	return nil
+}
+
+// Payment represents an attempt to pay an order through a payment provider
+type Payment struct {
+	ID            int         `json:"id" db:"id"`
+	OrderID       int         `json:"order_id" db:"order_id"`
+	Provider      string      `json:"provider" db:"provider"`
+	ProviderRef   string      `json:"provider_ref,omitempty" db:"provider_ref"` // Set once the provider accepts the payment
+	Status        string      `json:"status" db:"status"`
+	Amount        money.Money `json:"amount" db:"amount"` // In the order currency
+	FailureReason string      `json:"failure_reason,omitempty" db:"failure_reason"`
+	CreatedAt     time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// PaymentAuthorization represents a request to a payment provider to reserve the amount
+type PaymentAuthorization struct {
+	PaymentID int
+	OrderID   int
+	Amount    money.Money
+	Token     string // Payment method token issued to the client by the provider
+}
+
+// Payment webhook event types
+const (
+	PaymentEventCaptured = "payment.captured"
+	PaymentEventVoided   = "payment.voided"
+	PaymentEventRefunded = "payment.refunded"
+	PaymentEventFailed   = "payment.failed"
+)
+
+// paymentEventStatuses maps payment webhook event types to the payment statuses they report
+var paymentEventStatuses = map[string]string{
+	PaymentEventCaptured: PaymentStatusCaptured,
+	PaymentEventVoided:   PaymentStatusVoided,
+	PaymentEventRefunded: PaymentStatusRefunded,
+	PaymentEventFailed:   PaymentStatusFailed,
+}
+
+// PaymentWebhookEvent represents a verified notification of a payment provider
+// about a change of a payment made on its side.
+// Providers may deliver an event more than once and events out of order
+type PaymentWebhookEvent struct {
+	ID          string `json:"id"` // Unique per provider, the same for every delivery of the event
+	Type        string `json:"type"`
+	ProviderRef string `json:"payment_ref"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// Status returns the payment status the event reports, "" for unknown event types
+func (e PaymentWebhookEvent) Status() string {
+	return paymentEventStatuses[e.Type]
+}
+
+// Statuses of stored webhook events
+const (
+	WebhookEventReceived   = "received"   // Stored, not processed yet
+	WebhookEventProcessing = "processing" // Claimed by a delivery being processed
+	WebhookEventProcessed  = "processed"  // Applied to its payment and order
+	WebhookEventIgnored    = "ignored"    // Had nothing to apply, e.g. it was older than the payment status
+	WebhookEventFailed     = "failed"     // Could not be applied, processed again when redelivered
+)
+
+// StoredWebhookEvent represents a raw webhook event of a payment provider as it was received
+type StoredWebhookEvent struct {
+	ID          int        `json:"id" db:"id"`
+	Provider    string     `json:"provider" db:"provider"`
+	EventID     string     `json:"event_id" db:"event_id"`
+	EventType   string     `json:"event_type" db:"event_type"`
+	ProviderRef string     `json:"provider_ref" db:"provider_ref"`
+	Payload     []byte     `json:"-" db:"payload"` // Exactly as signed by the provider
+	Signature   string     `json:"-" db:"signature"`
+	Status      string     `json:"status" db:"status"`
+	Error       string     `json:"error,omitempty" db:"error"` // Why the event failed or was ignored
+	Deliveries  int        `json:"deliveries" db:"deliveries"`
+	ReceivedAt  time.Time  `json:"received_at" db:"received_at"`
+	ProcessedAt *time.Time `json:"processed_at,omitempty" db:"processed_at"`
+}
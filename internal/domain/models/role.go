@@ -0,0 +1,37 @@
+package models
+
+// Role names seeded by migrations
+const (
+	// RoleAdmin has every permission
+	RoleAdmin = "admin"
+	// RoleCatalogEditor manages books and categories
+	RoleCatalogEditor = "catalog_editor"
+	// RoleOrderManager views and processes orders
+	RoleOrderManager = "order_manager"
+	// RoleSupport views customers and their orders
+	RoleSupport = "support"
+)
+
+// Permission names seeded by migrations
+const (
+	// PermissionBooksWrite allows creating, updating and deleting books
+	PermissionBooksWrite = "books:write"
+	// PermissionCategoriesWrite allows creating, updating and deleting categories
+	PermissionCategoriesWrite = "categories:write"
+	// PermissionOrdersRead allows viewing orders of all customers
+	PermissionOrdersRead = "orders:read"
+	// PermissionOrdersWrite allows changing orders of all customers
+	PermissionOrdersWrite = "orders:write"
+	// PermissionUsersRead allows viewing user accounts
+	PermissionUsersRead = "users:read"
+	// PermissionRolesWrite allows assigning roles to users
+	PermissionRolesWrite = "roles:write"
+)
+
+// Role represents a named set of permissions
+type Role struct {
+	ID          int      `json:"id" db:"id"`
+	Name        string   `json:"name" db:"name"`
+	Description string   `json:"description" db:"description"`
+	Permissions []string `json:"permissions"`
+}
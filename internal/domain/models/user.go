@@ -7,7 +7,7 @@ type User struct {
 	ID           int       `json:"id" db:"id"`
 	Email        string    `json:"email" db:"email"`
 	PasswordHash string    `json:"-" db:"password_hash"`
-	IsAdmin      bool      `json:"is_admin" db:"is_admin"`
+	Roles        []string  `json:"roles"`
 	CreatedAt    time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -29,7 +29,7 @@ type UserRegistration struct {
 type UserResponse struct {
 	ID        int       `json:"id"`
 	Email     string    `json:"email"`
-	IsAdmin   bool      `json:"is_admin"`
+	Roles     []string  `json:"roles"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -38,7 +38,17 @@ func (u *User) ToResponse() UserResponse {
 	return UserResponse{
 		ID:        u.ID,
 		Email:     u.Email,
-		IsAdmin:   u.IsAdmin,
+		Roles:     u.Roles,
 		CreatedAt: u.CreatedAt,
 	}
 }
+
+// HasRole checks if the user has the given role
+func (u *User) HasRole(role string) bool {
+	for _, r := range u.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
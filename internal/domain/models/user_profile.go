@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/bookshop/api/pkg/money"
+)
 
 // UserProfileResponse represents a user profile with their orders
 type UserProfileResponse struct {
@@ -12,10 +16,10 @@ type UserProfileResponse struct {
 
 // ProfileOrderResponse represents an order in the user profile API response
 type ProfileOrderResponse struct {
-	UUID      string    `json:"uuid"`
-	Status    string    `json:"status"`
-	Total     float64   `json:"total"`
-	CreatedAt time.Time `json:"created_at"`
+	UUID      string      `json:"uuid"`
+	Status    string      `json:"status"`
+	Total     money.Money `json:"total"`
+	CreatedAt time.Time   `json:"created_at"`
 }
 
 // CreateOrderRequest represents a request to create a new order
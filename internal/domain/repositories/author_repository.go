@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/bookshop/api/internal/domain/models"
+)
+
+// AuthorRepository defines methods for working with authors in storage
+type AuthorRepository interface {
+	// GetByID returns an author by ID
+	GetByID(ctx context.Context, id int) (*models.Author, error)
+
+	// List returns a page of authors with their book counts and the total count
+	List(ctx context.Context, filter models.AuthorFilter) ([]models.Author, int, error)
+
+	// GetOrCreate returns the author with the name regardless of case, creating one if there is none
+	GetOrCreate(ctx context.Context, name string) (*models.Author, error)
+
+	// SetBookAuthors replaces the contributors of a book, in credit order
+	SetBookAuthors(ctx context.Context, bookID int, authors []models.BookAuthor) error
+
+	// GetByBookIDs returns the contributors of the books in credit order, by book ID
+	GetByBookIDs(ctx context.Context, bookIDs []int) (map[int][]models.BookAuthor, error)
+}
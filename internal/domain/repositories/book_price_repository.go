@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/bookshop/api/pkg/money"
+)
+
+// BookPriceRepository defines methods for working with per-currency book price overrides in storage
+type BookPriceRepository interface {
+	// GetOverrides returns the price overrides in the currency by book ID.
+	// Books without an override are absent from the result
+	GetOverrides(ctx context.Context, bookIDs []int, currency string) (map[int]money.Money, error)
+
+	// SetOverride creates or replaces the book price in the currency of price.
+	// Returns ErrNotFound if the book does not exist
+	SetOverride(ctx context.Context, bookID int, price money.Money) error
+
+	// DeleteOverride removes the book price in the currency.
+	// Returns ErrNotFound if there is no override
+	DeleteOverride(ctx context.Context, bookID int, currency string) error
+}
@@ -14,29 +14,54 @@ type BookRepository interface {
 	// GetByID returns a book by ID
 	GetByID(ctx context.Context, id int) (*models.Book, error)
 
-	// List returns a list of books with filtering
-	List(ctx context.Context, filter models.BookFilter) ([]models.Book, int, error)
+	// GetByISBN returns a book by its ISBN-13
+	GetByISBN(ctx context.Context, isbn string) (*models.Book, error)
+
+	// List returns a page of books with filtering, by offset or after or before a cursor.
+	// Facets are only counted when the filter asks for them
+	List(ctx context.Context, filter models.BookFilter) ([]models.Book, *models.BookFacets, models.PageInfo, error)
+
+	// Stream calls fn with every book matching the filter in its sort order, ignoring pages.
+	// Books are read in batches, streaming stops at the first error of fn
+	Stream(ctx context.Context, filter models.BookFilter, fn func(book *models.Book) error) error
+
+	// UpsertByISBN creates the book or updates the book with its ISBN, in the transaction of the context.
+	// Uploaded covers are kept. Returns true if the book was created
+	UpsertByISBN(ctx context.Context, book *models.Book) (bool, error)
 
 	// Update updates book data
 	Update(ctx context.Context, book *models.Book) error
 
-	// Delete deletes a book by ID
+	// UpdateCover updates the cover URL, thumbnails and storage key of a book
+	UpdateCover(ctx context.Context, book *models.Book) error
+
+	// Delete soft deletes a book by ID, deleted books are only kept for order history
 	Delete(ctx context.Context, id int) error
 
+	// Restore restores a deleted book by ID
+	Restore(ctx context.Context, id int) error
+
 	// UpdateStock updates the quantity of books in stock
 	UpdateStock(ctx context.Context, id int, quantity int) error
 
 	// DecrementStock decreases the quantity of books in stock
-	// Returns an error if there are not enough books in stock
+	// Returns an error if there are not enough books in stock.
+	// Decrementing several books locks them in call order, ReserveBooks locks them in a stable order
 	DecrementStock(ctx context.Context, id int, quantity int) error
 
-	// GetBooksByIDs returns books by a list of IDs
+	// GetBooksByIDs returns books by a list of IDs, deleted books are left out
 	GetBooksByIDs(ctx context.Context, ids []int) ([]models.Book, error)
 
-	// ReserveBooks reserves books (decreases available quantity)
-	// Returns an error if any of the items is unavailable
-	ReserveBooks(ctx context.Context, bookIDs []int) error
+	// GetBooksByIDsWithDeleted returns books by a list of IDs including deleted books
+	GetBooksByIDsWithDeleted(ctx context.Context, ids []int) ([]models.Book, error)
+
+	// ReserveBooks reserves books (decreases available quantity).
+	// The quantities map contains the number of copies to reserve per book ID.
+	// Returns an error if any of the items is unavailable, in which case nothing is reserved.
+	// Books are locked in order of their IDs, so concurrent reservations cannot deadlock
+	ReserveBooks(ctx context.Context, quantities map[int]int) error
 
-	// ReleaseBooks releases reserved books (increases available quantity)
-	ReleaseBooks(ctx context.Context, bookIDs []int) error
+	// ReleaseBooks releases reserved books (increases available quantity).
+	// The quantities map contains the number of copies to release per book ID
+	ReleaseBooks(ctx context.Context, quantities map[int]int) error
 }
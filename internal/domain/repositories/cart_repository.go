@@ -10,8 +10,12 @@ import (
 
 // CartRepository defines methods for working with shopping cart in storage
 type CartRepository interface {
-	// AddItem adds an item to the user's cart
-	AddItem(ctx context.Context, userID int, bookID int, expiresAt time.Time) error
+	// SaveItem adds an item to the user's cart or replaces the item for the same book
+	SaveItem(ctx context.Context, userID int, item models.CartItem) error
+
+	// GetItem returns the cart item for the book
+	// Returns ErrNotFound if the book is not in the cart or the item has expired
+	GetItem(ctx context.Context, userID int, bookID int) (*models.CartItem, error)
 
 	// GetCart returns the user's cart
 	GetCart(ctx context.Context, userID int) (*models.Cart, error)
@@ -11,21 +11,36 @@ type CategoryRepository interface {
 	// Create creates a new category
 	Create(ctx context.Context, category *models.Category) error
 
-	// GetByID returns a category by ID
+	// GetByID returns a category by ID, deleted categories are not found
 	GetByID(ctx context.Context, id int) (*models.Category, error)
 
-	// GetByName returns a category by name
+	// GetByName returns a category by name, deleted categories are not found
 	GetByName(ctx context.Context, name string) (*models.Category, error)
 
-	// List returns a list of all categories
+	// List returns a list of all categories that are not deleted
 	List(ctx context.Context) ([]models.Category, error)
 
+	// GetPath returns the ancestors of a category from the root down to the category itself
+	GetPath(ctx context.Context, id int) ([]models.Category, error)
+
+	// Move sets the parent of a category, nil moves it to the root.
+	// Returns ErrCategoryCycle if the parent is the category or one of its descendants
+	Move(ctx context.Context, id int, parentID *int) error
+
 	// Update updates category data
 	Update(ctx context.Context, category *models.Category) error
 
-	// Delete deletes a category by ID
+	// Delete soft deletes a category by ID.
+	// Returns ErrCategoryHasChildren or ErrCategoryHasBooks if the category is not empty
 	Delete(ctx context.Context, id int) error
 
+	// Restore restores a deleted category by ID.
+	// Returns ErrCategoryParentDeleted if its parent is deleted
+	Restore(ctx context.Context, id int) error
+
+	// ListDeleted returns a list of deleted categories, the most recently deleted first
+	ListDeleted(ctx context.Context) ([]models.Category, error)
+
 	// GetCategoriesByIDs returns categories by a list of IDs
 	GetCategoriesByIDs(ctx context.Context, ids []int) ([]models.Category, error)
 }
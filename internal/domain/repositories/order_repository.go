@@ -17,8 +17,24 @@ type OrderRepository interface {
 	// GetByUserID returns a list of user's orders
 	GetByUserID(ctx context.Context, userID int) ([]models.Order, error)
 
-	// Update updates order status
-	UpdateStatus(ctx context.Context, id int, status string) error
+	// ListByUserID returns a page of user's orders, by offset or after or before a cursor
+	ListByUserID(ctx context.Context, filter models.OrderFilter) ([]models.Order, models.PageInfo, error)
+
+	// List returns a page of orders of all users matching the filter with their customers,
+	// without items. Pagination is the same as in ListByUserID
+	List(ctx context.Context, filter models.OrderFilter) ([]models.Order, models.PageInfo, error)
+
+	// Stream calls fn with every order matching the filter with its items and customer, newest first.
+	// Streaming stops at the first error returned by fn
+	Stream(ctx context.Context, filter models.OrderFilter, fn func(order *models.Order) error) error
+
+	// UpdateStatus moves an order from the previous status of the change to its new status
+	// and records the change in the order history.
+	// Returns ErrNotFound if the order does not have the previous status
+	UpdateStatus(ctx context.Context, change *models.OrderStatusChange) error
+
+	// GetStatusHistory returns the status changes of an order, oldest first
+	GetStatusHistory(ctx context.Context, orderID int) ([]models.OrderStatusChange, error)
 
 	// AddOrderItem adds an item to the order
 	AddOrderItem(ctx context.Context, orderID int, item models.OrderItem) error
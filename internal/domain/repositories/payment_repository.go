@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/bookshop/api/internal/domain/models"
+)
+
+// PaymentRepository defines methods for working with order payments in storage
+type PaymentRepository interface {
+	// Create creates a new payment.
+	// Returns ErrDuplicateKey if another payment of the order is in progress or captured
+	Create(ctx context.Context, payment *models.Payment) error
+
+	// GetByProviderRef returns a payment by the provider and its reference of the payment
+	GetByProviderRef(ctx context.Context, provider, ref string) (*models.Payment, error)
+
+	// GetCapturedByOrderID returns the captured payment of an order
+	GetCapturedByOrderID(ctx context.Context, orderID int) (*models.Payment, error)
+
+	// ListByOrderID returns the payments of an order, oldest first
+	ListByOrderID(ctx context.Context, orderID int) ([]models.Payment, error)
+
+	// UpdateStatus moves a payment from the previous status to its status,
+	// saving its provider reference and failure reason.
+	// Returns ErrNotFound if the payment does not have the previous status
+	UpdateStatus(ctx context.Context, payment *models.Payment, from string) error
+}
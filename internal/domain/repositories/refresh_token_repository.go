@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// RefreshTokenRepository defines methods for tracking refresh token families in storage.
+// A family is the chain of refresh tokens produced by rotation from a single login;
+// only the most recently issued token of a family is accepted.
+type RefreshTokenRepository interface {
+	// CreateFamily starts a new family whose current token is tokenID
+	CreateFamily(ctx context.Context, familyID string, userID int, tokenID string, ttl time.Duration) error
+
+	// Rotate atomically replaces the current token of the family with newTokenID.
+	// Returns ErrNotFound if the family does not exist (expired or revoked).
+	// If oldTokenID is not the current token, the family is revoked and
+	// errors.ErrTokenReused is returned
+	Rotate(ctx context.Context, familyID, oldTokenID, newTokenID string, ttl time.Duration) error
+
+	// RevokeFamily revokes all refresh tokens of the family
+	RevokeFamily(ctx context.Context, familyID string) error
+}
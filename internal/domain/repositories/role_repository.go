@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/bookshop/api/internal/domain/models"
+)
+
+// RoleRepository defines methods for working with roles and permissions in storage
+type RoleRepository interface {
+	// List returns all roles with their permissions
+	List(ctx context.Context) ([]models.Role, error)
+
+	// SetUserRoles replaces the roles assigned to the user.
+	// Returns domain ErrUserNotFound if the user does not exist
+	// and ErrNotFound if any of the roles does not exist
+	SetUserRoles(ctx context.Context, userID int, roles []string) error
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// TokenRevocationRepository defines methods for storing revoked tokens
+type TokenRevocationRepository interface {
+	// RevokeToken adds the token ID to the denylist until the token expires
+	RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+
+	// IsTokenRevoked checks if the token ID is in the denylist
+	IsTokenRevoked(ctx context.Context, tokenID string) (bool, error)
+
+	// SetUserRevocationTime sets the watermark before which all tokens of the user are invalid
+	SetUserRevocationTime(ctx context.Context, userID int, revokedAt time.Time) error
+
+	// GetUserRevocationTime returns the user's watermark or zero time if none is set
+	GetUserRevocationTime(ctx context.Context, userID int) (time.Time, error)
+}
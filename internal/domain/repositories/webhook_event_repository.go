@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/bookshop/api/internal/domain/models"
+)
+
+// WebhookEventRepository defines methods for working with received payment webhook events in storage
+type WebhookEventRepository interface {
+	// Record stores a received event, or counts another delivery of an event stored before.
+	// The ID and status of the stored event are set on the event
+	Record(ctx context.Context, event *models.StoredWebhookEvent) error
+
+	// Claim marks an event as being processed by the caller. Events that are received or failed can be claimed,
+	// as well as events whose processing started before staleBefore and never finished.
+	// Returns ErrNotFound if the event cannot be claimed
+	Claim(ctx context.Context, id int, staleBefore time.Time) error
+
+	// Finish records the outcome of processing an event, message says why it failed or was ignored
+	Finish(ctx context.Context, id int, status string, message string) error
+}
@@ -11,14 +11,21 @@ type AuthService interface {
 	// Register registers a new user
 	Register(ctx context.Context, input models.UserRegistration) (*models.User, error)
 
-	// Login authenticates a user and returns tokens
-	Login(ctx context.Context, input models.UserCredentials) (string, string, error)
+	// Login authenticates a user and returns a token pair
+	Login(ctx context.Context, input models.UserCredentials) (*TokenPair, error)
 
 	// ValidateToken validates a token and returns the user ID
 	ValidateToken(ctx context.Context, token string) (int, error)
 
-	// RefreshToken refreshes the access token
-	RefreshToken(ctx context.Context, refreshToken string) (string, string, error)
+	// RefreshToken rotates the refresh token and returns a new token pair
+	RefreshToken(ctx context.Context, refreshToken string) (*TokenPair, error)
+
+	// Logout revokes the session the refresh token belongs to and,
+	// if given, the access token used for the request
+	Logout(ctx context.Context, refreshToken, accessToken string) error
+
+	// RevokeUserTokens revokes all tokens issued to the user up to now
+	RevokeUserTokens(ctx context.Context, userID int) error
 
 	// GetUserByID returns a user by ID
 	GetUserByID(ctx context.Context, id int) (*models.User, error)
@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+
+	"github.com/bookshop/api/internal/domain/models"
+)
+
+// AuthorService defines methods for working with authors
+type AuthorService interface {
+	// GetByID returns an author by ID
+	GetByID(ctx context.Context, id int) (*models.Author, error)
+
+	// List returns a list of authors with their book counts
+	List(ctx context.Context, filter models.AuthorFilter) (*models.AuthorListResponse, error)
+}
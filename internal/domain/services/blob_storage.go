@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStorage stores binary objects such as book cover images under slash-separated keys,
+// e.g. "covers/42/5f1c/small.jpg". Stored objects are publicly readable by URL
+type BlobStorage interface {
+	// Put stores the object under the key, replacing any existing one, and returns its public URL
+	Put(ctx context.Context, key string, data io.Reader, contentType string) (string, error)
+
+	// DeletePrefix deletes all objects whose keys start with the prefix
+	DeletePrefix(ctx context.Context, prefix string) error
+}
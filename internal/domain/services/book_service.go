@@ -2,27 +2,57 @@ package services
 
 import (
 	"context"
+	"io"
 
 	"github.com/bookshop/api/internal/domain/models"
 )
 
+// BookImporter defines methods for bulk loading catalogs
+type BookImporter interface {
+	// Import upserts the books of a catalog file by ISBN.
+	// Rows that cannot be imported are reported, the other rows are imported regardless
+	Import(ctx context.Context, format string, data io.Reader) (*models.BookImportReport, error)
+}
+
+// BookExporter defines methods for dumping catalogs
+type BookExporter interface {
+	// Export writes every book matching the filter to w in the format, ignoring pages.
+	// Prices are in the base currency
+	Export(ctx context.Context, format string, filter models.BookFilter, w io.Writer) error
+}
+
 // BookService defines methods for working with books
 type BookService interface {
 	// Create creates a new book
 	Create(ctx context.Context, input models.BookCreate) (*models.Book, error)
 
-	// GetByID returns a book by ID
-	GetByID(ctx context.Context, id int) (*models.Book, error)
+	// GetByID returns a book by ID priced in the currency, an empty currency means the base currency
+	GetByID(ctx context.Context, id int, currency string) (*models.Book, error)
+
+	// GetByISBN returns a book by its ISBN-10 or ISBN-13 priced in the currency
+	GetByISBN(ctx context.Context, isbn string, currency string) (*models.Book, error)
 
-	// List returns a list of books with filtering
+	// List returns a list of books with filtering priced in the filter currency
 	List(ctx context.Context, filter models.BookFilter) (*models.BookListResponse, error)
 
+	// ListByAuthor returns a list of books the author contributed to with filtering
+	ListByAuthor(ctx context.Context, authorID int, filter models.BookFilter) (*models.BookListResponse, error)
+
 	// Update updates book data
 	Update(ctx context.Context, id int, input models.BookUpdate) (*models.Book, error)
 
-	// Delete deletes a book by ID
+	// SetCover stores the cover image of a book with its thumbnails, replacing the previous cover
+	SetCover(ctx context.Context, id int, cover io.Reader) (*models.Book, error)
+
+	// Delete soft deletes a book by ID, deleted books are only kept for order history
 	Delete(ctx context.Context, id int) error
 
+	// Restore restores a deleted book by ID
+	Restore(ctx context.Context, id int) (*models.Book, error)
+
+	// ListDeleted returns a list of deleted books with filtering
+	ListDeleted(ctx context.Context, filter models.BookFilter) (*models.BookListResponse, error)
+
 	// GetBooksByIDs returns books by a list of IDs
 	GetBooksByIDs(ctx context.Context, ids []int) ([]models.Book, error)
 }
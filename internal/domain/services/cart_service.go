@@ -8,11 +8,18 @@ import (
 
 // CartService defines methods for working with shopping cart
 type CartService interface {
-	// AddItem adds an item to the user's cart
+	// AddItem adds copies of a book to the user's cart
 	AddItem(ctx context.Context, userID int, input models.CartItemRequest) error
 
-	// GetCart returns the user's cart
-	GetCart(ctx context.Context, userID int) (*models.CartResponse, error)
+	// SetItemQuantity sets the number of copies of a book in the user's cart
+	SetItemQuantity(ctx context.Context, userID int, bookID int, quantity int) error
+
+	// IncrementItem changes the number of copies of a book in the user's cart by delta.
+	// The item is removed when its quantity drops to zero or below
+	IncrementItem(ctx context.Context, userID int, bookID int, delta int) error
+
+	// GetCart returns the user's cart priced in the currency, an empty currency means the base currency
+	GetCart(ctx context.Context, userID int, currency string) (*models.CartResponse, error)
 
 	// RemoveItem removes an item from the user's cart
 	RemoveItem(ctx context.Context, userID int, bookID int) error
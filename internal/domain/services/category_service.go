@@ -17,9 +17,24 @@ type CategoryService interface {
 	// List returns a list of all categories
 	List(ctx context.Context) ([]models.Category, error)
 
+	// Tree returns all categories as a tree of root categories ordered by name
+	Tree(ctx context.Context) ([]models.CategoryNode, error)
+
+	// Path returns the breadcrumb path of a category from the root down to the category itself
+	Path(ctx context.Context, id int) ([]models.Category, error)
+
+	// Move moves a category under another parent or to the root
+	Move(ctx context.Context, id int, input models.CategoryMove) (*models.Category, error)
+
 	// Update updates category data
 	Update(ctx context.Context, id int, input models.CategoryUpdate) (*models.Category, error)
 
-	// Delete deletes a category by ID
+	// Delete soft deletes a category by ID, only empty categories can be deleted
 	Delete(ctx context.Context, id int) error
+
+	// Restore restores a deleted category by ID
+	Restore(ctx context.Context, id int) (*models.Category, error)
+
+	// ListDeleted returns a list of deleted categories
+	ListDeleted(ctx context.Context) ([]models.Category, error)
 }
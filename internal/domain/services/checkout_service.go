@@ -8,8 +8,9 @@ import (
 
 // CheckoutService defines methods for checkout operations
 type CheckoutService interface {
-	// Checkout processes an order from the user's cart
-	Checkout(ctx context.Context, userID int) (*models.Order, error)
+	// Checkout processes an order from the user's cart priced in the currency.
+	// The order keeps the currency and the exchange rate used
+	Checkout(ctx context.Context, userID int, currency string) (*models.Order, error)
 
 	// GetOrderByID returns an order by ID
 	GetOrderByID(ctx context.Context, orderID int, userID int) (*models.Order, error)
@@ -17,6 +18,17 @@ type CheckoutService interface {
 	// GetOrdersByUserID returns a list of user's orders
 	GetOrdersByUserID(ctx context.Context, userID int) ([]models.Order, error)
 
-	// UpdateOrderStatus updates order status
-	UpdateOrderStatus(ctx context.Context, orderID int, status string) error
+	// ListOrdersByUserID returns a page of user's order history
+	ListOrdersByUserID(ctx context.Context, filter models.OrderFilter) (*models.OrderListResponse, error)
+
+	// UpdateOrderStatus moves an order to a new status allowed by the order lifecycle.
+	// changedBy is the user making the change, nil for changes made by the system
+	UpdateOrderStatus(ctx context.Context, orderID int, status string, changedBy *int, reason string) error
+
+	// CancelOrder cancels an order of the user that is not paid yet, returns its books to stock
+	// and publishes an OrderCanceledEvent
+	CancelOrder(ctx context.Context, orderID int, userID int, reason string) (*models.Order, error)
+
+	// GetOrderStatusHistory returns the status changes of an order, oldest first
+	GetOrderStatusHistory(ctx context.Context, orderID int) ([]models.OrderStatusChange, error)
 }
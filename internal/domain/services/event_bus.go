@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+
+	"github.com/bookshop/api/internal/domain/models"
+)
+
+// EventHandler reacts to an event. It runs after the change that published the event is committed
+type EventHandler func(ctx context.Context, event models.Event)
+
+// EventBus delivers events to the handlers subscribed to them
+type EventBus interface {
+	// Publish delivers the event to the handlers subscribed to its name without waiting for them
+	Publish(ctx context.Context, event models.Event)
+
+	// Subscribe registers the handler for events with the name
+	Subscribe(name string, handler EventHandler)
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"context"
+	"io"
+
+	"github.com/bookshop/api/internal/domain/models"
+)
+
+// OrderService defines methods for managing the orders of all users
+type OrderService interface {
+	// List returns a page of orders matching the filter with their customers, without items.
+	// Total bounds of the filter may be in any currency
+	List(ctx context.Context, filter models.OrderFilter) (*models.OrderListResponse, error)
+
+	// GetByID returns an order with its items, customer and status history
+	GetByID(ctx context.Context, id int) (*models.Order, error)
+
+	// UpdateStatus moves an order to a new status allowed by the order lifecycle,
+	// recording the admin making the change and the note in the order history
+	UpdateStatus(ctx context.Context, id int, status string, changedBy int, note string) (*models.Order, error)
+
+	// GetStatusHistory returns the status changes of an order, oldest first
+	GetStatusHistory(ctx context.Context, id int) ([]models.OrderStatusChange, error)
+
+	// Export writes every order matching the filter to w as CSV, one row per order item, ignoring pages
+	Export(ctx context.Context, filter models.OrderFilter, w io.Writer) error
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/pkg/money"
+)
+
+// PaymentGateway charges customers through a payment provider.
+// Amounts are reserved by an authorization and taken when it is captured
+type PaymentGateway interface {
+	// Name returns the name of the provider payments are recorded with
+	Name() string
+
+	// Authorize reserves the amount with the payment method and returns the provider reference of the payment.
+	// Returns ErrPaymentDeclined if the provider declines the payment
+	Authorize(ctx context.Context, auth models.PaymentAuthorization) (string, error)
+
+	// Capture takes the authorized amount
+	Capture(ctx context.Context, ref string, amount money.Money) error
+
+	// Void releases an authorization that was not captured
+	Void(ctx context.Context, ref string) error
+
+	// Refund returns the amount of a captured payment to the customer
+	Refund(ctx context.Context, ref string, amount money.Money) error
+
+	// VerifyWebhook checks the signature of a webhook payload and returns its event.
+	// Returns ErrInvalidWebhookSignature if the provider did not sign the payload
+	VerifyWebhook(payload []byte, signature string) (*models.PaymentWebhookEvent, error)
+}
+
+// PaymentService defines methods for paying orders
+type PaymentService interface {
+	// PayOrder pays an order of the user with the payment method token.
+	// The order is paid once the payment is captured. A declined payment returns ErrPaymentDeclined
+	// and the order can be paid again
+	PayOrder(ctx context.Context, orderID int, userID int, token string) (*models.Payment, error)
+
+	// RefundOrder refunds the captured payment of an order and moves the order to refunded
+	RefundOrder(ctx context.Context, orderID int, changedBy int, reason string) error
+
+	// ListPayments returns the payments of an order of the user, oldest first
+	ListPayments(ctx context.Context, orderID int, userID int) ([]models.Payment, error)
+
+	// HandleWebhook verifies a webhook of the provider, stores its event and applies the payment change
+	// it notifies exactly once, however often and in whatever order events are delivered.
+	// Returns ErrUnknownPaymentProvider for providers other than the one of the gateway
+	HandleWebhook(ctx context.Context, provider string, payload []byte, signature string) error
+}
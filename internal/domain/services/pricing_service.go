@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/pkg/money"
+)
+
+// ExchangeRateProvider supplies exchange rates between currencies
+type ExchangeRateProvider interface {
+	// GetRate returns the rate to convert amounts from one currency to another
+	GetRate(ctx context.Context, from, to string) (money.Rate, error)
+}
+
+// PricingService defines methods for pricing the catalog in the currencies the shop sells in.
+// Catalog prices are stored in the base currency and converted unless a book has a price
+// override in the requested currency
+type PricingService interface {
+	// BaseCurrency returns the currency catalog prices are stored in
+	BaseCurrency() string
+
+	// IsSupported checks if the shop sells in the currency
+	IsSupported(currency string) bool
+
+	// GetRate returns the rate from the base currency to the currency
+	GetRate(ctx context.Context, currency string) (money.Rate, error)
+
+	// PriceBooks replaces the book prices with prices in the currency and returns the rate used
+	PriceBooks(ctx context.Context, books []models.Book, currency string) (money.Rate, error)
+
+	// ToBase converts an amount to the base currency
+	ToBase(ctx context.Context, amount money.Money) (money.Money, error)
+
+	// SetPriceOverride sets the book price in the currency of price
+	SetPriceOverride(ctx context.Context, bookID int, price money.Money) error
+
+	// DeletePriceOverride removes the book price in the currency
+	DeletePriceOverride(ctx context.Context, bookID int, currency string) error
+}
@@ -0,0 +1,27 @@
+package services
+
+import (
+	"context"
+
+	"github.com/bookshop/api/internal/domain/models"
+)
+
+// PermissionChecker defines the permission lookup used to authorize requests
+type PermissionChecker interface {
+	// HasPermission checks if any of the roles grants the permission
+	HasPermission(ctx context.Context, roles []string, permission string) (bool, error)
+}
+
+// RoleService defines methods for role-based access control
+type RoleService interface {
+	PermissionChecker
+
+	// ListRoles returns all roles with their permissions
+	ListRoles(ctx context.Context) ([]models.Role, error)
+
+	// GetUserRoles returns the roles assigned to the user
+	GetUserRoles(ctx context.Context, userID int) ([]string, error)
+
+	// SetUserRoles replaces the roles assigned to the user and revokes the user's tokens
+	SetUserRoles(ctx context.Context, userID int, roles []string) ([]string, error)
+}
@@ -1,20 +1,60 @@
 package services
 
-import "time"
+import (
+	"context"
+	"time"
+)
+
+// TokenType distinguishes access tokens from refresh tokens
+type TokenType string
+
+const (
+	// TokenTypeAccess is a short-lived token used to authorize API requests
+	TokenTypeAccess TokenType = "access"
+	// TokenTypeRefresh is a long-lived token used only to obtain a new token pair
+	TokenTypeRefresh TokenType = "refresh"
+)
 
 // TokenManager defines methods for working with tokens
 type TokenManager interface {
-	// CreateToken creates a new token
-	CreateToken(userID int, isAdmin bool, ttl time.Duration) (string, error)
-	// ValidateToken validates the token and returns user ID
-	ValidateToken(token string) (int, error)
-	// ParseToken parses the token and returns its information
-	ParseToken(token string) (*TokenClaims, error)
+	// CreateTokenPair issues a new access/refresh token pair starting a new refresh token family
+	CreateTokenPair(ctx context.Context, userID int, roles []string) (*TokenPair, error)
+	// RotateRefreshToken exchanges a refresh token for a new pair within the same family.
+	// Presenting a refresh token that was already rotated revokes the whole family
+	RotateRefreshToken(ctx context.Context, refreshToken string, roles []string) (*TokenPair, error)
+	// RevokeRefreshToken revokes the family the refresh token belongs to
+	RevokeRefreshToken(ctx context.Context, refreshToken string) error
+	// ParseAccessToken validates an access token and returns its information
+	ParseAccessToken(token string) (*TokenClaims, error)
+	// ParseRefreshToken validates a refresh token signature and expiration and returns its information
+	ParseRefreshToken(token string) (*TokenClaims, error)
+}
+
+// TokenRevocationService defines methods for server-side token revocation
+type TokenRevocationService interface {
+	// RevokeToken revokes a single token until it expires
+	RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	// RevokeUserTokens revokes all tokens issued to the user up to now
+	RevokeUserTokens(ctx context.Context, userID int) error
+	// IsRevoked checks if a token was revoked individually or by the user's watermark
+	IsRevoked(ctx context.Context, tokenID string, userID int, issuedAt time.Time) (bool, error)
 }
 
 // TokenClaims represents token data
 type TokenClaims struct {
-	UserID  int
-	IsAdmin bool
-	Exp     time.Time
+	TokenID  string
+	FamilyID string
+	Type     TokenType
+	UserID   int
+	Roles    []string
+	IssuedAt time.Time
+	Exp      time.Time
+}
+
+// TokenPair represents an issued access/refresh token pair
+type TokenPair struct {
+	AccessToken           string
+	RefreshToken          string
+	AccessTokenExpiresAt  time.Time
+	RefreshTokenExpiresAt time.Time
 }
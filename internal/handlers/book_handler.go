@@ -3,9 +3,11 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/bookshop/api/internal/domain/models"
 	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/pkg/money"
 	"github.com/labstack/echo/v4"
 )
 
@@ -88,7 +90,7 @@ func (h *BookHandler) getBook(c echo.Context) error {
 	}
 
 	// Get book
-	book, err := h.bookService.GetByID(c.Request().Context(), id)
+	book, err := h.bookService.GetByID(c.Request().Context(), id, "")
 	if err != nil {
 		return c.JSON(http.StatusNotFound, map[string]string{"error": "book not found"})
 	}
@@ -102,12 +104,18 @@ func (h *BookHandler) getBook(c echo.Context) error {
 // @Tags books
 // @Accept json
 // @Produce json
+// @Param q query string false "Search over titles and authors, the last word may be a prefix"
 // @Param category_ids query []int false "Category IDs"
+// @Param include_subcategories query bool false "Also match books in subcategories of the categories"
 // @Param min_price query number false "Minimum price"
 // @Param max_price query number false "Maximum price"
 // @Param in_stock query bool false "In stock only"
 // @Param page query int false "Page number"
 // @Param page_size query int false "Page size"
+// @Param after query string false "Cursor of the page to read the next page after"
+// @Param before query string false "Cursor of the page to read the previous page before"
+// @Param include_total query bool false "Count the total in cursor mode too"
+// @Param facets query bool false "Count the matching books per category, price range, decade and stock"
 // @Success 200 {object} models.BookListResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -128,6 +136,20 @@ func (h *BookHandler) listBooks(c echo.Context) error {
 		filter.PageSize = pageSize
 	}
 
+	// Get cursors
+	var err error
+	if filter.After, err = models.DecodeCursor(c.QueryParam("after")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if filter.Before, err = models.DecodeCursor(c.QueryParam("before")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	filter.IncludeTotal = c.QueryParam("include_total") == "true"
+	filter.Facets = c.QueryParam("facets") == "true"
+
+	// Get search query
+	filter.Query = strings.TrimSpace(c.QueryParam("q"))
+
 	// Get categories
 	if categoryIDs := c.QueryParam("category_ids"); categoryIDs != "" {
 		// Parse category ID list
@@ -137,13 +159,16 @@ func (h *BookHandler) listBooks(c echo.Context) error {
 			}
 		}
 	}
+	filter.IncludeSubcategories = c.QueryParam("include_subcategories") == "true"
 
 	// Get price range
-	if minPrice, err := strconv.ParseFloat(c.QueryParam("min_price"), 64); err == nil && minPrice >= 0 {
+	var minPrice money.Money
+	if err := minPrice.UnmarshalParam(c.QueryParam("min_price")); err == nil && !minPrice.IsNegative() {
 		filter.MinPrice = &minPrice
 	}
 
-	if maxPrice, err := strconv.ParseFloat(c.QueryParam("max_price"), 64); err == nil && maxPrice >= 0 {
+	var maxPrice money.Money
+	if err := maxPrice.UnmarshalParam(c.QueryParam("max_price")); err == nil && !maxPrice.IsNegative() {
 		filter.MaxPrice = &maxPrice
 	}
 
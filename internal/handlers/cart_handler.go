@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
 	"github.com/bookshop/api/internal/domain/models"
 	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/internal/middleware"
 	"github.com/labstack/echo/v4"
 )
 
@@ -26,17 +29,37 @@ func (h *CartHandler) RegisterRoutes(router *echo.Group) {
 	cart := router.Group("/cart")
 	cart.GET("", h.getCart)
 	cart.POST("/items", h.addItem)
+	cart.PUT("/items/:id", h.setItemQuantity)
+	cart.POST("/items/:id/increment", h.incrementItem)
 	cart.DELETE("/items/:id", h.removeItem)
 	cart.DELETE("", h.clearCart)
 }
 
+// handleCartError maps cart service errors to HTTP responses
+func handleCartError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domainerrors.ErrBookNotFound), errors.Is(err, domainerrors.ErrItemNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, domainerrors.ErrOutOfStock):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case errors.Is(err, domainerrors.ErrInvalidData):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, domainerrors.ErrExchangeRateUnavailable):
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal server error"})
+	}
+}
+
 // getCart handles the request to get cart contents
 // @Summary Get cart
 // @Description Returns the user's cart contents
 // @Tags cart
 // @Accept json
 // @Produce json
+// @Param currency query string false "Currency to price the cart in, overrides the Accept-Currency header"
 // @Success 200 {object} models.CartResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /cart [get]
@@ -45,9 +68,9 @@ func (h *CartHandler) getCart(c echo.Context) error {
 	userID := getUserIDFromContext(c)
 
 	// Get cart
-	cart, err := h.cartService.GetCart(c.Request().Context(), userID)
+	cart, err := h.cartService.GetCart(c.Request().Context(), userID, middleware.CurrencyFromContext(c))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return handleCartError(c, err)
 	}
 
 	return c.JSON(http.StatusOK, cart)
@@ -55,7 +78,7 @@ func (h *CartHandler) getCart(c echo.Context) error {
 
 // addItem handles the request to add an item to the cart
 // @Summary Add item to cart
-// @Description Adds an item to the user's cart
+// @Description Adds copies of a book to the user's cart. Copies already in the cart are added to
 // @Tags cart
 // @Accept json
 // @Produce json
@@ -64,6 +87,7 @@ func (h *CartHandler) getCart(c echo.Context) error {
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /cart/items [post]
 func (h *CartHandler) addItem(c echo.Context) error {
@@ -83,18 +107,116 @@ func (h *CartHandler) addItem(c echo.Context) error {
 	// Add item to cart
 	err := h.cartService.AddItem(c.Request().Context(), userID, req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return handleCartError(c, err)
 	}
 
 	// Get updated cart
-	cart, err := h.cartService.GetCart(c.Request().Context(), userID)
+	cart, err := h.cartService.GetCart(c.Request().Context(), userID, middleware.CurrencyFromContext(c))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return handleCartError(c, err)
 	}
 
 	return c.JSON(http.StatusCreated, cart)
 }
 
+// setItemQuantity handles the request to set the quantity of a cart item
+// @Summary Set cart item quantity
+// @Description Sets the number of copies of a book in the user's cart
+// @Tags cart
+// @Accept json
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param item body models.CartItemQuantityRequest true "Quantity"
+// @Success 200 {object} models.CartResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /cart/items/{id} [put]
+func (h *CartHandler) setItemQuantity(c echo.Context) error {
+	// Get user ID from context
+	userID := getUserIDFromContext(c)
+
+	// Get book ID from request parameters
+	bookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid item ID"})
+	}
+
+	var req models.CartItemQuantityRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request format"})
+	}
+
+	// Validate request
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	// Set item quantity
+	if err := h.cartService.SetItemQuantity(c.Request().Context(), userID, bookID, req.Quantity); err != nil {
+		return handleCartError(c, err)
+	}
+
+	// Get updated cart
+	cart, err := h.cartService.GetCart(c.Request().Context(), userID, middleware.CurrencyFromContext(c))
+	if err != nil {
+		return handleCartError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, cart)
+}
+
+// incrementItem handles the request to change the quantity of a cart item
+// @Summary Increment cart item quantity
+// @Description Changes the number of copies of a book in the user's cart by delta. The item is removed when its quantity drops to zero
+// @Tags cart
+// @Accept json
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param item body models.CartItemIncrementRequest true "Quantity change"
+// @Success 200 {object} models.CartResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /cart/items/{id}/increment [post]
+func (h *CartHandler) incrementItem(c echo.Context) error {
+	// Get user ID from context
+	userID := getUserIDFromContext(c)
+
+	// Get book ID from request parameters
+	bookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid item ID"})
+	}
+
+	var req models.CartItemIncrementRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request format"})
+	}
+
+	// Validate request
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	// Change item quantity
+	if err := h.cartService.IncrementItem(c.Request().Context(), userID, bookID, req.Delta); err != nil {
+		return handleCartError(c, err)
+	}
+
+	// Get updated cart
+	cart, err := h.cartService.GetCart(c.Request().Context(), userID, middleware.CurrencyFromContext(c))
+	if err != nil {
+		return handleCartError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, cart)
+}
+
 // removeItem handles the request to remove an item from the cart
 // @Summary Remove item from cart
 // @Description Removes an item from the user's cart
@@ -125,9 +247,9 @@ func (h *CartHandler) removeItem(c echo.Context) error {
 	}
 
 	// Get updated cart
-	cart, err := h.cartService.GetCart(c.Request().Context(), userID)
+	cart, err := h.cartService.GetCart(c.Request().Context(), userID, middleware.CurrencyFromContext(c))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return handleCartError(c, err)
 	}
 
 	return c.JSON(http.StatusOK, cart)
@@ -157,7 +279,7 @@ func (h *CartHandler) clearCart(c echo.Context) error {
 
 // getUserIDFromContext extracts user ID from the request context
 func getUserIDFromContext(c echo.Context) int {
-	userID, ok := c.Get("user_id").(int)
+	userID, ok := c.Get("userID").(int)
 	if !ok {
 		return 0
 	}
@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
 	"github.com/bookshop/api/internal/domain/models"
 	"github.com/bookshop/api/internal/domain/services"
 	"github.com/labstack/echo/v4"
@@ -21,18 +23,43 @@ func NewCategoryHandler(categoryService services.CategoryService) *CategoryHandl
 	}
 }
 
-// RegisterRoutes registers routes for handling category requests
+// RegisterRoutes registers public routes for handling category requests
 func (h *CategoryHandler) RegisterRoutes(router *echo.Group) {
-	// Public routes
 	categories := router.Group("/categories")
 	categories.GET("", h.listCategories)
+	categories.GET("/tree", h.getCategoryTree)
 	categories.GET("/:id", h.getCategory)
+	categories.GET("/:id/path", h.getCategoryPath)
+}
+
+// RegisterAdminRoutes registers category management routes on the admin router group.
+// The middleware is applied to every management route
+func (h *CategoryHandler) RegisterAdminRoutes(router *echo.Group, m ...echo.MiddlewareFunc) {
+	categories := router.Group("/categories", m...)
+	categories.POST("", h.createCategory)
+	categories.PUT("/:id", h.updateCategory)
+	categories.PUT("/:id/parent", h.moveCategory)
+	categories.DELETE("/:id", h.deleteCategory)
+	categories.GET("/deleted", h.listDeletedCategories)
+	categories.POST("/:id/restore", h.restoreCategory)
+}
 
-	// Admin routes
-	admin := router.Group("/admin/categories")
-	admin.POST("", h.createCategory)
-	admin.PUT("/:id", h.updateCategory)
-	admin.DELETE("/:id", h.deleteCategory)
+// handleCategoryError maps category service errors to HTTP responses
+func handleCategoryError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domainerrors.ErrCategoryNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, domainerrors.ErrCategoryExists),
+		errors.Is(err, domainerrors.ErrCategoryCycle),
+		errors.Is(err, domainerrors.ErrCategoryHasChildren),
+		errors.Is(err, domainerrors.ErrCategoryHasBooks),
+		errors.Is(err, domainerrors.ErrCategoryParentDeleted):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case errors.Is(err, domainerrors.ErrInvalidData):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal server error"})
+	}
 }
 
 // listCategories handles the request to get a list of categories
@@ -48,12 +75,59 @@ func (h *CategoryHandler) listCategories(c echo.Context) error {
 	// Get list of categories
 	categories, err := h.categoryService.List(c.Request().Context())
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return handleCategoryError(c, err)
 	}
 
 	return c.JSON(http.StatusOK, categories)
 }
 
+// getCategoryTree handles the request to get the category tree
+// @Summary Get category tree
+// @Description Returns all categories as a tree of root categories, siblings are ordered by name
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.CategoryNode
+// @Failure 500 {object} ErrorResponse
+// @Router /categories/tree [get]
+func (h *CategoryHandler) getCategoryTree(c echo.Context) error {
+	// Get category tree
+	tree, err := h.categoryService.Tree(c.Request().Context())
+	if err != nil {
+		return handleCategoryError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, tree)
+}
+
+// getCategoryPath handles the request to get the breadcrumb path of a category
+// @Summary Get category path
+// @Description Returns the categories from the root down to the category
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path int true "Category ID"
+// @Success 200 {array} models.Category
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /categories/{id}/path [get]
+func (h *CategoryHandler) getCategoryPath(c echo.Context) error {
+	// Get category ID from request parameters
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid category ID"})
+	}
+
+	// Get category path
+	path, err := h.categoryService.Path(c.Request().Context(), id)
+	if err != nil {
+		return handleCategoryError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, path)
+}
+
 // getCategory handles the request to get category information
 // @Summary Get category
 // @Description Returns category information by ID
@@ -76,7 +150,7 @@ func (h *CategoryHandler) getCategory(c echo.Context) error {
 	// Get category
 	category, err := h.categoryService.GetByID(c.Request().Context(), id)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, map[string]string{"error": "category not found"})
+		return handleCategoryError(c, err)
 	}
 
 	return c.JSON(http.StatusOK, category)
@@ -88,10 +162,13 @@ func (h *CategoryHandler) getCategory(c echo.Context) error {
 // @Tags admin,categories
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param category body models.CategoryCreate true "Category data"
 // @Success 201 {object} models.Category
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /admin/categories [post]
 func (h *CategoryHandler) createCategory(c echo.Context) error {
@@ -108,7 +185,7 @@ func (h *CategoryHandler) createCategory(c echo.Context) error {
 	// Create category
 	category, err := h.categoryService.Create(c.Request().Context(), req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return handleCategoryError(c, err)
 	}
 
 	return c.JSON(http.StatusCreated, category)
@@ -121,11 +198,14 @@ func (h *CategoryHandler) createCategory(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param id path int true "Category ID"
+// @Security BearerAuth
 // @Param category body models.CategoryUpdate true "Category data"
 // @Success 200 {object} models.Category
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /admin/categories/{id} [put]
 func (h *CategoryHandler) updateCategory(c echo.Context) error {
@@ -148,7 +228,50 @@ func (h *CategoryHandler) updateCategory(c echo.Context) error {
 	// Update category
 	category, err := h.categoryService.Update(c.Request().Context(), id, req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return handleCategoryError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, category)
+}
+
+// moveCategory handles the request to move a category in the tree
+// @Summary Move category
+// @Description Moves a category and its subcategories under another parent, or to the root if the parent is null
+// @Tags admin,categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Param parent body models.CategoryMove true "New parent"
+// @Success 200 {object} models.Category
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/categories/{id}/parent [put]
+func (h *CategoryHandler) moveCategory(c echo.Context) error {
+	// Get category ID from request parameters
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid category ID"})
+	}
+
+	var req models.CategoryMove
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request format"})
+	}
+
+	// Validate request
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	// Move category
+	category, err := h.categoryService.Move(c.Request().Context(), id, req)
+	if err != nil {
+		return handleCategoryError(c, err)
 	}
 
 	return c.JSON(http.StatusOK, category)
@@ -156,15 +279,18 @@ func (h *CategoryHandler) updateCategory(c echo.Context) error {
 
 // deleteCategory handles the request to delete a category
 // @Summary Delete category
-// @Description Deletes a category by ID
+// @Description Soft deletes a category by ID. Categories with subcategories or books cannot be deleted
 // @Tags admin,categories
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int true "Category ID"
 // @Success 204 "No Content"
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /admin/categories/{id} [delete]
 func (h *CategoryHandler) deleteCategory(c echo.Context) error {
@@ -176,8 +302,62 @@ func (h *CategoryHandler) deleteCategory(c echo.Context) error {
 
 	// Delete category
 	if err := h.categoryService.Delete(c.Request().Context(), id); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return handleCategoryError(c, err)
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+// listDeletedCategories handles the request to get the list of deleted categories
+// @Summary Get deleted category list
+// @Description Returns the deleted categories, the most recently deleted first
+// @Tags admin,categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Category
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/categories/deleted [get]
+func (h *CategoryHandler) listDeletedCategories(c echo.Context) error {
+	// Get list of deleted categories
+	categories, err := h.categoryService.ListDeleted(c.Request().Context())
+	if err != nil {
+		return handleCategoryError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, categories)
+}
+
+// restoreCategory handles the request to restore a deleted category
+// @Summary Restore category
+// @Description Restores a deleted category under its parent, which must not be deleted
+// @Tags admin,categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Success 200 {object} models.Category
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/categories/{id}/restore [post]
+func (h *CategoryHandler) restoreCategory(c echo.Context) error {
+	// Get category ID from request parameters
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid category ID"})
+	}
+
+	// Restore category
+	category, err := h.categoryService.Restore(c.Request().Context(), id)
+	if err != nil {
+		return handleCategoryError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, category)
+}
@@ -13,6 +13,9 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// maxOrderPageSize is the largest page of orders, every order is loaded with its items
+const maxOrderPageSize = 100
+
 // CheckoutHandler handles requests related to order processing
 type CheckoutHandler struct {
 	checkoutService services.CheckoutService
@@ -27,71 +30,124 @@ func NewCheckoutHandler(checkoutService services.CheckoutService) *CheckoutHandl
 
 // RegisterRoutes registers routes for order processing
 func (h *CheckoutHandler) RegisterRoutes(router *echo.Group) {
-	// Order routes (require authentication)
+	// Order routes (the router group is expected to require authentication)
 	orders := router.Group("/orders")
-	orders.Use(middleware.AuthMiddleware(middleware.NewJWTConfig("your-secret-key")))
 	{
 		orders.POST("", h.createOrder)
 		orders.GET("", h.getUserOrders)
 		orders.GET("/:id", h.getOrderByID)
+		orders.POST("/:id/cancel", h.cancelOrder)
+	}
+}
+
+// handleCheckoutError maps checkout service errors to HTTP responses
+func handleCheckoutError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, domainerrors.ErrEmptyCart),
+		errors.Is(err, domainerrors.ErrUnsupportedCurrency),
+		errors.Is(err, domainerrors.ErrInvalidOrderStatus):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, domainerrors.ErrOrderNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, domainerrors.ErrOutOfStock),
+		errors.Is(err, domainerrors.ErrInvalidOrderTransition),
+		errors.Is(err, domainerrors.ErrOrderCanceled):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case errors.Is(err, domainerrors.ErrExchangeRateUnavailable):
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 }
 
 // createOrder handles the request to create an order from the user's cart
 // @Summary Create order
-// @Description Creates a new order from the user's cart
+// @Description Creates a new order from the user's cart. The order is priced in the request currency
+// @Description and keeps the exchange rate used
 // @Tags orders
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param currency query string false "Currency to place the order in, overrides the Accept-Currency header"
 // @Success 201 {object} models.Order
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
 // @Router /orders [post]
 func (h *CheckoutHandler) createOrder(c echo.Context) error {
 	// Get user ID from context
 	userID := c.Get("userID").(int)
 
 	// Create order
-	order, err := h.checkoutService.Checkout(c.Request().Context(), userID)
+	order, err := h.checkoutService.Checkout(c.Request().Context(), userID, middleware.CurrencyFromContext(c))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return handleCheckoutError(c, err)
 	}
 
 	// Return response
 	return c.JSON(http.StatusCreated, order)
 }
 
-// getUserOrders handles the request to get the list of user's orders
+// getUserOrders handles the request to get a page of the user's order history
 // @Summary Get user orders
-// @Description Returns a list of orders for the current user
+// @Description Returns a page of orders of the current user, newest first
 // @Tags orders
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {array} models.Order
+// @Param page query int false "Page number, ignored with a cursor"
+// @Param page_size query int false "Page size, at most 100"
+// @Param after query string false "Cursor of the page to read the next page after"
+// @Param before query string false "Cursor of the page to read the previous page before"
+// @Param include_total query bool false "Count the total in cursor mode too, page mode always counts it"
+// @Success 200 {object} models.OrderListResponse
+// @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /orders [get]
 func (h *CheckoutHandler) getUserOrders(c echo.Context) error {
-	// Get user ID from context
-	userID := c.Get("userID").(int)
+	// Create filter for the user from context
+	filter := models.OrderFilter{
+		UserID:   c.Get("userID").(int),
+		Page:     1,
+		PageSize: 10,
+	}
 
-	// Get user's orders
-	orders, err := h.checkoutService.GetOrdersByUserID(c.Request().Context(), userID)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	// Get pagination parameters from request
+	if page, err := strconv.Atoi(c.QueryParam("page")); err == nil && page > 0 {
+		filter.Page = page
+	}
+
+	if pageSize, err := strconv.Atoi(c.QueryParam("page_size")); err == nil && pageSize > 0 {
+		filter.PageSize = min(pageSize, maxOrderPageSize)
 	}
 
-	// Convert orders to response format
-	response := make([]models.Order, len(orders))
-	for i, order := range orders {
-		response[i] = order
+	var err error
+	if filter.After, err = models.DecodeCursor(c.QueryParam("after")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if filter.Before, err = models.DecodeCursor(c.QueryParam("before")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if filter.After != nil && filter.Before != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "after and before cannot be used together"})
+	}
+
+	filter.IncludeTotal = c.QueryParam("include_total") == "true"
+
+	// Get a page of user's orders
+	orders, err := h.checkoutService.ListOrdersByUserID(c.Request().Context(), filter)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvalidCursor) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
 	// Return response
-	return c.JSON(http.StatusOK, response)
+	return c.JSON(http.StatusOK, orders)
 }
 
 // getOrderByID handles the request to get an order by ID
@@ -120,7 +176,7 @@ func (h *CheckoutHandler) getOrderByID(c echo.Context) error {
 	// Get order
 	order, err := h.checkoutService.GetOrderByID(c.Request().Context(), orderID, userID)
 	if err != nil {
-		if errors.Is(err, domainerrors.ErrNotFound) {
+		if errors.Is(err, domainerrors.ErrNotFound) || errors.Is(err, domainerrors.ErrOrderNotFound) {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "order not found"})
 		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
@@ -129,3 +185,49 @@ func (h *CheckoutHandler) getOrderByID(c echo.Context) error {
 	// Return response
 	return c.JSON(http.StatusOK, order)
 }
+
+// cancelOrder handles the request of a customer to cancel an order
+// @Summary Cancel order
+// @Description Cancels an order of the user that is still pending or awaiting payment.
+// @Description Its books are returned to stock and the reason is recorded in the order history
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Param request body models.OrderCancelRequest false "Cancellation reason"
+// @Success 200 {object} models.Order
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /orders/{id}/cancel [post]
+func (h *CheckoutHandler) cancelOrder(c echo.Context) error {
+	// Get user ID from context
+	userID := c.Get("userID").(int)
+
+	// Get order ID from request parameters
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid order ID"})
+	}
+
+	var req models.OrderCancelRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request format"})
+	}
+
+	// Validate request
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	// Cancel the order
+	order, err := h.checkoutService.CancelOrder(c.Request().Context(), orderID, userID, req.Reason)
+	if err != nil {
+		return handleCheckoutError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, order)
+}
@@ -3,7 +3,9 @@ package middleware
 import (
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/bookshop/api/internal/domain/services"
 	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
 )
@@ -11,12 +13,15 @@ import (
 // JWTConfig contains settings for JWT authentication
 type JWTConfig struct {
 	SecretKey string
+	// Revocations is used to reject revoked tokens; the check is skipped if nil
+	Revocations services.TokenRevocationService
 }
 
 // NewJWTConfig creates a new instance of JWTConfig
-func NewJWTConfig(secretKey string) *JWTConfig {
+func NewJWTConfig(secretKey string, revocations services.TokenRevocationService) *JWTConfig {
 	return &JWTConfig{
-		SecretKey: secretKey,
+		SecretKey:   secretKey,
+		Revocations: revocations,
 	}
 }
 
@@ -51,19 +56,43 @@ func AuthMiddleware(config *JWTConfig) echo.MiddlewareFunc {
 
 			// Verify token validity
 			if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
+				// Only access tokens may be used to authorize requests
+				if tokenType, _ := claims["token_type"].(string); tokenType != string(services.TokenTypeAccess) {
+					return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid token type"})
+				}
+
 				// Get user ID from token
 				userID, ok := claims["user_id"].(float64)
 				if !ok {
 					return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid user ID format"})
 				}
 
+				// Get issue time from token, it is compared with revocation watermarks
+				issuedAt, ok := issuedAtFromClaims(claims)
+				if !ok {
+					return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid issue time format"})
+				}
+
+				// Check server-side revocation
+				if config.Revocations != nil {
+					tokenID, _ := claims["jti"].(string)
+
+					revoked, err := config.Revocations.IsRevoked(
+						c.Request().Context(), tokenID, int(userID), issuedAt,
+					)
+					if err != nil {
+						return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "unable to verify token"})
+					}
+					if revoked {
+						return c.JSON(http.StatusUnauthorized, map[string]string{"error": "token has been revoked"})
+					}
+				}
+
 				// Save user ID in context
 				c.Set("userID", int(userID))
 
-				// Check user role if present
-				if role, ok := claims["role"].(string); ok {
-					c.Set("userRole", role)
-				}
+				// Save user roles in context
+				c.Set("userRoles", rolesFromClaims(claims))
 
 				return next(c)
 			}
@@ -73,13 +102,19 @@ func AuthMiddleware(config *JWTConfig) echo.MiddlewareFunc {
 	}
 }
 
-// AdminMiddleware creates middleware for checking admin role
-func AdminMiddleware() echo.MiddlewareFunc {
+// RequirePermission creates middleware for checking that one of the user roles grants the permission.
+// It must be used after AuthMiddleware
+func RequirePermission(checker services.PermissionChecker, permission string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			// Get user role from context
-			role, ok := c.Get("userRole").(string)
-			if !ok || role != "admin" {
+			// Get user roles from context
+			roles, _ := c.Get("userRoles").([]string)
+
+			allowed, err := checker.HasPermission(c.Request().Context(), roles, permission)
+			if err != nil {
+				return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "unable to verify permissions"})
+			}
+			if !allowed {
 				return c.JSON(http.StatusForbidden, map[string]string{"error": "access denied"})
 			}
 
@@ -87,3 +122,24 @@ func AdminMiddleware() echo.MiddlewareFunc {
 		}
 	}
 }
+
+// issuedAtFromClaims returns the issue time of a token from the iat_us claim in microseconds
+func issuedAtFromClaims(claims jwt.MapClaims) (time.Time, bool) {
+	usec, ok := claims["iat_us"].(float64)
+	if !ok || usec <= 0 {
+		return time.Time{}, false
+	}
+	return time.UnixMicro(int64(usec)), true
+}
+
+// rolesFromClaims extracts role names from the roles claim
+func rolesFromClaims(claims jwt.MapClaims) []string {
+	values, _ := claims["roles"].([]interface{})
+	roles := make([]string, 0, len(values))
+	for _, value := range values {
+		if role, ok := value.(string); ok {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
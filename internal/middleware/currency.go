@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/labstack/echo/v4"
+)
+
+// currencyContextKey is the context key of the currency prices are shown in
+const currencyContextKey = "currency"
+
+// Currency creates middleware that resolves the currency prices are shown in.
+// The currency query parameter wins over the Accept-Currency header, e.g. "EUR, GBP;q=0.5".
+// An unsupported currency in the query parameter is rejected, unsupported header entries are
+// skipped and the base currency is used when nothing matches
+func Currency(pricing services.PricingService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			currency := pricing.BaseCurrency()
+
+			if requested := strings.ToUpper(strings.TrimSpace(c.QueryParam("currency"))); requested != "" {
+				if !pricing.IsSupported(requested) {
+					return c.JSON(http.StatusBadRequest, map[string]string{"error": "unsupported currency: " + requested})
+				}
+				currency = requested
+			} else {
+				for _, accepted := range parseAcceptCurrency(c.Request().Header.Get("Accept-Currency")) {
+					if pricing.IsSupported(accepted) {
+						currency = accepted
+						break
+					}
+				}
+			}
+
+			c.Set(currencyContextKey, currency)
+			c.Response().Header().Set("Content-Currency", currency)
+			return next(c)
+		}
+	}
+}
+
+// CurrencyFromContext returns the currency resolved by the Currency middleware.
+// An empty string means the base currency
+func CurrencyFromContext(c echo.Context) string {
+	currency, _ := c.Get(currencyContextKey).(string)
+	return currency
+}
+
+// parseAcceptCurrency returns the currencies of an Accept-Currency header by descending quality
+func parseAcceptCurrency(header string) []string {
+	type accepted struct {
+		currency string
+		quality  float64
+	}
+
+	var entries []accepted
+	for _, part := range strings.Split(header, ",") {
+		currency, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		currency = strings.ToUpper(strings.TrimSpace(currency))
+		if currency == "" {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if q, err := strconv.ParseFloat(value, 64); err == nil {
+				quality = q
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		entries = append(entries, accepted{currency: currency, quality: quality})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].quality > entries[j].quality
+	})
+
+	currencies := make([]string, len(entries))
+	for i, entry := range entries {
+		currencies[i] = entry.currency
+	}
+	return currencies
+}
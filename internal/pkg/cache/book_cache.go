@@ -6,16 +6,17 @@ import (
 	"time"
 
 	"github.com/bookshop/api/pkg/logger"
+	"github.com/bookshop/api/pkg/money"
 )
 
 // Book represents a book model for caching
 type Book struct {
-	ID          int       `json:"id"`
-	Title       string    `json:"title"`
-	Author      string    `json:"author"`
-	Price       float64   `json:"price"`
-	Quantity    int       `json:"quantity"`
-	LastUpdated time.Time `json:"last_updated"`
+	ID          int         `json:"id"`
+	Title       string      `json:"title"`
+	Author      string      `json:"author"`
+	Price       money.Money `json:"price"`
+	Quantity    int         `json:"quantity"`
+	LastUpdated time.Time   `json:"last_updated"`
 }
 
 // BookCache provides caching functionality for books
@@ -0,0 +1,59 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/pkg/logger"
+)
+
+// Bus delivers events to handlers in the same process.
+// Every handler runs in its own goroutine, a handler that panics is logged and does not affect the others
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]services.EventHandler
+	logger   logger.Logger
+}
+
+// NewBus creates an event bus without subscribers
+func NewBus(logger logger.Logger) services.EventBus {
+	return &Bus{
+		handlers: make(map[string][]services.EventHandler),
+		logger:   logger,
+	}
+}
+
+// Subscribe registers the handler for events with the name
+func (b *Bus) Subscribe(name string, handler services.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// Publish starts the handlers of the event. They outlive the request that published it,
+// so they get its context values without its cancellation
+func (b *Bus) Publish(ctx context.Context, event models.Event) {
+	b.mu.RLock()
+	handlers := b.handlers[event.EventName()]
+	b.mu.RUnlock()
+
+	ctx = context.WithoutCancel(ctx)
+	for _, handler := range handlers {
+		go b.run(ctx, handler, event)
+	}
+}
+
+// run calls the handler and recovers from its panics
+func (b *Bus) run(ctx context.Context, handler services.EventHandler, event models.Event) {
+	defer func() {
+		if p := recover(); p != nil {
+			b.logger.Error("Event handler panicked", "event", event.EventName(), "error", fmt.Sprint(p))
+		}
+	}()
+
+	handler(ctx, event)
+}
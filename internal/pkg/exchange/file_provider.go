@@ -0,0 +1,42 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/pkg/money"
+)
+
+// FileProvider serves exchange rates from a JSON file in the RatesDocument format.
+// The file is read once, so rates change on restart
+type FileProvider struct {
+	table *rateTable
+}
+
+// NewFileProvider reads exchange rates from the file
+func NewFileProvider(path string) (services.ExchangeRateProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading exchange rates file: %w", err)
+	}
+
+	var document RatesDocument
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("error decoding exchange rates file: %w", err)
+	}
+
+	table, err := newRateTable(document.Base, document.Rates)
+	if err != nil {
+		return nil, fmt.Errorf("error loading exchange rates file: %w", err)
+	}
+
+	return &FileProvider{table: table}, nil
+}
+
+// GetRate returns the rate to convert amounts from one currency to another
+func (p *FileProvider) GetRate(_ context.Context, from, to string) (money.Rate, error) {
+	return p.table.rate(from, to)
+}
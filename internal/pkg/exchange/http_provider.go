@@ -0,0 +1,105 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/internal/pkg/external"
+	"github.com/bookshop/api/pkg/logger"
+	"github.com/bookshop/api/pkg/money"
+	"golang.org/x/sync/singleflight"
+)
+
+// HTTPProvider fetches exchange rates from an HTTP API that serves the RatesDocument format
+// at /latest?from=<base>, such as Frankfurter. Rates are cached for the TTL and the last
+// fetched rates keep being served if a refresh fails. Concurrent refreshes share one request
+type HTTPProvider struct {
+	client *external.APIClient
+	base   string
+	ttl    time.Duration
+	logger logger.Logger
+
+	refresh singleflight.Group
+
+	mu        sync.Mutex // Guards the cached table only, it is not held while fetching
+	table     *rateTable
+	fetchedAt time.Time
+}
+
+// NewHTTPProvider creates a provider that fetches rates against the base currency
+func NewHTTPProvider(
+	client *external.APIClient,
+	base string,
+	ttl time.Duration,
+	logger logger.Logger,
+) services.ExchangeRateProvider {
+	return &HTTPProvider{
+		client: client,
+		base:   base,
+		ttl:    ttl,
+		logger: logger,
+	}
+}
+
+// GetRate returns the rate to convert amounts from one currency to another
+func (p *HTTPProvider) GetRate(ctx context.Context, from, to string) (money.Rate, error) {
+	table, err := p.rates(ctx)
+	if err != nil {
+		return money.Rate{}, err
+	}
+	return table.rate(from, to)
+}
+
+// rates returns the cached rate table, refreshing it when stale
+func (p *HTTPProvider) rates(ctx context.Context) (*rateTable, error) {
+	p.mu.Lock()
+	cached, fetchedAt := p.table, p.fetchedAt
+	p.mu.Unlock()
+
+	if cached != nil && time.Since(fetchedAt) < p.ttl {
+		return cached, nil
+	}
+
+	// The refresh is shared by the waiting requests, it is not canceled with the request that started it
+	fetched, err, _ := p.refresh.Do("rates", func() (interface{}, error) {
+		table, err := p.fetch(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		p.mu.Lock()
+		p.table = table
+		p.fetchedAt = time.Now()
+		p.mu.Unlock()
+
+		return table, nil
+	})
+	if err != nil {
+		if cached != nil {
+			p.logger.Error("Failed to refresh exchange rates, serving stale rates", "error", err)
+			return cached, nil
+		}
+		return nil, err
+	}
+
+	return fetched.(*rateTable), nil
+}
+
+// fetch requests the latest rates from the API
+func (p *HTTPProvider) fetch(ctx context.Context) (*rateTable, error) {
+	var document RatesDocument
+	if err := p.client.GetJSON(ctx, "/latest?from="+url.QueryEscape(p.base), &document); err != nil {
+		return nil, fmt.Errorf("error fetching exchange rates: %w", err)
+	}
+
+	table, err := newRateTable(document.Base, document.Rates)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing exchange rates: %w", err)
+	}
+
+	return table, nil
+}
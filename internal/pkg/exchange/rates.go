@@ -0,0 +1,65 @@
+package exchange
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/pkg/money"
+)
+
+// RatesDocument is the rates format shared by the file and HTTP providers:
+// every rate is the number of units of the currency worth one unit of the base currency.
+// Rates may be JSON numbers or decimal strings
+type RatesDocument struct {
+	Base  string                 `json:"base"`
+	Rates map[string]json.Number `json:"rates"`
+}
+
+// rateTable holds rates against a single base currency and derives cross rates from them
+type rateTable struct {
+	base  string
+	rates map[string]*big.Rat
+}
+
+// newRateTable parses rates against the base currency
+func newRateTable(base string, rates map[string]json.Number) (*rateTable, error) {
+	base = strings.ToUpper(base)
+	if !money.ValidCurrency(base) {
+		return nil, fmt.Errorf("invalid base currency %q", base)
+	}
+
+	table := &rateTable{
+		base:  base,
+		rates: map[string]*big.Rat{base: big.NewRat(1, 1)},
+	}
+
+	for currency, value := range rates {
+		currency = strings.ToUpper(currency)
+		rate, err := money.ParseRate(base, currency, value.String())
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate for %s: %w", currency, err)
+		}
+		table.rates[currency] = rate.Value
+	}
+
+	return table, nil
+}
+
+// rate returns the rate from one currency to another through the base currency
+func (t *rateTable) rate(from, to string) (money.Rate, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+
+	fromRate, ok := t.rates[from]
+	if !ok {
+		return money.Rate{}, fmt.Errorf("%w: no rate for %s", domainerrors.ErrExchangeRateUnavailable, from)
+	}
+	toRate, ok := t.rates[to]
+	if !ok {
+		return money.Rate{}, fmt.Errorf("%w: no rate for %s", domainerrors.ErrExchangeRateUnavailable, to)
+	}
+
+	return money.NewRate(from, to, new(big.Rat).Quo(toRate, fromRate)), nil
+}
@@ -13,11 +13,15 @@ import (
 	"github.com/bookshop/api/pkg/logger"
 )
 
+// retryBaseDelay is the delay before the first retry, doubled for every next one
+const retryBaseDelay = 200 * time.Millisecond
+
 // APIClient represents a client for working with external APIs
 type APIClient struct {
 	client      *http.Client
 	baseURL     string
 	rateLimiter *ratelimit.RateLimiter
+	retryCount  int // Number of retries for GET requests, zero if retries are disabled
 	logger      logger.Logger
 }
 
@@ -46,16 +50,54 @@ func NewAPIClient(config APIClientConfig, logger logger.Logger) *APIClient {
 		Timeout: config.Timeout,
 	}
 
+	retryCount := 0
+	if config.RetryEnabled {
+		retryCount = config.RetryCount
+	}
+
 	return &APIClient{
 		client:      client,
 		baseURL:     config.BaseURL,
 		rateLimiter: ratelimit.NewRateLimiter(config.RateLimit),
+		retryCount:  retryCount,
 		logger:      logger,
 	}
 }
 
-// Get performs a GET request to the API with rate limiting
+// Get performs a GET request to the API with rate limiting.
+// Network errors, rate limiting and 5xx responses are retried with exponential backoff
 func (c *APIClient) Get(ctx context.Context, path string) (*http.Response, error) {
+	delay := retryBaseDelay
+	for attempt := 0; ; attempt++ {
+		response, err := c.get(ctx, path)
+		if attempt >= c.retryCount || !shouldRetry(response, err) {
+			return response, err
+		}
+
+		if response != nil {
+			response.Body.Close()
+		}
+		c.logger.Info("Retrying external API request", "path", path, "attempt", attempt+1, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// shouldRetry checks if a GET request failed in a way that may succeed on retry
+func shouldRetry(response *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= http.StatusInternalServerError
+}
+
+// get performs a single GET request to the API with rate limiting
+func (c *APIClient) get(ctx context.Context, path string) (*http.Response, error) {
 	url := c.baseURL + path
 	var response *http.Response
 	var err error
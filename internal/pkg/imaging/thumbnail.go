@@ -0,0 +1,65 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+)
+
+// Thumbnail scales the image down to the width keeping its aspect ratio.
+// Every thumbnail pixel is the average of the source pixels it covers, which keeps
+// downscaled text and lines smooth. Images no wider than the width keep their size.
+// Transparent areas are flattened onto white so that thumbnails can be stored as JPEG
+func Thumbnail(src image.Image, width int) *image.RGBA {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+
+	dstW, dstH := srcW, srcH
+	if srcW > width && width > 0 {
+		dstW = width
+		dstH = max(srcH*width/srcW, 1)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+
+	// Source columns covered by each thumbnail column
+	cols := spans(srcW, dstW)
+	rows := spans(srcH, dstH)
+
+	for dy, row := range rows {
+		for dx, col := range cols {
+			var r, g, b, a, n uint64
+			for sy := row[0]; sy < row[1]; sy++ {
+				for sx := col[0]; sx < col[1]; sx++ {
+					pr, pg, pb, pa := src.At(bounds.Min.X+sx, bounds.Min.Y+sy).RGBA()
+					r += uint64(pr)
+					g += uint64(pg)
+					b += uint64(pb)
+					a += uint64(pa)
+					n++
+				}
+			}
+
+			// Colors are premultiplied, so white shows through by the missing alpha
+			white := 0xffff - a/n
+			dst.SetRGBA(dx, dy, color.RGBA{
+				R: uint8((r/n + white) >> 8),
+				G: uint8((g/n + white) >> 8),
+				B: uint8((b/n + white) >> 8),
+				A: 0xff,
+			})
+		}
+	}
+
+	return dst
+}
+
+// spans splits n source pixels into m consecutive spans of at least one pixel each
+func spans(n, m int) [][2]int {
+	result := make([][2]int, m)
+	for i := range result {
+		start := i * n / m
+		end := max((i+1)*n/m, start+1)
+		result[i] = [2]int{start, end}
+	}
+	return result
+}
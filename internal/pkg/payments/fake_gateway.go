@@ -0,0 +1,212 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/pkg/money"
+)
+
+// FakeProviderName is the provider name payments of the fake gateway are recorded with
+const FakeProviderName = "fake"
+
+// Outcomes of authorizations by the fake gateway
+const (
+	FakeOutcomeSuccess = "success"
+	FakeOutcomeDecline = "decline"
+	FakeOutcomeError   = "error"
+)
+
+// fakeTokenOutcomes maps the payment method tokens forcing an outcome of the fake gateway to the outcome,
+// other tokens get the configured outcome
+var fakeTokenOutcomes = map[string]string{
+	"tok_success": FakeOutcomeSuccess,
+	"tok_decline": FakeOutcomeDecline,
+	"tok_error":   FakeOutcomeError,
+}
+
+// FakeGateway is an in-memory payment gateway for development and offline testing.
+// Authorizations succeed, are declined or fail as configured, and every call takes the configured delay.
+// Payments are forgotten on restart
+type FakeGateway struct {
+	outcome string
+	delay   time.Duration
+	secret  []byte
+
+	mu       sync.Mutex
+	payments map[string]*fakePayment
+}
+
+// fakePayment is a payment as the fake gateway knows it
+type fakePayment struct {
+	amount money.Money
+	status string
+}
+
+// NewFakeGateway creates a fake gateway authorizing payments with the outcome after the delay.
+// Webhooks are verified with the secret, see SignWebhook
+func NewFakeGateway(outcome string, delay time.Duration, webhookSecret string) (services.PaymentGateway, error) {
+	switch outcome {
+	case FakeOutcomeSuccess, FakeOutcomeDecline, FakeOutcomeError:
+	default:
+		return nil, fmt.Errorf("unknown fake payment outcome %q", outcome)
+	}
+	if webhookSecret == "" {
+		return nil, fmt.Errorf("webhook secret is required")
+	}
+
+	return &FakeGateway{
+		outcome:  outcome,
+		delay:    delay,
+		secret:   []byte(webhookSecret),
+		payments: make(map[string]*fakePayment),
+	}, nil
+}
+
+// Name returns the name of the provider payments are recorded with
+func (g *FakeGateway) Name() string {
+	return FakeProviderName
+}
+
+// wait simulates the latency of a provider
+func (g *FakeGateway) wait(ctx context.Context) error {
+	if g.delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(g.delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %v", domainerrors.ErrPaymentGateway, ctx.Err())
+	}
+}
+
+// Authorize reserves the amount and returns the reference of the payment
+func (g *FakeGateway) Authorize(ctx context.Context, auth models.PaymentAuthorization) (string, error) {
+	if err := g.wait(ctx); err != nil {
+		return "", err
+	}
+
+	outcome, ok := fakeTokenOutcomes[auth.Token]
+	if !ok {
+		outcome = g.outcome
+	}
+
+	switch outcome {
+	case FakeOutcomeDecline:
+		return "", fmt.Errorf("%w: card declined", domainerrors.ErrPaymentDeclined)
+	case FakeOutcomeError:
+		return "", fmt.Errorf("%w: simulated provider failure", domainerrors.ErrPaymentGateway)
+	}
+
+	ref, err := newFakeRef()
+	if err != nil {
+		return "", err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.payments[ref] = &fakePayment{amount: auth.Amount, status: models.PaymentStatusAuthorized}
+
+	return ref, nil
+}
+
+// newFakeRef returns a random payment reference
+func newFakeRef() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("%w: %v", domainerrors.ErrPaymentGateway, err)
+	}
+	return "fake_" + hex.EncodeToString(b), nil
+}
+
+// Capture takes the authorized amount, at most the amount authorized
+func (g *FakeGateway) Capture(ctx context.Context, ref string, amount money.Money) error {
+	return g.transition(ctx, ref, amount, models.PaymentStatusAuthorized, models.PaymentStatusCaptured)
+}
+
+// Void releases an authorization that was not captured
+func (g *FakeGateway) Void(ctx context.Context, ref string) error {
+	return g.transition(ctx, ref, money.Money{}, models.PaymentStatusAuthorized, models.PaymentStatusVoided)
+}
+
+// Refund returns at most the captured amount
+func (g *FakeGateway) Refund(ctx context.Context, ref string, amount money.Money) error {
+	return g.transition(ctx, ref, amount, models.PaymentStatusCaptured, models.PaymentStatusRefunded)
+}
+
+// transition moves a payment from one status to another, checking that the amount does not exceed
+// the amount of the payment unless it is zero
+func (g *FakeGateway) transition(ctx context.Context, ref string, amount money.Money, from, to string) error {
+	if err := g.wait(ctx); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	payment, ok := g.payments[ref]
+	if !ok {
+		return fmt.Errorf("%w: unknown payment %q", domainerrors.ErrPaymentGateway, ref)
+	}
+	if payment.status != from {
+		return fmt.Errorf("%w: payment %q is %s", domainerrors.ErrPaymentGateway, ref, payment.status)
+	}
+	if !amount.IsZero() {
+		if cmp, err := amount.Cmp(payment.amount); err != nil || cmp > 0 {
+			return fmt.Errorf("%w: amount %s exceeds the payment amount %s", domainerrors.ErrPaymentGateway, amount, payment.amount)
+		}
+	}
+
+	payment.status = to
+	return nil
+}
+
+// VerifyWebhook checks that the payload is signed with the webhook secret and returns its event.
+// The signature is the hex-encoded HMAC-SHA256 of the payload
+func (g *FakeGateway) VerifyWebhook(payload []byte, signature string) (*models.PaymentWebhookEvent, error) {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, sign(g.secret, payload)) {
+		return nil, domainerrors.ErrInvalidWebhookSignature
+	}
+
+	var event models.PaymentWebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("%w: malformed webhook payload: %v", domainerrors.ErrInvalidData, err)
+	}
+	if event.ID == "" {
+		return nil, fmt.Errorf("%w: webhook payload has no event ID", domainerrors.ErrInvalidData)
+	}
+	if event.ProviderRef == "" {
+		return nil, fmt.Errorf("%w: webhook payload has no payment reference", domainerrors.ErrInvalidData)
+	}
+
+	return &event, nil
+}
+
+// SignWebhook returns the signature of a webhook payload the fake gateway accepts with the secret,
+// for sending webhooks when testing offline
+func SignWebhook(secret string, payload []byte) string {
+	return hex.EncodeToString(sign([]byte(secret), payload))
+}
+
+// sign returns the HMAC-SHA256 of the payload with the secret
+func sign(secret, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
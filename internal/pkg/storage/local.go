@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bookshop/api/internal/domain/services"
+)
+
+// LocalStorage stores objects as files in a directory that is served at a base URL.
+// The content type is not kept, it is derived from the file extension when files are served
+type LocalStorage struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalStorage creates the directory if needed and returns storage writing to it.
+// The base URL is where the directory is served, e.g. "/media" or "https://cdn.example.com"
+func NewLocalStorage(dir, baseURL string) (services.BlobStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating storage directory: %w", err)
+	}
+
+	return &LocalStorage{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+// Put writes the object to a temporary file and renames it into place,
+// so readers never see a partially written object
+func (s *LocalStorage) Put(_ context.Context, key string, data io.Reader, _ string) (string, error) {
+	filename, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return "", fmt.Errorf("error creating object directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filename), ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("error creating object file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	if _, err := io.Copy(tmp, data); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("error writing object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("error writing object: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return "", fmt.Errorf("error writing object: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), filename); err != nil {
+		return "", fmt.Errorf("error storing object: %w", err)
+	}
+
+	return s.baseURL + "/" + key, nil
+}
+
+// DeletePrefix deletes the objects under the prefix. Prefixes are expected to end at a
+// key segment, e.g. "covers/42/", partial segments are not matched
+func (s *LocalStorage) DeletePrefix(_ context.Context, prefix string) error {
+	dir, err := s.path(strings.TrimSuffix(prefix, "/"))
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("error deleting objects: %w", err)
+	}
+
+	return nil
+}
+
+// path returns the file of a key, keys cannot point outside the storage directory
+func (s *LocalStorage) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" || clean != "/"+key {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+
+	return filepath.Join(s.dir, filepath.FromSlash(clean)), nil
+}
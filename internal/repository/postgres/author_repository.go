@@ -0,0 +1,218 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AuthorRepository implements repositories.AuthorRepository interface.
+// Queries run in the transaction of the context if there is one
+type AuthorRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewAuthorRepository creates a new instance of AuthorRepository
+func NewAuthorRepository(db *pgxpool.Pool) repositories.AuthorRepository {
+	return &AuthorRepository{
+		db: db,
+	}
+}
+
+// querier returns the transaction of the context or the pool
+func (r *AuthorRepository) querier(ctx context.Context) pgxQuerier {
+	return querier(ctx, r.db)
+}
+
+// GetByID returns an author by ID
+func (r *AuthorRepository) GetByID(ctx context.Context, id int) (*models.Author, error) {
+	query := `
+		SELECT id, name, created_at, updated_at
+		FROM authors
+		WHERE id = $1
+	`
+
+	author := &models.Author{}
+	err := r.querier(ctx).QueryRow(ctx, query, id).Scan(
+		&author.ID,
+		&author.Name,
+		&author.CreatedAt,
+		&author.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repositories.ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting author: %w", err)
+	}
+
+	return author, nil
+}
+
+// List returns a page of authors ordered by name with their book counts and the total count
+func (r *AuthorRepository) List(ctx context.Context, filter models.AuthorFilter) ([]models.Author, int, error) {
+	var conditions string
+	var args []interface{}
+	argIndex := 1
+
+	if filter.Query != "" {
+		conditions = fmt.Sprintf(" WHERE a.name ILIKE '%%' || $%d || '%%'", argIndex)
+		args = append(args, escapeLike(filter.Query))
+		argIndex++
+	}
+
+	var total int
+	if err := r.querier(ctx).QueryRow(ctx, "SELECT COUNT(*) FROM authors a"+conditions, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("error counting authors: %w", err)
+	}
+
+	pageSize := filter.PageSize
+	if pageSize <= 0 {
+		pageSize = 10 // Default value
+	}
+
+	page := filter.Page
+	if page <= 0 {
+		page = 1
+	}
+
+	query := fmt.Sprintf(`
+		SELECT a.id, a.name, a.created_at, a.updated_at,
+			(SELECT COUNT(DISTINCT ba.book_id)
+			 FROM book_authors ba
+			 JOIN books b ON b.id = ba.book_id AND b.deleted_at IS NULL
+			 WHERE ba.author_id = a.id)
+		FROM authors a%s
+		ORDER BY lower(a.name), a.id
+		LIMIT $%d OFFSET $%d
+	`, conditions, argIndex, argIndex+1)
+	args = append(args, pageSize, (page-1)*pageSize)
+
+	rows, err := r.querier(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error getting authors list: %w", err)
+	}
+	defer rows.Close()
+
+	authors := make([]models.Author, 0, pageSize)
+	for rows.Next() {
+		var author models.Author
+		if err := rows.Scan(
+			&author.ID,
+			&author.Name,
+			&author.CreatedAt,
+			&author.UpdatedAt,
+			&author.BookCount,
+		); err != nil {
+			return nil, 0, fmt.Errorf("error scanning author row: %w", err)
+		}
+		authors = append(authors, author)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return authors, total, nil
+}
+
+// GetOrCreate returns the author with the name regardless of case, creating one if there is none.
+// An existing author keeps the spelling it was created with
+func (r *AuthorRepository) GetOrCreate(ctx context.Context, name string) (*models.Author, error) {
+	// The no-op update makes the existing row returned on conflict
+	query := `
+		INSERT INTO authors (name, created_at, updated_at)
+		VALUES ($1, $2, $2)
+		ON CONFLICT ((lower(name))) DO UPDATE SET name = authors.name
+		RETURNING id, name, created_at, updated_at
+	`
+
+	author := &models.Author{}
+	err := r.querier(ctx).QueryRow(ctx, query, name, time.Now()).Scan(
+		&author.ID,
+		&author.Name,
+		&author.CreatedAt,
+		&author.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting or creating author: %w", err)
+	}
+
+	return author, nil
+}
+
+// SetBookAuthors replaces the contributors of a book, in credit order
+func (r *AuthorRepository) SetBookAuthors(ctx context.Context, bookID int, authors []models.BookAuthor) error {
+	q := r.querier(ctx)
+
+	if _, err := q.Exec(ctx, "DELETE FROM book_authors WHERE book_id = $1", bookID); err != nil {
+		return fmt.Errorf("error removing book authors: %w", err)
+	}
+
+	query := `
+		INSERT INTO book_authors (book_id, author_id, role, position)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING
+	`
+
+	for i, author := range authors {
+		if _, err := q.Exec(ctx, query, bookID, author.AuthorID, author.Role, i); err != nil {
+			return fmt.Errorf("error adding book author: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetByBookIDs returns the contributors of the books in credit order, by book ID
+func (r *AuthorRepository) GetByBookIDs(ctx context.Context, bookIDs []int) (map[int][]models.BookAuthor, error) {
+	result := make(map[int][]models.BookAuthor, len(bookIDs))
+	if len(bookIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT ba.book_id, a.id, a.name, ba.role
+		FROM book_authors ba
+		JOIN authors a ON a.id = ba.author_id
+		WHERE ba.book_id = ANY($1)
+		ORDER BY ba.book_id, ba.position
+	`
+
+	rows, err := r.querier(ctx).Query(ctx, query, bookIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error getting book authors: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bookID int
+		var author models.BookAuthor
+		if err := rows.Scan(&bookID, &author.AuthorID, &author.Name, &author.Role); err != nil {
+			return nil, fmt.Errorf("error scanning book author row: %w", err)
+		}
+		result[bookID] = append(result[bookID], author)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike returns a string matched literally inside a LIKE pattern
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/pkg/money"
+	"github.com/jackc/pgx/v5"
+)
+
+// Facets counted by bookFacetsQuery
+const (
+	facetCategory = "category"
+	facetPrice    = "price"
+	facetDecade   = "decade"
+	facetStock    = "stock"
+)
+
+// priceFacetBounds are the bounds of the price ranges books are counted in,
+// in minor units of the base currency
+var priceFacetBounds = []int64{1000, 2500, 5000, 10000}
+
+// bookFacetsQuery returns the query counting the books selected by the FROM clause per facet value.
+// Every row is a facet, a value and a count, categories also come with their names
+func bookFacetsQuery(from string) string {
+	bounds := make([]string, len(priceFacetBounds))
+	for i, bound := range priceFacetBounds {
+		bounds[i] = money.New(bound, money.DefaultCurrency).Decimal()
+	}
+
+	return `
+		WITH matched AS (
+			SELECT b.category_id, c.name AS category_name, b.price, b.year_published, b.stock
+		` + from + `
+		)
+		SELECT '` + facetCategory + `', category_id, COALESCE(MAX(category_name), ''), COUNT(*)
+		FROM matched GROUP BY category_id
+		UNION ALL
+		SELECT '` + facetPrice + `', width_bucket(price, ARRAY[` + strings.Join(bounds, ", ") + `]::numeric[]), '', COUNT(*)
+		FROM matched GROUP BY 2
+		UNION ALL
+		SELECT '` + facetDecade + `', year_published / 10 * 10, '', COUNT(*)
+		FROM matched GROUP BY 2
+		UNION ALL
+		SELECT '` + facetStock + `', (stock > 0)::int, '', COUNT(*)
+		FROM matched GROUP BY 2
+	`
+}
+
+// scanBookFacets reads the result of bookFacetsQuery from the batch
+func scanBookFacets(results pgx.BatchResults) (*models.BookFacets, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count book facets: %w", err)
+	}
+	defer rows.Close()
+
+	facets := &models.BookFacets{
+		Categories:  make([]models.CategoryFacet, 0),
+		PriceRanges: make([]models.PriceRangeFacet, 0),
+		Decades:     make([]models.DecadeFacet, 0),
+	}
+
+	for rows.Next() {
+		var facet, name string
+		var value, count int
+		if err := rows.Scan(&facet, &value, &name, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan book facet row: %w", err)
+		}
+
+		switch facet {
+		case facetCategory:
+			facets.Categories = append(facets.Categories, models.CategoryFacet{ID: value, Name: name, Count: count})
+		case facetPrice:
+			facets.PriceRanges = append(facets.PriceRanges, priceRangeFacet(value, count))
+		case facetDecade:
+			facets.Decades = append(facets.Decades, models.DecadeFacet{Decade: value, Count: count})
+		case facetStock:
+			if value == 1 {
+				facets.Stock.InStock = count
+			} else {
+				facets.Stock.OutOfStock = count
+			}
+		default:
+			return nil, fmt.Errorf("unknown book facet %q", facet)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over facet rows: %w", err)
+	}
+
+	sort.Slice(facets.Categories, func(i, j int) bool {
+		return facets.Categories[i].Name < facets.Categories[j].Name
+	})
+	sort.Slice(facets.PriceRanges, func(i, j int) bool {
+		return facets.PriceRanges[i].Min.Amount < facets.PriceRanges[j].Min.Amount
+	})
+	sort.Slice(facets.Decades, func(i, j int) bool {
+		return facets.Decades[i].Decade < facets.Decades[j].Decade
+	})
+
+	return facets, nil
+}
+
+// priceRangeFacet returns the price range of a width_bucket number over priceFacetBounds.
+// Bucket 0 is below the first bound and the last bucket has no upper bound
+func priceRangeFacet(bucket, count int) models.PriceRangeFacet {
+	facet := models.PriceRangeFacet{
+		Min:   money.Zero(money.DefaultCurrency),
+		Count: count,
+	}
+	if bucket > 0 {
+		facet.Min = money.New(priceFacetBounds[bucket-1], money.DefaultCurrency)
+	}
+	if bucket < len(priceFacetBounds) {
+		upper := money.New(priceFacetBounds[bucket], money.DefaultCurrency)
+		facet.Max = &upper
+	}
+	return facet
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/bookshop/api/pkg/money"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// BookPriceRepository implements repositories.BookPriceRepository interface
+type BookPriceRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewBookPriceRepository creates a new book price repository instance
+func NewBookPriceRepository(db *pgxpool.Pool) repositories.BookPriceRepository {
+	return &BookPriceRepository{
+		db: db,
+	}
+}
+
+// GetOverrides returns the price overrides in the currency by book ID
+func (r *BookPriceRepository) GetOverrides(ctx context.Context, bookIDs []int, currency string) (map[int]money.Money, error) {
+	overrides := make(map[int]money.Money)
+	if len(bookIDs) == 0 {
+		return overrides, nil
+	}
+
+	query := `
+		SELECT book_id, price
+		FROM book_prices
+		WHERE book_id = ANY($1) AND currency = $2
+	`
+
+	rows, err := r.db.Query(ctx, query, bookIDs, currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get book price overrides: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bookID int
+		price := money.Zero(currency) // Scanning keeps the currency
+		if err := rows.Scan(&bookID, &price); err != nil {
+			return nil, fmt.Errorf("failed to scan book price override: %w", err)
+		}
+		overrides[bookID] = price
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate book price overrides: %w", err)
+	}
+
+	return overrides, nil
+}
+
+// SetOverride creates or replaces the book price in the currency of price
+func (r *BookPriceRepository) SetOverride(ctx context.Context, bookID int, price money.Money) error {
+	query := `
+		INSERT INTO book_prices (book_id, currency, price, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+		ON CONFLICT (book_id, currency) DO UPDATE
+		SET price = EXCLUDED.price, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.db.Exec(ctx, query, bookID, price.Currency, price, time.Now())
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == ForeignKeyViolationCode {
+			return repositories.ErrNotFound
+		}
+		return fmt.Errorf("failed to set book price override: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteOverride removes the book price in the currency
+func (r *BookPriceRepository) DeleteOverride(ctx context.Context, bookID int, currency string) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM book_prices WHERE book_id = $1 AND currency = $2`, bookID, currency)
+	if err != nil {
+		return fmt.Errorf("failed to delete book price override: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repositories.ErrNotFound
+	}
+
+	return nil
+}
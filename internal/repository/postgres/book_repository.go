@@ -4,13 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
+	"unicode"
 
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
 	"github.com/bookshop/api/internal/domain/models"
 	"github.com/bookshop/api/internal/domain/repositories"
 	repomodels "github.com/bookshop/api/internal/repository/postgres/models"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -31,8 +35,9 @@ func (r *BookRepository) Create(ctx context.Context, book *models.Book) error {
 	query := `
 		INSERT INTO books (
 			title, author, year_published, price, stock, 
-			category_id, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			category_id, isbn, publisher, language, format,
+			page_count, description, cover_url, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, $9, $10, $11, $12, $13, $14, $15)
 		RETURNING id
 	`
 
@@ -50,11 +55,22 @@ func (r *BookRepository) Create(ctx context.Context, book *models.Book) error {
 		repoBook.Price,
 		repoBook.Stock,
 		repoBook.CategoryID,
+		repoBook.ISBN,
+		repoBook.Publisher,
+		repoBook.Language,
+		repoBook.Format,
+		repoBook.PageCount,
+		repoBook.Description,
+		repoBook.CoverURL,
 		repoBook.CreatedAt,
 		repoBook.UpdatedAt,
 	).Scan(&repoBook.ID)
 
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == UniqueViolationCode {
+			return domainerrors.ErrDuplicateISBN
+		}
 		return fmt.Errorf("failed to create book: %w", err)
 	}
 
@@ -64,32 +80,119 @@ func (r *BookRepository) Create(ctx context.Context, book *models.Book) error {
 	return nil
 }
 
-// GetByID returns a book by ID
+// UpsertByISBN creates the book or updates the book with its ISBN, in the transaction of the context.
+// A cover URL only replaces the current one if the book has no uploaded cover
+func (r *BookRepository) UpsertByISBN(ctx context.Context, book *models.Book) (bool, error) {
+	query := `
+		INSERT INTO books (
+			title, author, year_published, price, stock,
+			category_id, isbn, publisher, language, format,
+			page_count, description, cover_url, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $14)
+		ON CONFLICT (isbn) WHERE deleted_at IS NULL DO UPDATE SET
+			title = EXCLUDED.title,
+			author = EXCLUDED.author,
+			year_published = EXCLUDED.year_published,
+			price = EXCLUDED.price,
+			stock = EXCLUDED.stock,
+			category_id = EXCLUDED.category_id,
+			publisher = EXCLUDED.publisher,
+			language = EXCLUDED.language,
+			format = EXCLUDED.format,
+			page_count = EXCLUDED.page_count,
+			description = EXCLUDED.description,
+			cover_url = CASE
+				WHEN EXCLUDED.cover_url <> '' AND books.cover_key = '' THEN EXCLUDED.cover_url
+				ELSE books.cover_url
+			END,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at, (xmax = 0) AS inserted
+	`
+
+	now := time.Now()
+	book.UpdatedAt = now
+
+	repoBook := repomodels.FromDomain(book)
+
+	var created bool
+	err := querier(ctx, r.db).QueryRow(ctx, query,
+		repoBook.Title,
+		repoBook.Author,
+		repoBook.YearPublished,
+		repoBook.Price,
+		repoBook.Stock,
+		repoBook.CategoryID,
+		repoBook.ISBN,
+		repoBook.Publisher,
+		repoBook.Language,
+		repoBook.Format,
+		repoBook.PageCount,
+		repoBook.Description,
+		repoBook.CoverURL,
+		now,
+	).Scan(&book.ID, &book.CreatedAt, &created)
+	if err != nil {
+		return false, fmt.Errorf("failed to upsert book: %w", err)
+	}
+
+	return created, nil
+}
+
+// bookColumns is the column list read by bookScanDest, books are aliased b and their categories c
+const bookColumns = `
+	b.id, b.title, b.author, b.year_published, b.price,
+	b.stock, b.category_id, COALESCE(b.isbn, ''), b.publisher, b.language,
+	b.format, b.page_count, b.description, b.cover_url, b.cover_key, b.cover_thumbnails,
+	b.created_at, b.updated_at, b.deleted_at,
+	COALESCE(c.name, '') as category_name`
+
+// bookScanDest returns the scan destinations of bookColumns
+func bookScanDest(book *repomodels.Book, categoryName *string) []interface{} {
+	return []interface{}{
+		&book.ID,
+		&book.Title,
+		&book.Author,
+		&book.YearPublished,
+		&book.Price,
+		&book.Stock,
+		&book.CategoryID,
+		&book.ISBN,
+		&book.Publisher,
+		&book.Language,
+		&book.Format,
+		&book.PageCount,
+		&book.Description,
+		&book.CoverURL,
+		&book.CoverKey,
+		&book.CoverThumbnails,
+		&book.CreatedAt,
+		&book.UpdatedAt,
+		&book.DeletedAt,
+		categoryName,
+	}
+}
+
+// GetByID returns a book by ID, deleted books are not found
 func (r *BookRepository) GetByID(ctx context.Context, id int) (*models.Book, error) {
+	return r.getBook(ctx, "b.id = $1 AND b.deleted_at IS NULL", id)
+}
+
+// GetByISBN returns a book by its ISBN-13, deleted books are not found
+func (r *BookRepository) GetByISBN(ctx context.Context, isbn string) (*models.Book, error) {
+	return r.getBook(ctx, "b.isbn = $1 AND b.deleted_at IS NULL", isbn)
+}
+
+// getBook returns the book matching the condition
+func (r *BookRepository) getBook(ctx context.Context, condition string, arg interface{}) (*models.Book, error) {
 	query := `
-		SELECT 
-			b.id, b.title, b.author, b.year_published, b.price, 
-			b.stock, b.category_id, b.created_at, b.updated_at,
-			c.name as category_name
+		SELECT ` + bookColumns + `
 		FROM books b
 		LEFT JOIN categories c ON b.category_id = c.id
-		WHERE b.id = $1
-	`
+		WHERE ` + condition
 
 	repoBook := &repomodels.Book{}
 	var categoryName string
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&repoBook.ID,
-		&repoBook.Title,
-		&repoBook.Author,
-		&repoBook.YearPublished,
-		&repoBook.Price,
-		&repoBook.Stock,
-		&repoBook.CategoryID,
-		&repoBook.CreatedAt,
-		&repoBook.UpdatedAt,
-		&categoryName,
-	)
+	err := r.db.QueryRow(ctx, query, arg).Scan(bookScanDest(repoBook, &categoryName)...)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -112,8 +215,11 @@ func (r *BookRepository) GetByID(ctx context.Context, id int) (*models.Book, err
 	return book, nil
 }
 
-// List returns a list of books with filtering and pagination
-func (r *BookRepository) List(ctx context.Context, filter models.BookFilter) ([]models.Book, int, error) {
+// List returns a page of books with filtering.
+// Pages are read by offset, or after or before a cursor when one is set in the filter.
+// The total count is always returned in offset mode and on request in cursor mode,
+// facets are only returned on request
+func (r *BookRepository) List(ctx context.Context, filter models.BookFilter) ([]models.Book, *models.BookFacets, models.PageInfo, error) {
 	// Base query to get books
 	baseQuery := `
 		FROM books b
@@ -122,11 +228,270 @@ func (r *BookRepository) List(ctx context.Context, filter models.BookFilter) ([]
 	`
 
 	// Add filtering conditions
-	var conditions string
+	conditions, args, searchQuery := bookConditions(filter)
+	argIndex := len(args) + 1
+
+	var info models.PageInfo
+
+	// Count, facets and the page are read in one round trip
+	batch := &pgx.Batch{}
+
+	// Query to count total number of books
+	counted := filter.IncludeTotal || (filter.After == nil && filter.Before == nil)
+	if counted {
+		batch.Queue("SELECT COUNT(*) "+baseQuery+conditions, args...)
+	}
+
+	// Facets are counted over all matching books, not just the page
+	if filter.Facets {
+		batch.Queue(bookFacetsQuery(baseQuery+conditions), args...)
+	}
+
+	order, err := bookKeyset(filter.Sort, searchQuery)
+	if err != nil {
+		return nil, nil, info, err
+	}
+
+	// Add pagination
+	pageSize := filter.PageSize
+	if pageSize <= 0 {
+		pageSize = 10 // Default value
+	}
+
+	page := filter.Page
+	if page <= 0 {
+		page = 1
+	}
+
+	// Rows after or before a cursor, otherwise by offset
+	cursor, backwards := filter.After, false
+	if filter.Before != nil {
+		cursor, backwards = filter.Before, true
+	}
+
+	var pagination string
+	if cursor != nil {
+		condition, cursorArgs, err := order.condition(cursor, backwards, argIndex)
+		if err != nil {
+			return nil, nil, info, err
+		}
+		conditions += " AND " + condition
+		args = append(args, cursorArgs...)
+		argIndex += len(cursorArgs)
+
+		// One more row tells if there is another page
+		pagination = fmt.Sprintf("%s LIMIT %d", order.orderBy(backwards), pageSize+1)
+	} else {
+		offset := (page - 1) * pageSize
+		pagination = fmt.Sprintf("%s LIMIT %d OFFSET %d", order.orderBy(false), pageSize+1, offset)
+	}
+
+	// Highlight matched words of search results, the fields are escaped so that only the marks are HTML
+	var highlights string
+	if searchQuery != "" {
+		highlights = fmt.Sprintf(`,
+			ts_headline('simple', %[3]s, %[1]s, '%[2]s') as title_highlight,
+			ts_headline('simple', %[4]s, %[1]s, '%[2]s') as author_highlight
+		`, searchQuery, headlineOptions, escapeHTMLExpr("b.title"), escapeHTMLExpr("b.author"))
+	}
+
+	// Query to get books with filtering and pagination
+	query := `
+		SELECT ` + bookColumns + `, ` + order.valuesColumn() + ` as cursor_values
+	` + highlights + baseQuery + conditions + pagination
+
+	batch.Queue(query, args...)
+
+	results := r.db.SendBatch(ctx, batch)
+	defer results.Close()
+
+	if counted {
+		var total int
+		if err := results.QueryRow().Scan(&total); err != nil {
+			return nil, nil, info, fmt.Errorf("failed to count books: %w", err)
+		}
+		info.TotalCount = &total
+	}
+
+	var facets *models.BookFacets
+	if filter.Facets {
+		facets, err = scanBookFacets(results)
+		if err != nil {
+			return nil, nil, info, err
+		}
+	}
+
+	rows, err := results.Query()
+	if err != nil {
+		return nil, nil, info, fmt.Errorf("failed to query books list: %w", err)
+	}
+	defer rows.Close()
+
+	// bookRow is a selected book with the data that is not stored on the book
+	type bookRow struct {
+		book         repomodels.Book
+		categoryName string
+		cursorValues []string
+		highlight    *models.Highlight
+	}
+
+	bookRows := make([]bookRow, 0, pageSize+1)
+	for rows.Next() {
+		var row bookRow
+		dest := append(bookScanDest(&row.book, &row.categoryName), &row.cursorValues)
+
+		if searchQuery != "" {
+			row.highlight = &models.Highlight{}
+			dest = append(dest, &row.highlight.Title, &row.highlight.Author)
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, nil, info, fmt.Errorf("failed to scan book row: %w", err)
+		}
+
+		bookRows = append(bookRows, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, info, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	bookRows, more := trimPage(bookRows, pageSize, backwards)
+
+	// Convert repository models to domain models with category information
+	domainBooks := make([]models.Book, len(bookRows))
+	for i, row := range bookRows {
+		domainBooks[i] = *row.book.ToDomain()
+		if row.categoryName != "" {
+			domainBooks[i].Category = &models.Category{
+				ID:   domainBooks[i].CategoryID,
+				Name: row.categoryName,
+			}
+		}
+		domainBooks[i].Highlight = row.highlight
+	}
+
+	if len(bookRows) > 0 {
+		first, last := bookRows[0], bookRows[len(bookRows)-1]
+		setPageCursors(&info,
+			order.cursor(first.cursorValues, first.book.ID),
+			order.cursor(last.cursorValues, last.book.ID),
+			more, backwards, cursor == nil && page == 1)
+	}
+
+	return domainBooks, facets, info, nil
+}
+
+// streamFetchSize is the number of books fetched from a stream cursor at a time
+const streamFetchSize = 500
+
+// Stream calls fn with every book matching the filter, in the order of its sort keys.
+// Pages, cursors and facets of the filter are ignored. Books are read in batches
+// through a server-side cursor in a read-only transaction, so the catalog is never
+// held in memory and fn sees one snapshot of it. Stops at the first error of fn
+func (r *BookRepository) Stream(ctx context.Context, filter models.BookFilter, fn func(book *models.Book) error) error {
+	conditions, args, searchQuery := bookConditions(filter)
+
+	order, err := bookKeyset(filter.Sort, searchQuery)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Nothing is written, the cursor is closed with the transaction
+	defer tx.Rollback(ctx)
+
+	query := `
+		DECLARE book_stream NO SCROLL CURSOR FOR
+		SELECT ` + bookColumns + `
+		FROM books b
+		LEFT JOIN categories c ON b.category_id = c.id
+		WHERE 1=1
+	` + conditions + order.orderBy(false)
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to declare book cursor: %w", err)
+	}
+
+	fetch := fmt.Sprintf("FETCH FORWARD %d FROM book_stream", streamFetchSize)
+	books := make([]models.Book, 0, streamFetchSize)
+	for {
+		books, err = fetchBooks(ctx, tx, fetch, books[:0])
+		if err != nil {
+			return err
+		}
+		if len(books) == 0 {
+			return nil
+		}
+
+		// The batch is read before fn is called so that a slow consumer does not hold the rows open
+		for i := range books {
+			if err := fn(&books[i]); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// fetchBooks appends the books of a FETCH from a cursor over bookColumns to books
+func fetchBooks(ctx context.Context, tx pgx.Tx, fetch string, books []models.Book) ([]models.Book, error) {
+	rows, err := tx.Query(ctx, fetch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch books: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var repoBook repomodels.Book
+		var categoryName string
+		if err := rows.Scan(bookScanDest(&repoBook, &categoryName)...); err != nil {
+			return nil, fmt.Errorf("failed to scan book row: %w", err)
+		}
+
+		book := repoBook.ToDomain()
+		if categoryName != "" {
+			book.Category = &models.Category{
+				ID:   book.CategoryID,
+				Name: categoryName,
+			}
+		}
+		books = append(books, *book)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return books, nil
+}
+
+// bookConditions returns the conditions selecting the books of the filter with their arguments,
+// numbered from $1. The tsquery expression of a search is also returned, it is empty without one
+func bookConditions(filter models.BookFilter) (string, []interface{}, string) {
+	// Deleted books are only listed on request
+	conditions := " AND b.deleted_at IS NULL"
+	if filter.Deleted {
+		conditions = " AND b.deleted_at IS NOT NULL"
+	}
+
 	var args []interface{}
 	argIndex := 1
 
-	if len(filter.CategoryIDs) > 0 {
+	if len(filter.CategoryIDs) > 0 && filter.IncludeSubcategories {
+		conditions += fmt.Sprintf(` AND b.category_id IN (
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM categories WHERE id = ANY($%d)
+				UNION
+				SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+			)
+			SELECT id FROM subtree
+		)`, argIndex)
+		args = append(args, filter.CategoryIDs)
+		argIndex++
+	} else if len(filter.CategoryIDs) > 0 {
 		conditions += " AND b.category_id IN ("
 		for i, catID := range filter.CategoryIDs {
 			if i > 0 {
@@ -139,13 +504,19 @@ func (r *BookRepository) List(ctx context.Context, filter models.BookFilter) ([]
 		conditions += ")"
 	}
 
-	if filter.MinPrice != nil && *filter.MinPrice > 0 {
+	if len(filter.AuthorIDs) > 0 {
+		conditions += fmt.Sprintf(" AND EXISTS (SELECT 1 FROM book_authors ba WHERE ba.book_id = b.id AND ba.author_id = ANY($%d))", argIndex)
+		args = append(args, filter.AuthorIDs)
+		argIndex++
+	}
+
+	if filter.MinPrice != nil && filter.MinPrice.IsPositive() {
 		conditions += fmt.Sprintf(" AND b.price >= $%d", argIndex)
 		args = append(args, *filter.MinPrice)
 		argIndex++
 	}
 
-	if filter.MaxPrice != nil && *filter.MaxPrice > 0 {
+	if filter.MaxPrice != nil && filter.MaxPrice.IsPositive() {
 		conditions += fmt.Sprintf(" AND b.price <= $%d", argIndex)
 		args = append(args, *filter.MaxPrice)
 		argIndex++
@@ -155,93 +526,116 @@ func (r *BookRepository) List(ctx context.Context, filter models.BookFilter) ([]
 		conditions += " AND b.stock > 0"
 	}
 
-	// Query to count total number of books
-	countQuery := "SELECT COUNT(*) " + baseQuery + conditions
-	var total int
-	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count books: %w", err)
+	if filter.ISBN != "" {
+		conditions += fmt.Sprintf(" AND b.isbn = $%d", argIndex)
+		args = append(args, filter.ISBN)
+		argIndex++
 	}
 
-	// Add pagination
-	pageSize := filter.PageSize
-	if pageSize <= 0 {
-		pageSize = 10 // Default value
+	if filter.Publisher != "" {
+		conditions += fmt.Sprintf(" AND lower(b.publisher) = lower($%d)", argIndex)
+		args = append(args, filter.Publisher)
+		argIndex++
 	}
 
-	page := filter.Page
-	if page <= 0 {
-		page = 1
+	if len(filter.Languages) > 0 {
+		conditions += fmt.Sprintf(" AND b.language = ANY($%d)", argIndex)
+		args = append(args, filter.Languages)
+		argIndex++
 	}
 
-	offset := (page - 1) * pageSize
-	if offset < 0 {
-		offset = 0
+	if len(filter.Formats) > 0 {
+		conditions += fmt.Sprintf(" AND b.format = ANY($%d)", argIndex)
+		args = append(args, filter.Formats)
+		argIndex++
 	}
 
-	pagination := fmt.Sprintf(" ORDER BY b.id DESC LIMIT %d OFFSET %d", pageSize, offset)
+	if filter.MinPages != nil {
+		conditions += fmt.Sprintf(" AND b.page_count >= $%d", argIndex)
+		args = append(args, *filter.MinPages)
+		argIndex++
+	}
 
-	// Query to get books with filtering and pagination
-	query := `
-		SELECT 
-			b.id, b.title, b.author, b.year_published, b.price, 
-			b.stock, b.category_id, b.created_at, b.updated_at,
-			c.name as category_name
-	` + baseQuery + conditions + pagination
+	if filter.MaxPages != nil {
+		conditions += fmt.Sprintf(" AND b.page_count <= $%d", argIndex)
+		args = append(args, *filter.MaxPages)
+		argIndex++
+	}
 
-	rows, err := r.db.Query(ctx, query, args...)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to query books list: %w", err)
+	// Full-text search, the query is also used for ranking and highlighting
+	var searchQuery string
+	if tsquery := buildSearchQuery(filter.Query); tsquery != "" {
+		searchQuery = fmt.Sprintf("to_tsquery('simple', $%d)", argIndex)
+		conditions += " AND b.search_vector @@ " + searchQuery
+		args = append(args, tsquery)
 	}
-	defer rows.Close()
 
-	repoBooks := make([]repomodels.Book, 0)
-	categoryNames := make(map[int]string)
+	return conditions, args, searchQuery
+}
 
-	for rows.Next() {
-		var book repomodels.Book
-		var categoryName string
-		err := rows.Scan(
-			&book.ID,
-			&book.Title,
-			&book.Author,
-			&book.YearPublished,
-			&book.Price,
-			&book.Stock,
-			&book.CategoryID,
-			&book.CreatedAt,
-			&book.UpdatedAt,
-			&categoryName,
-		)
-		if err != nil {
-			return nil, 0, fmt.Errorf("failed to scan book row: %w", err)
-		}
+// bookSortKeys maps the fields books can be sorted by to their sort keys.
+// Sort fields are never put into queries directly, only the columns of this whitelist
+var bookSortKeys = map[string]sortKey{
+	"title":          {name: "title", expr: "b.title", typ: "text"},
+	"author":         {name: "author", expr: "b.author", typ: "text"},
+	"price":          {name: "price", expr: "b.price", typ: "numeric"},
+	"year_published": {name: "year_published", expr: "b.year_published", typ: "int"},
+	"created_at":     {name: "created_at", expr: "b.created_at", typ: "timestamptz"},
+}
 
-		repoBooks = append(repoBooks, book)
-		if categoryName != "" {
-			categoryNames[book.ID] = categoryName
+// bookKeyset returns the order of a book list.
+// Without sort keys search results are ranked by relevance and newest books come first otherwise.
+// The book ID always breaks ties so that pages neither skip nor repeat books
+func bookKeyset(sort []models.BookSort, searchQuery string) (keyset, error) {
+	order := make(keyset, 0, len(sort)+2)
+	for _, field := range sort {
+		key, ok := bookSortKeys[field.Field]
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot sort by %q", domainerrors.ErrInvalidData, field.Field)
 		}
+		key.desc = field.Descending
+		order = append(order, key)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("error iterating over rows: %w", err)
+	if len(order) == 0 && searchQuery != "" {
+		order = append(order, sortKey{
+			name: "relevance",
+			expr: fmt.Sprintf("ts_rank_cd(b.search_vector, %s)", searchQuery),
+			typ:  "real",
+			desc: true,
+		})
 	}
 
-	// Convert repository models to domain models
-	domainBooks := repomodels.BookSliceToDomain(repoBooks)
+	return append(order, sortKey{name: "id", expr: "b.id", typ: "int", desc: true}), nil
+}
 
-	// Add category information
-	for i := range domainBooks {
-		bookID := domainBooks[i].ID
-		if categoryName, ok := categoryNames[bookID]; ok {
-			domainBooks[i].Category = &models.Category{
-				ID:   domainBooks[i].CategoryID,
-				Name: categoryName,
-			}
-		}
-	}
+// headlineOptions configures ts_headline to mark every matched word in the whole field
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, HighlightAll=true"
 
-	return domainBooks, total, nil
+// escapeHTMLExpr returns an SQL expression escaping the HTML special characters of a text expression.
+// Escaped characters are parsed as entities, so they are never highlighted
+func escapeHTMLExpr(expr string) string {
+	return fmt.Sprintf(
+		`replace(replace(replace(replace(replace(%s, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`,
+		expr,
+	)
+}
+
+// buildSearchQuery converts a user search query to a tsquery matching books
+// that contain all of its words. The last word is matched as a prefix so that
+// results follow the user while typing. Only letters and digits are kept,
+// which also keeps tsquery operators out of user input.
+// Returns an empty string if the query has no words
+func buildSearchQuery(query string) string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return ""
+	}
+
+	words[len(words)-1] += ":*"
+	return strings.Join(words, " & ")
 }
 
 // Update updates book data
@@ -255,8 +649,17 @@ func (r *BookRepository) Update(ctx context.Context, book *models.Book) error {
 			price = $4, 
 			stock = $5, 
 			category_id = $6, 
-			updated_at = $7
-		WHERE id = $8
+			isbn = NULLIF($7, ''),
+			publisher = $8,
+			language = $9,
+			format = $10,
+			page_count = $11,
+			description = $12,
+			cover_url = $13,
+			cover_key = $14,
+			cover_thumbnails = $15,
+			updated_at = $16
+		WHERE id = $17 AND deleted_at IS NULL
 	`
 
 	// Convert domain model to repository model
@@ -269,22 +672,75 @@ func (r *BookRepository) Update(ctx context.Context, book *models.Book) error {
 		repoBook.Price,
 		repoBook.Stock,
 		repoBook.CategoryID,
+		repoBook.ISBN,
+		repoBook.Publisher,
+		repoBook.Language,
+		repoBook.Format,
+		repoBook.PageCount,
+		repoBook.Description,
+		repoBook.CoverURL,
+		repoBook.CoverKey,
+		coverThumbnails(repoBook.CoverThumbnails),
 		repoBook.UpdatedAt,
 		repoBook.ID,
 	)
 
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == UniqueViolationCode {
+			return domainerrors.ErrDuplicateISBN
+		}
 		return fmt.Errorf("failed to update book: %w", err)
 	}
 
 	return nil
 }
 
-// Delete deletes a book by ID
+// UpdateCover updates the cover URL, thumbnails and storage key of a book
+func (r *BookRepository) UpdateCover(ctx context.Context, book *models.Book) error {
+	query := `
+		UPDATE books
+		SET cover_url = $1, cover_key = $2, cover_thumbnails = $3, updated_at = $4
+		WHERE id = $5 AND deleted_at IS NULL
+	`
+
+	book.UpdatedAt = time.Now()
+
+	result, err := r.db.Exec(ctx, query,
+		book.CoverURL,
+		book.CoverKey,
+		coverThumbnails(book.CoverThumbnails),
+		book.UpdatedAt,
+		book.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update book cover: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repositories.ErrNotFound
+	}
+
+	return nil
+}
+
+// coverThumbnails returns the thumbnails to store, books without thumbnails store an empty object
+func coverThumbnails(thumbnails map[string]string) map[string]string {
+	if thumbnails == nil {
+		return map[string]string{}
+	}
+	return thumbnails
+}
+
+// Delete soft deletes a book by ID. The row is kept for the orders it is in
 func (r *BookRepository) Delete(ctx context.Context, id int) error {
-	query := "DELETE FROM books WHERE id = $1"
+	query := `
+		UPDATE books
+		SET deleted_at = $1, updated_at = $1
+		WHERE id = $2 AND deleted_at IS NULL
+	`
 
-	result, err := r.db.Exec(ctx, query, id)
+	result, err := querier(ctx, r.db).Exec(ctx, query, time.Now(), id)
 	if err != nil {
 		return fmt.Errorf("failed to delete book: %w", err)
 	}
@@ -297,6 +753,31 @@ func (r *BookRepository) Delete(ctx context.Context, id int) error {
 	return nil
 }
 
+// Restore restores a deleted book by ID.
+// Returns ErrDuplicateISBN if another book got the ISBN in the meantime
+func (r *BookRepository) Restore(ctx context.Context, id int) error {
+	query := `
+		UPDATE books
+		SET deleted_at = NULL, updated_at = $1
+		WHERE id = $2 AND deleted_at IS NOT NULL
+	`
+
+	result, err := querier(ctx, r.db).Exec(ctx, query, time.Now(), id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == UniqueViolationCode {
+			return domainerrors.ErrDuplicateISBN
+		}
+		return fmt.Errorf("failed to restore book: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repositories.ErrNotFound
+	}
+
+	return nil
+}
+
 // UpdateStock updates the quantity of books in stock
 func (r *BookRepository) UpdateStock(ctx context.Context, id int, quantity int) error {
 	query := `
@@ -314,22 +795,28 @@ func (r *BookRepository) UpdateStock(ctx context.Context, id int, quantity int)
 	return nil
 }
 
-// DecrementStock decreases the quantity of books in stock
+// DecrementStock decreases the quantity of books in stock, within the transaction from context if there is one.
 // Returns an error if there are not enough books in stock
 func (r *BookRepository) DecrementStock(ctx context.Context, id int, quantity int) error {
+	return decrementStock(ctx, querier(ctx, r.db), id, quantity)
+}
+
+// decrementStock decreases the stock of the book using the given querier,
+// so it can be run both on the pool and inside a transaction
+func decrementStock(ctx context.Context, q pgxQuerier, id int, quantity int) error {
 	query := `
 		UPDATE books
 		SET stock = stock - $1, updated_at = $2
-		WHERE id = $3 AND stock >= $4
+		WHERE id = $3 AND stock >= $4 AND deleted_at IS NULL
 		RETURNING stock
 	`
 
 	now := time.Now()
 	var newStock int
-	err := r.db.QueryRow(ctx, query, quantity, now, id, quantity).Scan(&newStock)
+	err := q.QueryRow(ctx, query, quantity, now, id, quantity).Scan(&newStock)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return fmt.Errorf("not enough books in stock for book with ID %d", id)
+			return fmt.Errorf("%w: not enough books in stock for book with ID %d", domainerrors.ErrOutOfStock, id)
 		}
 		return fmt.Errorf("failed to decrement book stock: %w", err)
 	}
@@ -337,8 +824,19 @@ func (r *BookRepository) DecrementStock(ctx context.Context, id int, quantity in
 	return nil
 }
 
-// GetBooksByIDs returns books by a list of IDs
+// GetBooksByIDs returns books by a list of IDs, deleted books are left out
 func (r *BookRepository) GetBooksByIDs(ctx context.Context, ids []int) ([]models.Book, error) {
+	return r.getBooksByIDs(ctx, ids, " AND b.deleted_at IS NULL")
+}
+
+// GetBooksByIDsWithDeleted returns books by a list of IDs including deleted books,
+// for records such as order history that outlive the catalog
+func (r *BookRepository) GetBooksByIDsWithDeleted(ctx context.Context, ids []int) ([]models.Book, error) {
+	return r.getBooksByIDs(ctx, ids, "")
+}
+
+// getBooksByIDs returns books by a list of IDs that also match the condition
+func (r *BookRepository) getBooksByIDs(ctx context.Context, ids []int, condition string) ([]models.Book, error) {
 	if len(ids) == 0 {
 		return []models.Book{}, nil
 	}
@@ -353,14 +851,11 @@ func (r *BookRepository) GetBooksByIDs(ctx context.Context, ids []int) ([]models
 
 	// Build the query
 	query := fmt.Sprintf(`
-		SELECT 
-			b.id, b.title, b.author, b.year_published, b.price, 
-			b.stock, b.category_id, b.created_at, b.updated_at,
-			c.name as category_name
+		SELECT `+bookColumns+`
 		FROM books b
 		LEFT JOIN categories c ON b.category_id = c.id
-		WHERE b.id IN (%s)
-	`, strings.Join(placeholders, ","))
+		WHERE b.id IN (%s)%s
+	`, strings.Join(placeholders, ","), condition)
 
 	// Execute the query
 	rows, err := r.db.Query(ctx, query, args...)
@@ -376,19 +871,7 @@ func (r *BookRepository) GetBooksByIDs(ctx context.Context, ids []int) ([]models
 	for rows.Next() {
 		var book repomodels.Book
 		var categoryName string
-		err := rows.Scan(
-			&book.ID,
-			&book.Title,
-			&book.Author,
-			&book.YearPublished,
-			&book.Price,
-			&book.Stock,
-			&book.CategoryID,
-			&book.CreatedAt,
-			&book.UpdatedAt,
-			&categoryName,
-		)
-		if err != nil {
+		if err := rows.Scan(bookScanDest(&book, &categoryName)...); err != nil {
 			return nil, fmt.Errorf("failed to scan book row: %w", err)
 		}
 
@@ -419,30 +902,20 @@ func (r *BookRepository) GetBooksByIDs(ctx context.Context, ids []int) ([]models
 	return domainBooks, nil
 }
 
-// ReserveBooks reserves books (decreases available quantity)
+// ReserveBooks reserves books (decreases available quantity by the requested amounts),
+// within the transaction from context if there is one.
 // Returns an error if any of the items is unavailable
-func (r *BookRepository) ReserveBooks(ctx context.Context, bookIDs []int) error {
-	tx, err := r.db.Begin(ctx)
+func (r *BookRepository) ReserveBooks(ctx context.Context, quantities map[int]int) error {
+	tx, err := beginTx(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to start transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
-	for _, bookID := range bookIDs {
-		query := `
-			UPDATE books
-			SET stock = stock - 1, updated_at = $1
-			WHERE id = $2 AND stock > 0
-			RETURNING stock
-		`
-
-		var remainingStock int
-		err := tx.QueryRow(ctx, query, time.Now(), bookID).Scan(&remainingStock)
-		if err != nil {
-			if errors.Is(err, pgx.ErrNoRows) {
-				return fmt.Errorf("not enough books on stock for ID: %d", bookID)
-			}
-			return fmt.Errorf("failed to reserve book: %w", err)
+	// Lock rows in a stable order to avoid deadlocks between concurrent reservations
+	for _, bookID := range sortedBookIDs(quantities) {
+		if err := decrementStock(ctx, tx, bookID, quantities[bookID]); err != nil {
+			return err
 		}
 	}
 
@@ -453,22 +926,22 @@ func (r *BookRepository) ReserveBooks(ctx context.Context, bookIDs []int) error
 	return nil
 }
 
-// ReleaseBooks returns reserved books back to stock
-func (r *BookRepository) ReleaseBooks(ctx context.Context, bookIDs []int) error {
-	tx, err := r.db.Begin(ctx)
+// ReleaseBooks returns reserved books back to stock, within the transaction from context if there is one
+func (r *BookRepository) ReleaseBooks(ctx context.Context, quantities map[int]int) error {
+	tx, err := beginTx(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to start transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
-	for _, bookID := range bookIDs {
+	for _, bookID := range sortedBookIDs(quantities) {
 		query := `
 			UPDATE books
-			SET stock = stock + 1, updated_at = $1
-			WHERE id = $2
+			SET stock = stock + $1, updated_at = $2
+			WHERE id = $3
 		`
 
-		_, err := tx.Exec(ctx, query, time.Now(), bookID)
+		_, err := tx.Exec(ctx, query, quantities[bookID], time.Now(), bookID)
 		if err != nil {
 			return fmt.Errorf("failed to return book to stock: %w", err)
 		}
@@ -480,3 +953,13 @@ func (r *BookRepository) ReleaseBooks(ctx context.Context, bookIDs []int) error
 
 	return nil
 }
+
+// sortedBookIDs returns the book IDs of the quantities map in ascending order
+func sortedBookIDs(quantities map[int]int) []int {
+	ids := make([]int, 0, len(quantities))
+	for id := range quantities {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
@@ -25,16 +25,16 @@ func NewCartRepository(db *pgxpool.Pool) repositories.CartRepository {
 	}
 }
 
-// AddItem adds an item to the user's cart
-func (r *CartRepository) AddItem(ctx context.Context, userID int, bookID int, expiresAt time.Time) error {
+// SaveItem adds an item to the user's cart or replaces the item for the same book
+func (r *CartRepository) SaveItem(ctx context.Context, userID int, item models.CartItem) error {
 	query := `
-		INSERT INTO cart_items (user_id, book_id, added_at, expires_at)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO cart_items (user_id, book_id, quantity, added_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
 		ON CONFLICT (user_id, book_id) 
-		DO UPDATE SET expires_at = $4
+		DO UPDATE SET quantity = $3, expires_at = $5
 	`
 
-	_, err := r.db.Exec(ctx, query, userID, bookID, time.Now(), expiresAt)
+	_, err := r.db.Exec(ctx, query, userID, item.BookID, item.Quantity, item.AddedAt, item.ExpiresAt)
 	if err != nil {
 		return fmt.Errorf("failed to add item to cart: %w", err)
 	}
@@ -42,10 +42,35 @@ func (r *CartRepository) AddItem(ctx context.Context, userID int, bookID int, ex
 	return nil
 }
 
+// GetItem returns the cart item for the book
+func (r *CartRepository) GetItem(ctx context.Context, userID int, bookID int) (*models.CartItem, error) {
+	query := `
+		SELECT ci.book_id, ci.quantity, ci.added_at, ci.expires_at
+		FROM cart_items ci
+		WHERE ci.user_id = $1 AND ci.book_id = $2 AND ci.expires_at > $3
+	`
+
+	item := &models.CartItem{}
+	err := r.db.QueryRow(ctx, query, userID, bookID, time.Now()).Scan(
+		&item.BookID,
+		&item.Quantity,
+		&item.AddedAt,
+		&item.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repositories.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get cart item: %w", err)
+	}
+
+	return item, nil
+}
+
 // GetCart returns the user's cart
 func (r *CartRepository) GetCart(ctx context.Context, userID int) (*models.Cart, error) {
 	query := `
-		SELECT ci.book_id, ci.added_at, ci.expires_at
+		SELECT ci.book_id, ci.quantity, ci.added_at, ci.expires_at
 		FROM cart_items ci
 		WHERE ci.user_id = $1 AND ci.expires_at > $2
 		ORDER BY ci.added_at DESC
@@ -66,6 +91,7 @@ func (r *CartRepository) GetCart(ctx context.Context, userID int) (*models.Cart,
 		item := models.CartItem{}
 		err := rows.Scan(
 			&item.BookID,
+			&item.Quantity,
 			&item.AddedAt,
 			&item.ExpiresAt,
 		)
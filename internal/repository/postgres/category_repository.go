@@ -7,9 +7,11 @@ import (
 	"strings"
 	"time"
 
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
 	"github.com/bookshop/api/internal/domain/models"
 	"github.com/bookshop/api/internal/domain/repositories"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -28,8 +30,8 @@ func NewCategoryRepository(db *pgxpool.Pool) repositories.CategoryRepository {
 // Create creates a new category
 func (r *CategoryRepository) Create(ctx context.Context, category *models.Category) error {
 	query := `
-		INSERT INTO categories (name, created_at, updated_at)
-		VALUES ($1, $2, $3)
+		INSERT INTO categories (name, parent_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4)
 		RETURNING id
 	`
 
@@ -39,36 +41,42 @@ func (r *CategoryRepository) Create(ctx context.Context, category *models.Catego
 
 	err := r.db.QueryRow(ctx, query,
 		category.Name,
+		category.ParentID,
 		category.CreatedAt,
 		category.UpdatedAt,
 	).Scan(&category.ID)
 
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == UniqueViolationCode {
+			return domainerrors.ErrCategoryExists
+		}
 		return fmt.Errorf("error creating category: %w", err)
 	}
 
 	return nil
 }
 
-// GetByID returns a category by ID
+// GetByID returns a category by ID, deleted categories are not found
 func (r *CategoryRepository) GetByID(ctx context.Context, id int) (*models.Category, error) {
 	query := `
-		SELECT id, name, created_at, updated_at
+		SELECT id, name, parent_id, created_at, updated_at
 		FROM categories
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	category := &models.Category{}
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&category.ID,
 		&category.Name,
+		&category.ParentID,
 		&category.CreatedAt,
 		&category.UpdatedAt,
 	)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("category not found")
+			return nil, repositories.ErrNotFound
 		}
 		return nil, fmt.Errorf("error getting category: %w", err)
 	}
@@ -76,25 +84,26 @@ func (r *CategoryRepository) GetByID(ctx context.Context, id int) (*models.Categ
 	return category, nil
 }
 
-// GetByName returns a category by name
+// GetByName returns a category by name, deleted categories are not found
 func (r *CategoryRepository) GetByName(ctx context.Context, name string) (*models.Category, error) {
 	query := `
-		SELECT id, name, created_at, updated_at
+		SELECT id, name, parent_id, created_at, updated_at
 		FROM categories
-		WHERE name = $1
+		WHERE name = $1 AND deleted_at IS NULL
 	`
 
 	category := &models.Category{}
 	err := r.db.QueryRow(ctx, query, name).Scan(
 		&category.ID,
 		&category.Name,
+		&category.ParentID,
 		&category.CreatedAt,
 		&category.UpdatedAt,
 	)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("category not found")
+			return nil, repositories.ErrNotFound
 		}
 		return nil, fmt.Errorf("error getting category: %w", err)
 	}
@@ -102,11 +111,12 @@ func (r *CategoryRepository) GetByName(ctx context.Context, name string) (*model
 	return category, nil
 }
 
-// List returns a list of all categories
+// List returns a list of all categories that are not deleted
 func (r *CategoryRepository) List(ctx context.Context) ([]models.Category, error) {
 	query := `
-		SELECT id, name, created_at, updated_at
+		SELECT id, name, parent_id, created_at, updated_at
 		FROM categories
+		WHERE deleted_at IS NULL
 		ORDER BY name
 	`
 
@@ -122,6 +132,7 @@ func (r *CategoryRepository) List(ctx context.Context) ([]models.Category, error
 		err := rows.Scan(
 			&category.ID,
 			&category.Name,
+			&category.ParentID,
 			&category.CreatedAt,
 			&category.UpdatedAt,
 		)
@@ -138,12 +149,136 @@ func (r *CategoryRepository) List(ctx context.Context) ([]models.Category, error
 	return categories, nil
 }
 
+// GetPath returns the ancestors of a category from the root down to the category itself
+func (r *CategoryRepository) GetPath(ctx context.Context, id int) ([]models.Category, error) {
+	query := `
+		WITH RECURSIVE path AS (
+			SELECT id, name, parent_id, created_at, updated_at, 0 AS depth
+			FROM categories
+			WHERE id = $1 AND deleted_at IS NULL
+			UNION ALL
+			SELECT c.id, c.name, c.parent_id, c.created_at, c.updated_at, p.depth + 1
+			FROM categories c
+			JOIN path p ON c.id = p.parent_id
+		)
+		SELECT id, name, parent_id, created_at, updated_at
+		FROM path
+		ORDER BY depth DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting category path: %w", err)
+	}
+	defer rows.Close()
+
+	path := make([]models.Category, 0)
+	for rows.Next() {
+		category := models.Category{}
+		err := rows.Scan(
+			&category.ID,
+			&category.Name,
+			&category.ParentID,
+			&category.CreatedAt,
+			&category.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning category data: %w", err)
+		}
+		path = append(path, category)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating through results: %w", err)
+	}
+
+	if len(path) == 0 {
+		return nil, repositories.ErrNotFound
+	}
+
+	return path, nil
+}
+
+// Move sets the parent of a category, nil moves it to the root.
+// Moves are serialized by an advisory lock so that concurrent moves
+// cannot create a cycle that neither of them sees on its own
+func (r *CategoryRepository) Move(ctx context.Context, id int, parentID *int) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", categoryTreeLockKey); err != nil {
+		return fmt.Errorf("error locking category tree: %w", err)
+	}
+
+	if parentID != nil {
+		// The new parent must exist, deletions wait for the tree lock so it cannot be deleted before commit
+		var exists bool
+		err := tx.QueryRow(ctx, `
+			SELECT true FROM categories WHERE id = $1 AND deleted_at IS NULL FOR SHARE
+		`, *parentID).Scan(&exists)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return repositories.ErrNotFound
+			}
+			return fmt.Errorf("error checking parent category: %w", err)
+		}
+
+		// The category must not be the new parent or one of its ancestors
+		query := `
+			WITH RECURSIVE ancestors AS (
+				SELECT id, parent_id FROM categories WHERE id = $1
+				UNION ALL
+				SELECT c.id, c.parent_id
+				FROM categories c
+				JOIN ancestors a ON c.id = a.parent_id
+			)
+			SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = $2)
+		`
+
+		var cycle bool
+		if err := tx.QueryRow(ctx, query, *parentID, id).Scan(&cycle); err != nil {
+			return fmt.Errorf("error checking category ancestors: %w", err)
+		}
+		if cycle {
+			return domainerrors.ErrCategoryCycle
+		}
+	}
+
+	result, err := tx.Exec(ctx, `
+		UPDATE categories
+		SET parent_id = $1, updated_at = $2
+		WHERE id = $3 AND deleted_at IS NULL
+	`, parentID, time.Now(), id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == ForeignKeyViolationCode {
+			return repositories.ErrNotFound
+		}
+		return fmt.Errorf("error moving category: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return repositories.ErrNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// categoryTreeLockKey is the advisory lock key serializing category moves, deletions and restores
+const categoryTreeLockKey = 0x63617465 // "cate"
+
 // Update updates category data
 func (r *CategoryRepository) Update(ctx context.Context, category *models.Category) error {
 	query := `
 		UPDATE categories
 		SET name = $1, updated_at = $2
-		WHERE id = $3
+		WHERE id = $3 AND deleted_at IS NULL
 	`
 
 	category.UpdatedAt = time.Now()
@@ -155,27 +290,154 @@ func (r *CategoryRepository) Update(ctx context.Context, category *models.Catego
 	)
 
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == UniqueViolationCode {
+			return domainerrors.ErrCategoryExists
+		}
 		return fmt.Errorf("error updating category: %w", err)
 	}
 
 	return nil
 }
 
-// Delete deletes a category by ID
+// Delete soft deletes a category by ID. Categories with subcategories or books are kept.
+// The category row is locked, so books being added to it concurrently are waited for
 func (r *CategoryRepository) Delete(ctx context.Context, id int) error {
-	query := `
-		DELETE FROM categories
-		WHERE id = $1
-	`
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", categoryTreeLockKey); err != nil {
+		return fmt.Errorf("error locking category tree: %w", err)
+	}
 
-	_, err := r.db.Exec(ctx, query, id)
+	var hasChildren, hasBooks bool
+	err = tx.QueryRow(ctx, `
+		SELECT
+			EXISTS (SELECT 1 FROM categories WHERE parent_id = c.id AND deleted_at IS NULL),
+			EXISTS (SELECT 1 FROM books WHERE category_id = c.id AND deleted_at IS NULL)
+		FROM categories c
+		WHERE c.id = $1 AND c.deleted_at IS NULL
+		FOR UPDATE
+	`, id).Scan(&hasChildren, &hasBooks)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repositories.ErrNotFound
+		}
+		return fmt.Errorf("error checking category contents: %w", err)
+	}
+	if hasChildren {
+		return domainerrors.ErrCategoryHasChildren
+	}
+	if hasBooks {
+		return domainerrors.ErrCategoryHasBooks
+	}
+
+	now := time.Now()
+	if _, err := tx.Exec(ctx, `
+		UPDATE categories
+		SET deleted_at = $1, updated_at = $1
+		WHERE id = $2
+	`, now, id); err != nil {
 		return fmt.Errorf("error deleting category: %w", err)
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
+// Restore restores a deleted category by ID. The parent category must not be deleted
+func (r *CategoryRepository) Restore(ctx context.Context, id int) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", categoryTreeLockKey); err != nil {
+		return fmt.Errorf("error locking category tree: %w", err)
+	}
+
+	var parentDeleted bool
+	err = tx.QueryRow(ctx, `
+		SELECT COALESCE(p.deleted_at IS NOT NULL, false)
+		FROM categories c
+		LEFT JOIN categories p ON p.id = c.parent_id
+		WHERE c.id = $1 AND c.deleted_at IS NOT NULL
+	`, id).Scan(&parentDeleted)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repositories.ErrNotFound
+		}
+		return fmt.Errorf("error checking category parent: %w", err)
+	}
+	if parentDeleted {
+		return domainerrors.ErrCategoryParentDeleted
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE categories
+		SET deleted_at = NULL, updated_at = $1
+		WHERE id = $2
+	`, time.Now(), id); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == UniqueViolationCode {
+			return domainerrors.ErrCategoryExists
+		}
+		return fmt.Errorf("error restoring category: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListDeleted returns a list of deleted categories, the most recently deleted first
+func (r *CategoryRepository) ListDeleted(ctx context.Context) ([]models.Category, error) {
+	query := `
+		SELECT id, name, parent_id, created_at, updated_at, deleted_at
+		FROM categories
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id DESC
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error getting deleted categories list: %w", err)
+	}
+	defer rows.Close()
+
+	categories := make([]models.Category, 0)
+	for rows.Next() {
+		category := models.Category{}
+		err := rows.Scan(
+			&category.ID,
+			&category.Name,
+			&category.ParentID,
+			&category.CreatedAt,
+			&category.UpdatedAt,
+			&category.DeletedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning category data: %w", err)
+		}
+		categories = append(categories, category)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating through results: %w", err)
+	}
+
+	return categories, nil
+}
+
 // GetCategoriesByIDs returns categories by a list of IDs
 func (r *CategoryRepository) GetCategoriesByIDs(ctx context.Context, ids []int) ([]models.Category, error) {
 	if len(ids) == 0 {
@@ -191,7 +453,7 @@ func (r *CategoryRepository) GetCategoriesByIDs(ctx context.Context, ids []int)
 	}
 
 	query := fmt.Sprintf(`
-		SELECT id, name, created_at, updated_at
+		SELECT id, name, parent_id, created_at, updated_at
 		FROM categories
 		WHERE id IN (%s)
 		ORDER BY name
@@ -209,6 +471,7 @@ func (r *CategoryRepository) GetCategoriesByIDs(ctx context.Context, ids []int)
 		err := rows.Scan(
 			&category.ID,
 			&category.Name,
+			&category.ParentID,
 			&category.CreatedAt,
 			&category.UpdatedAt,
 		)
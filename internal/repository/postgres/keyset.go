@@ -0,0 +1,189 @@
+package postgres
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/models"
+)
+
+// sortKey is a key of a keyset-paginated ORDER BY
+type sortKey struct {
+	name string // Field name in cursor sort signatures
+	expr string // SQL expression, built from whitelisted columns only
+	typ  string // SQL type cursor values are cast back to
+	desc bool
+}
+
+// keyset is the order of a keyset-paginated query.
+// The last key must be the row ID so that the order is total
+type keyset []sortKey
+
+// signature identifies the order, cursors are only valid for the order they were created for
+func (k keyset) signature() string {
+	parts := make([]string, len(k))
+	for i, key := range k {
+		direction := "asc"
+		if key.desc {
+			direction = "desc"
+		}
+		parts[i] = key.name + ":" + direction
+	}
+	return strings.Join(parts, ",")
+}
+
+// orderBy returns the ORDER BY clause, backwards reverses every key to read rows before a cursor
+func (k keyset) orderBy(backwards bool) string {
+	parts := make([]string, len(k))
+	for i, key := range k {
+		parts[i] = key.expr
+		if key.desc != backwards {
+			parts[i] += " DESC"
+		}
+	}
+	return " ORDER BY " + strings.Join(parts, ", ")
+}
+
+// valuesColumn returns the select expression of the sort key values of a row, without the ID
+func (k keyset) valuesColumn() string {
+	parts := make([]string, len(k)-1)
+	for i, key := range k[:len(k)-1] {
+		parts[i] = "(" + key.expr + ")::text"
+	}
+	return "ARRAY[" + strings.Join(parts, ", ") + "]::text[]"
+}
+
+// cursor returns the cursor of a row from its sort key values and ID
+func (k keyset) cursor(values []string, id int) *models.Cursor {
+	return &models.Cursor{Sort: k.signature(), Values: values, ID: id}
+}
+
+// condition returns the condition selecting the rows after the cursor in the order,
+// or before it when reading backwards. Placeholders are numbered from argIndex
+func (k keyset) condition(cursor *models.Cursor, backwards bool, argIndex int) (string, []interface{}, error) {
+	if cursor.Sort != k.signature() || len(cursor.Values) != len(k)-1 {
+		return "", nil, fmt.Errorf("%w: cursor does not match the sort order", domainerrors.ErrInvalidCursor)
+	}
+
+	args := make([]interface{}, 0, len(k))
+	placeholders := make([]string, len(k))
+	for i, key := range k {
+		if i < len(cursor.Values) {
+			// Cursors come from clients, a value the cast would fail on is a bad cursor, not a query error
+			if !validCursorValue(key.typ, cursor.Values[i]) {
+				return "", nil, fmt.Errorf("%w: invalid %s value", domainerrors.ErrInvalidCursor, key.name)
+			}
+			args = append(args, cursor.Values[i])
+		} else {
+			args = append(args, cursor.ID)
+		}
+		placeholders[i] = fmt.Sprintf("$%d::%s", argIndex+i, key.typ)
+	}
+
+	// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ... with the comparison flipped for descending keys
+	disjuncts := make([]string, len(k))
+	for i, key := range k {
+		terms := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			terms = append(terms, k[j].expr+" = "+placeholders[j])
+		}
+
+		op := ">"
+		if key.desc != backwards {
+			op = "<"
+		}
+		terms = append(terms, key.expr+" "+op+" "+placeholders[i])
+
+		disjuncts[i] = "(" + strings.Join(terms, " AND ") + ")"
+	}
+
+	return "(" + strings.Join(disjuncts, " OR ") + ")", args, nil
+}
+
+// numericPattern matches the text of finite numeric values, realPattern the text of finite real values
+var (
+	numericPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+	realPattern    = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?(e[-+][0-9]+)?$`)
+)
+
+// timestampLayouts are the layouts of timestamptz values as text, with the offsets of every time zone
+var timestampLayouts = []string{
+	"2006-01-02 15:04:05.999999-07",
+	"2006-01-02 15:04:05.999999-07:00",
+	"2006-01-02 15:04:05.999999-07:00:00",
+}
+
+// validCursorValue checks that a cursor value is the text of a value of the SQL type
+func validCursorValue(typ, value string) bool {
+	switch typ {
+	case "int":
+		_, err := strconv.ParseInt(value, 10, 32)
+		return err == nil
+	case "real":
+		if !realPattern.MatchString(value) {
+			return false
+		}
+		_, err := strconv.ParseFloat(value, 32)
+		return err == nil
+	case "numeric":
+		return numericPattern.MatchString(value)
+	case "timestamptz":
+		for _, layout := range timestampLayouts {
+			if _, err := time.Parse(layout, value); err == nil {
+				return true
+			}
+		}
+		return false
+	case "text":
+		return utf8.ValidString(value) && !strings.ContainsRune(value, 0)
+	default:
+		return false
+	}
+}
+
+// trimPage drops the extra row fetched to find out if there are more rows
+// and restores the order of rows read backwards.
+// Returns whether there are more rows in the reading direction
+func trimPage[T any](rows []T, limit int, backwards bool) ([]T, bool) {
+	more := len(rows) > limit
+	if more {
+		rows = rows[:limit]
+	}
+
+	if backwards {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	return rows, more
+}
+
+// setPageCursors sets the next and previous page cursors from the cursors of the first and last rows.
+// more tells if there are rows past the page in the reading direction and
+// fromStart if the page was read from the start of the list
+func setPageCursors(info *models.PageInfo, first, last *models.Cursor, more, backwards, fromStart bool) {
+	if first == nil {
+		return
+	}
+
+	if backwards {
+		info.NextCursor = last
+		if more {
+			info.PrevCursor = first
+		}
+		return
+	}
+
+	if more {
+		info.NextCursor = last
+	}
+	if !fromStart {
+		info.PrevCursor = first
+	}
+}
@@ -4,48 +4,79 @@ import (
 	"time"
 
 	domainmodels "github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/pkg/money"
 )
 
 // Book represents a book model for repository operations
 type Book struct {
-	ID            int       `db:"id"`
-	Title         string    `db:"title"`
-	Author        string    `db:"author"`
-	YearPublished int       `db:"year_published"`
-	Price         float64   `db:"price"`
-	Stock         int       `db:"stock"`
-	CategoryID    int       `db:"category_id"`
-	CreatedAt     time.Time `db:"created_at"`
-	UpdatedAt     time.Time `db:"updated_at"`
+	ID              int               `db:"id"`
+	Title           string            `db:"title"`
+	Author          string            `db:"author"`
+	YearPublished   int               `db:"year_published"`
+	Price           money.Money       `db:"price"`
+	Stock           int               `db:"stock"`
+	CategoryID      int               `db:"category_id"`
+	ISBN            string            `db:"isbn"`
+	Publisher       string            `db:"publisher"`
+	Language        string            `db:"language"`
+	Format          string            `db:"format"`
+	PageCount       int               `db:"page_count"`
+	Description     string            `db:"description"`
+	CoverURL        string            `db:"cover_url"`
+	CoverKey        string            `db:"cover_key"`
+	CoverThumbnails map[string]string `db:"cover_thumbnails"`
+	CreatedAt       time.Time         `db:"created_at"`
+	UpdatedAt       time.Time         `db:"updated_at"`
+	DeletedAt       *time.Time        `db:"deleted_at"`
 }
 
 // ToDomain converts repository model to domain model
 func (b *Book) ToDomain() *domainmodels.Book {
 	return &domainmodels.Book{
-		ID:            b.ID,
-		Title:         b.Title,
-		Author:        b.Author,
-		YearPublished: b.YearPublished,
-		Price:         b.Price,
-		Stock:         b.Stock,
-		CategoryID:    b.CategoryID,
-		CreatedAt:     b.CreatedAt,
-		UpdatedAt:     b.UpdatedAt,
+		ID:              b.ID,
+		Title:           b.Title,
+		Author:          b.Author,
+		YearPublished:   b.YearPublished,
+		Price:           b.Price,
+		Stock:           b.Stock,
+		CategoryID:      b.CategoryID,
+		ISBN:            b.ISBN,
+		Publisher:       b.Publisher,
+		Language:        b.Language,
+		Format:          b.Format,
+		PageCount:       b.PageCount,
+		Description:     b.Description,
+		CoverURL:        b.CoverURL,
+		CoverKey:        b.CoverKey,
+		CoverThumbnails: b.CoverThumbnails,
+		CreatedAt:       b.CreatedAt,
+		UpdatedAt:       b.UpdatedAt,
+		DeletedAt:       b.DeletedAt,
 	}
 }
 
 // FromDomain converts domain model to repository model
 func FromDomain(book *domainmodels.Book) *Book {
 	return &Book{
-		ID:            book.ID,
-		Title:         book.Title,
-		Author:        book.Author,
-		YearPublished: book.YearPublished,
-		Price:         book.Price,
-		Stock:         book.Stock,
-		CategoryID:    book.CategoryID,
-		CreatedAt:     book.CreatedAt,
-		UpdatedAt:     book.UpdatedAt,
+		ID:              book.ID,
+		Title:           book.Title,
+		Author:          book.Author,
+		YearPublished:   book.YearPublished,
+		Price:           book.Price,
+		Stock:           book.Stock,
+		CategoryID:      book.CategoryID,
+		ISBN:            book.ISBN,
+		Publisher:       book.Publisher,
+		Language:        book.Language,
+		Format:          book.Format,
+		PageCount:       book.PageCount,
+		Description:     book.Description,
+		CoverURL:        book.CoverURL,
+		CoverKey:        book.CoverKey,
+		CoverThumbnails: book.CoverThumbnails,
+		CreatedAt:       book.CreatedAt,
+		UpdatedAt:       book.UpdatedAt,
+		DeletedAt:       book.DeletedAt,
 	}
 }
 
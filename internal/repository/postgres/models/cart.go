@@ -9,6 +9,7 @@ import (
 // CartItem represents a cart item for repository operations
 type CartItem struct {
 	BookID    int       `db:"book_id"`
+	Quantity  int       `db:"quantity"`
 	AddedAt   time.Time `db:"added_at"`
 	ExpiresAt time.Time `db:"expires_at"`
 }
@@ -23,6 +24,7 @@ type Cart struct {
 func (ci *CartItem) ToDomain() domainmodels.CartItem {
 	return domainmodels.CartItem{
 		BookID:    ci.BookID,
+		Quantity:  ci.Quantity,
 		AddedAt:   ci.AddedAt,
 		ExpiresAt: ci.ExpiresAt,
 	}
@@ -32,6 +34,7 @@ func (ci *CartItem) ToDomain() domainmodels.CartItem {
 func CartItemFromDomain(item domainmodels.CartItem) CartItem {
 	return CartItem{
 		BookID:    item.BookID,
+		Quantity:  item.Quantity,
 		AddedAt:   item.AddedAt,
 		ExpiresAt: item.ExpiresAt,
 	}
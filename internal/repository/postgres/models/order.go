@@ -4,23 +4,29 @@ import (
 	"time"
 
 	domainmodels "github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/pkg/money"
 )
 
 // OrderItem represents an order item for repository operations
 type OrderItem struct {
-	ID        int       `db:"id"`
-	OrderID   int       `db:"order_id"`
-	BookID    int       `db:"book_id"`
-	Price     float64   `db:"price"`
-	CreatedAt time.Time `db:"created_at"`
+	ID           int         `db:"id"`
+	OrderID      int         `db:"order_id"`
+	BookID       int         `db:"book_id"`
+	Title        string      `db:"title"`
+	Author       string      `db:"author"`
+	ISBN         string      `db:"isbn"`
+	CategoryName string      `db:"category_name"`
+	Price        money.Money `db:"price"`
+	Quantity     int         `db:"quantity"`
+	CreatedAt    time.Time   `db:"created_at"`
 }
 
 // Order represents an order model for repository operations
 type Order struct {
-	ID         int     `db:"id"`
-	UserID     int     `db:"user_id"`
-	Status     string  `db:"status"`
-	TotalPrice float64 `db:"total_price"`
+	ID         int         `db:"id"`
+	UserID     int         `db:"user_id"`
+	Status     string      `db:"status"`
+	TotalPrice money.Money `db:"total_price"`
 	Items      []OrderItem
 	CreatedAt  time.Time `db:"created_at"`
 	UpdatedAt  time.Time `db:"updated_at"`
@@ -29,22 +35,32 @@ type Order struct {
 // OrderItemToDomain converts repository order item to domain model
 func (oi *OrderItem) ToDomain() domainmodels.OrderItem {
 	return domainmodels.OrderItem{
-		ID:        oi.ID,
-		OrderID:   oi.OrderID,
-		BookID:    oi.BookID,
-		Price:     oi.Price,
-		CreatedAt: oi.CreatedAt,
+		ID:           oi.ID,
+		OrderID:      oi.OrderID,
+		BookID:       oi.BookID,
+		Title:        oi.Title,
+		Author:       oi.Author,
+		ISBN:         oi.ISBN,
+		CategoryName: oi.CategoryName,
+		Price:        oi.Price,
+		Quantity:     oi.Quantity,
+		CreatedAt:    oi.CreatedAt,
 	}
 }
 
 // OrderItemFromDomain converts domain order item to repository model
 func OrderItemFromDomain(item domainmodels.OrderItem) OrderItem {
 	return OrderItem{
-		ID:        item.ID,
-		OrderID:   item.OrderID,
-		BookID:    item.BookID,
-		Price:     item.Price,
-		CreatedAt: item.CreatedAt,
+		ID:           item.ID,
+		OrderID:      item.OrderID,
+		BookID:       item.BookID,
+		Title:        item.Title,
+		Author:       item.Author,
+		ISBN:         item.ISBN,
+		CategoryName: item.CategoryName,
+		Price:        item.Price,
+		Quantity:     item.Quantity,
+		CreatedAt:    item.CreatedAt,
 	}
 }
 
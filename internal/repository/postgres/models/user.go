@@ -8,10 +8,10 @@ import (
 
 // User represents a user model for repository operations
 type User struct {
-	ID           int       `db:"id"`
-	Email        string    `db:"email"`
-	PasswordHash string    `db:"password_hash"`
-	IsAdmin      bool      `db:"is_admin"`
+	ID           int    `db:"id"`
+	Email        string `db:"email"`
+	PasswordHash string `db:"password_hash"`
+	Roles        []string
 	CreatedAt    time.Time `db:"created_at"`
 	UpdatedAt    time.Time `db:"updated_at"`
 }
@@ -22,7 +22,7 @@ func (u *User) ToDomain() *domainmodels.User {
 		ID:           u.ID,
 		Email:        u.Email,
 		PasswordHash: u.PasswordHash,
-		IsAdmin:      u.IsAdmin,
+		Roles:        u.Roles,
 		CreatedAt:    u.CreatedAt,
 		UpdatedAt:    u.UpdatedAt,
 	}
@@ -34,7 +34,7 @@ func UserFromDomain(user *domainmodels.User) *User {
 		ID:           user.ID,
 		Email:        user.Email,
 		PasswordHash: user.PasswordHash,
-		IsAdmin:      user.IsAdmin,
+		Roles:        user.Roles,
 		CreatedAt:    user.CreatedAt,
 		UpdatedAt:    user.UpdatedAt,
 	}
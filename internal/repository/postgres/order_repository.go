@@ -8,10 +8,15 @@ import (
 
 	"github.com/bookshop/api/internal/domain/models"
 	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/bookshop/api/pkg/money"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// orderColumns is the column list read by scanOrder
+const orderColumns = `id, user_id, status, currency, exchange_rate::text, total_price, created_at, updated_at`
+
 // OrderRepository implements repositories.OrderRepository interface
 type OrderRepository struct {
 	db *pgxpool.Pool
@@ -24,20 +29,34 @@ func NewOrderRepository(db *pgxpool.Pool) repositories.OrderRepository {
 	}
 }
 
-// Create creates a new order
+// Create creates a new order, within the transaction from context if there is one
 func (r *OrderRepository) Create(ctx context.Context, order *models.Order) error {
-	tx, err := r.db.Begin(ctx)
+	tx, err := beginTx(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("error starting transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
 	query := `
-		INSERT INTO orders (user_id, status, total_price, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO orders (user_id, status, currency, exchange_rate, total_price, created_at, updated_at)
+		VALUES ($1, $2, $3, $4::numeric, $5, $6, $7)
 		RETURNING id
 	`
 
+	// Orders are placed pending unless they are given another status
+	if order.Status == "" {
+		order.Status = models.OrderStatusPending
+	}
+
+	// Orders placed without a currency are in the base currency
+	if order.Currency == "" {
+		order.Currency = order.TotalPrice.Currency
+		if order.Currency == "" {
+			order.Currency = money.DefaultCurrency
+		}
+		order.ExchangeRate = money.IdentityRate(order.Currency)
+	}
+
 	now := time.Now()
 	order.CreatedAt = now
 	order.UpdatedAt = now
@@ -45,6 +64,8 @@ func (r *OrderRepository) Create(ctx context.Context, order *models.Order) error
 	err = tx.QueryRow(ctx, query,
 		order.UserID,
 		order.Status,
+		order.Currency,
+		order.ExchangeRate.Decimal(),
 		order.TotalPrice,
 		order.CreatedAt,
 		order.UpdatedAt,
@@ -61,15 +82,20 @@ func (r *OrderRepository) Create(ctx context.Context, order *models.Order) error
 		item.CreatedAt = now
 
 		itemQuery := `
-			INSERT INTO order_items (order_id, book_id, price, created_at)
-			VALUES ($1, $2, $3, $4)
+			INSERT INTO order_items (order_id, book_id, title, author, isbn, category_name, price, quantity, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 			RETURNING id
 		`
 
 		err = tx.QueryRow(ctx, itemQuery,
 			item.OrderID,
 			item.BookID,
+			item.Title,
+			item.Author,
+			item.ISBN,
+			item.CategoryName,
 			item.Price,
+			item.Quantity,
 			item.CreatedAt,
 		).Scan(&item.ID)
 
@@ -78,6 +104,16 @@ func (r *OrderRepository) Create(ctx context.Context, order *models.Order) error
 		}
 	}
 
+	// The status history starts with the customer placing the order
+	historyQuery := `
+		INSERT INTO order_status_history (order_id, to_status, changed_by, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	if _, err := tx.Exec(ctx, historyQuery, order.ID, order.Status, order.UserID, now); err != nil {
+		return fmt.Errorf("error recording order status: %w", err)
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return fmt.Errorf("error committing transaction: %w", err)
 	}
@@ -85,27 +121,18 @@ func (r *OrderRepository) Create(ctx context.Context, order *models.Order) error
 	return nil
 }
 
-// GetByID returns an order by ID
+// GetByID returns an order by ID, within the transaction from context if there is one
 func (r *OrderRepository) GetByID(ctx context.Context, id int) (*models.Order, error) {
 	query := `
-		SELECT id, user_id, status, total_price, created_at, updated_at
+		SELECT ` + orderColumns + `
 		FROM orders
 		WHERE id = $1
 	`
 
-	order := &models.Order{}
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&order.ID,
-		&order.UserID,
-		&order.Status,
-		&order.TotalPrice,
-		&order.CreatedAt,
-		&order.UpdatedAt,
-	)
-
+	order, err := scanOrder(querier(ctx, r.db).QueryRow(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("order not found")
+			return nil, repositories.ErrNotFound
 		}
 		return nil, fmt.Errorf("error getting order: %w", err)
 	}
@@ -123,7 +150,7 @@ func (r *OrderRepository) GetByID(ctx context.Context, id int) (*models.Order, e
 // GetByUserID returns a list of user's orders
 func (r *OrderRepository) GetByUserID(ctx context.Context, userID int) ([]models.Order, error) {
 	query := `
-		SELECT id, user_id, status, total_price, created_at, updated_at
+		SELECT ` + orderColumns + `
 		FROM orders
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -137,19 +164,11 @@ func (r *OrderRepository) GetByUserID(ctx context.Context, userID int) ([]models
 
 	orders := make([]models.Order, 0)
 	for rows.Next() {
-		order := models.Order{}
-		err := rows.Scan(
-			&order.ID,
-			&order.UserID,
-			&order.Status,
-			&order.TotalPrice,
-			&order.CreatedAt,
-			&order.UpdatedAt,
-		)
+		order, err := scanOrder(rows)
 		if err != nil {
 			return nil, fmt.Errorf("error scanning order data: %w", err)
 		}
-		orders = append(orders, order)
+		orders = append(orders, *order)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -168,37 +187,382 @@ func (r *OrderRepository) GetByUserID(ctx context.Context, userID int) ([]models
 	return orders, nil
 }
 
-// UpdateStatus updates order status
-func (r *OrderRepository) UpdateStatus(ctx context.Context, id int, status string) error {
+// orderKeyset is the order of order history pages, newest first
+var orderKeyset = keyset{
+	{name: "created_at", expr: "created_at", typ: "timestamptz", desc: true},
+	{name: "id", expr: "id", typ: "int", desc: true},
+}
+
+// ListByUserID returns a page of user's orders with their items, newest first.
+// Pages are read by offset, or after or before a cursor when one is set in the filter.
+// The total count is always returned in offset mode and on request in cursor mode
+func (r *OrderRepository) ListByUserID(ctx context.Context, filter models.OrderFilter) ([]models.Order, models.PageInfo, error) {
+	if filter.UserID <= 0 {
+		return nil, models.PageInfo{}, fmt.Errorf("user ID is required to list user orders")
+	}
+
+	orders, info, err := r.listOrders(ctx, filter, false)
+	if err != nil {
+		return nil, info, err
+	}
+
+	if err := loadOrderItems(ctx, r.db, orders); err != nil {
+		return nil, info, err
+	}
+
+	return orders, info, nil
+}
+
+// List returns a page of orders of all users matching the filter with their customers, newest first.
+// Items are not loaded. Pagination is the same as in ListByUserID
+func (r *OrderRepository) List(ctx context.Context, filter models.OrderFilter) ([]models.Order, models.PageInfo, error) {
+	return r.listOrders(ctx, filter, true)
+}
+
+// orderCustomerColumn selects the email of the customer of an order after orderColumns
+const orderCustomerColumn = `(SELECT email FROM users WHERE users.id = orders.user_id)`
+
+// orderConditions returns the conditions selecting the orders of the filter with their arguments,
+// numbered from $1
+func orderConditions(filter models.OrderFilter) (string, []interface{}) {
+	var conditions string
+	var args []interface{}
+	argIndex := 1
+
+	if filter.UserID > 0 {
+		conditions += fmt.Sprintf(" AND user_id = $%d", argIndex)
+		args = append(args, filter.UserID)
+		argIndex++
+	}
+
+	if len(filter.Statuses) > 0 {
+		conditions += fmt.Sprintf(" AND status = ANY($%d)", argIndex)
+		args = append(args, filter.Statuses)
+		argIndex++
+	}
+
+	if filter.CreatedFrom != nil {
+		conditions += fmt.Sprintf(" AND created_at >= $%d", argIndex)
+		args = append(args, *filter.CreatedFrom)
+		argIndex++
+	}
+
+	if filter.CreatedTo != nil {
+		conditions += fmt.Sprintf(" AND created_at < $%d", argIndex)
+		args = append(args, *filter.CreatedTo)
+		argIndex++
+	}
+
+	// Totals are in the order currency, the frozen rate converts them back to the base currency
+	if filter.MinTotal != nil {
+		conditions += fmt.Sprintf(" AND total_price / exchange_rate >= $%d", argIndex)
+		args = append(args, *filter.MinTotal)
+		argIndex++
+	}
+
+	if filter.MaxTotal != nil {
+		conditions += fmt.Sprintf(" AND total_price / exchange_rate <= $%d", argIndex)
+		args = append(args, *filter.MaxTotal)
+	}
+
+	return conditions, args
+}
+
+// listOrders returns a page of the orders of the filter without their items, newest first.
+// With withCustomer the customers of the orders are read too
+func (r *OrderRepository) listOrders(ctx context.Context, filter models.OrderFilter, withCustomer bool) ([]models.Order, models.PageInfo, error) {
+	var info models.PageInfo
+
+	conditions, args := orderConditions(filter)
+
+	// Query to count total number of orders
+	if filter.IncludeTotal || (filter.After == nil && filter.Before == nil) {
+		var total int
+		err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM orders WHERE 1=1"+conditions, args...).Scan(&total)
+		if err != nil {
+			return nil, info, fmt.Errorf("error counting orders: %w", err)
+		}
+		info.TotalCount = &total
+	}
+
+	pageSize := filter.PageSize
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+
+	page := filter.Page
+	if page <= 0 {
+		page = 1
+	}
+
+	// Rows after or before a cursor, otherwise by offset
+	cursor, backwards := filter.After, false
+	if filter.Before != nil {
+		cursor, backwards = filter.Before, true
+	}
+
+	var pagination string
+	if cursor != nil {
+		condition, cursorArgs, err := orderKeyset.condition(cursor, backwards, len(args)+1)
+		if err != nil {
+			return nil, info, err
+		}
+		conditions += " AND " + condition
+		args = append(args, cursorArgs...)
+
+		// One more row tells if there is another page
+		pagination = fmt.Sprintf("%s LIMIT %d", orderKeyset.orderBy(backwards), pageSize+1)
+	} else {
+		offset := (page - 1) * pageSize
+		pagination = fmt.Sprintf("%s LIMIT %d OFFSET %d", orderKeyset.orderBy(false), pageSize+1, offset)
+	}
+
+	columns := orderColumns
+	if withCustomer {
+		columns += ", " + orderCustomerColumn
+	}
+
+	query := `
+		SELECT ` + columns + `, ` + orderKeyset.valuesColumn() + ` AS cursor_values
+		FROM orders
+		WHERE 1=1` + conditions + pagination
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, info, fmt.Errorf("error getting orders: %w", err)
+	}
+	defer rows.Close()
+
+	// orderRow is a selected order with its cursor values
+	type orderRow struct {
+		order        *models.Order
+		cursorValues []string
+	}
+
+	orderRows := make([]orderRow, 0, pageSize+1)
+	for rows.Next() {
+		var row orderRow
+		var email *string
+		extra := []interface{}{&row.cursorValues}
+		if withCustomer {
+			extra = []interface{}{&email, &row.cursorValues}
+		}
+
+		row.order, err = scanOrder(rows, extra...)
+		if err != nil {
+			return nil, info, fmt.Errorf("error scanning order data: %w", err)
+		}
+		if withCustomer {
+			row.order.Customer = orderCustomer(row.order.UserID, email)
+		}
+		orderRows = append(orderRows, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, info, fmt.Errorf("error iterating over results: %w", err)
+	}
+
+	orderRows, more := trimPage(orderRows, pageSize, backwards)
+
+	orders := make([]models.Order, len(orderRows))
+	for i, row := range orderRows {
+		orders[i] = *row.order
+	}
+
+	if len(orderRows) > 0 {
+		first, last := orderRows[0], orderRows[len(orderRows)-1]
+		setPageCursors(&info,
+			orderKeyset.cursor(first.cursorValues, first.order.ID),
+			orderKeyset.cursor(last.cursorValues, last.order.ID),
+			more, backwards, cursor == nil && page == 1)
+	}
+
+	return orders, info, nil
+}
+
+// Stream calls fn with every order matching the filter with its items and customer, newest first.
+// Orders are read through a cursor in a read-only snapshot, so the export is consistent
+// however long it takes. Streaming stops at the first error returned by fn
+func (r *OrderRepository) Stream(ctx context.Context, filter models.OrderFilter, fn func(order *models.Order) error) error {
+	conditions, args := orderConditions(filter)
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Nothing is written, the cursor is closed with the transaction
+	defer tx.Rollback(ctx)
+
 	query := `
-		UPDATE orders
-		SET status = $1, updated_at = $2
-		WHERE id = $3
+		DECLARE order_stream NO SCROLL CURSOR FOR
+		SELECT ` + orderColumns + `, ` + orderCustomerColumn + `
+		FROM orders
+		WHERE 1=1
+	` + conditions + orderKeyset.orderBy(false)
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to declare order cursor: %w", err)
+	}
+
+	fetch := fmt.Sprintf("FETCH FORWARD %d FROM order_stream", streamFetchSize)
+	orders := make([]models.Order, 0, streamFetchSize)
+	for {
+		orders, err = fetchOrders(ctx, tx, fetch, orders[:0])
+		if err != nil {
+			return err
+		}
+		if len(orders) == 0 {
+			return nil
+		}
+
+		// Items of the whole batch are read at once
+		if err := loadOrderItems(ctx, tx, orders); err != nil {
+			return err
+		}
+
+		for i := range orders {
+			if err := fn(&orders[i]); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// fetchOrders appends the orders of a FETCH from a cursor over orderColumns and orderCustomerColumn to orders
+func fetchOrders(ctx context.Context, tx pgx.Tx, fetch string, orders []models.Order) ([]models.Order, error) {
+	rows, err := tx.Query(ctx, fetch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch orders: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var email *string
+		order, err := scanOrder(rows, &email)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order row: %w", err)
+		}
+		order.Customer = orderCustomer(order.UserID, email)
+		orders = append(orders, *order)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return orders, nil
+}
+
+// orderCustomer returns the customer of an order, nil if the user no longer exists
+func orderCustomer(userID int, email *string) *models.OrderCustomer {
+	if email == nil {
+		return nil
+	}
+	return &models.OrderCustomer{ID: userID, Email: *email}
+}
+
+// UpdateStatus moves an order to a new status and records the change in its history.
+// The order must still have the previous status of the change, otherwise ErrNotFound is returned
+func (r *OrderRepository) UpdateStatus(ctx context.Context, change *models.OrderStatusChange) error {
+	// Both statements run as one, the history cannot miss a change
+	query := `
+		WITH updated AS (
+			UPDATE orders
+			SET status = $1, updated_at = $2
+			WHERE id = $3 AND status = $4
+			RETURNING id
+		)
+		INSERT INTO order_status_history (order_id, from_status, to_status, changed_by, reason, created_at)
+		SELECT id, $4, $1, $5, $6, $2 FROM updated
+		RETURNING id
 	`
 
-	_, err := r.db.Exec(ctx, query, status, time.Now(), id)
+	change.CreatedAt = time.Now()
+
+	err := querier(ctx, r.db).QueryRow(ctx, query,
+		change.ToStatus,
+		change.CreatedAt,
+		change.OrderID,
+		change.FromStatus,
+		change.ChangedBy,
+		change.Reason,
+	).Scan(&change.ID)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repositories.ErrNotFound
+		}
 		return fmt.Errorf("error updating order status: %w", err)
 	}
 
 	return nil
 }
 
-// AddOrderItem adds an item to the order
+// GetStatusHistory returns the status changes of an order, oldest first
+func (r *OrderRepository) GetStatusHistory(ctx context.Context, orderID int) ([]models.OrderStatusChange, error) {
+	query := `
+		SELECT id, order_id, COALESCE(from_status, ''), to_status, changed_by, reason, created_at
+		FROM order_status_history
+		WHERE order_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := querier(ctx, r.db).Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting order status history: %w", err)
+	}
+	defer rows.Close()
+
+	history := make([]models.OrderStatusChange, 0)
+	for rows.Next() {
+		var change models.OrderStatusChange
+		err := rows.Scan(
+			&change.ID,
+			&change.OrderID,
+			&change.FromStatus,
+			&change.ToStatus,
+			&change.ChangedBy,
+			&change.Reason,
+			&change.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning order status change: %w", err)
+		}
+		history = append(history, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over results: %w", err)
+	}
+
+	return history, nil
+}
+
+// AddOrderItem adds an item to the order, within the transaction from context if there is one
 func (r *OrderRepository) AddOrderItem(ctx context.Context, orderID int, item models.OrderItem) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
-		INSERT INTO order_items (order_id, book_id, price, created_at)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO order_items (order_id, book_id, title, author, isbn, category_name, price, quantity, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING id
 	`
 
 	item.OrderID = orderID
 	item.CreatedAt = time.Now()
 
-	err := r.db.QueryRow(ctx, query,
+	err = tx.QueryRow(ctx, query,
 		item.OrderID,
 		item.BookID,
+		item.Title,
+		item.Author,
+		item.ISBN,
+		item.CategoryName,
 		item.Price,
+		item.Quantity,
 		item.CreatedAt,
 	).Scan(&item.ID)
 
@@ -213,23 +577,67 @@ func (r *OrderRepository) AddOrderItem(ctx context.Context, orderID int, item mo
 		WHERE id = $3
 	`
 
-	_, err = r.db.Exec(ctx, updateQuery, item.Price, time.Now(), orderID)
+	_, err = tx.Exec(ctx, updateQuery, item.Subtotal(), time.Now(), orderID)
 	if err != nil {
 		return fmt.Errorf("error updating order total price: %w", err)
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+
 	return nil
 }
 
 // GetOrderItems returns a list of items in the order
 func (r *OrderRepository) GetOrderItems(ctx context.Context, orderID int) ([]models.OrderItem, error) {
+	return queryOrderItems(ctx, querier(ctx, r.db), "oi.order_id = $1", orderID)
+}
+
+// loadOrderItems sets the items of the orders, reading them with a single query
+func loadOrderItems(ctx context.Context, q pgxQuerier, orders []models.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(orders))
+	for i, order := range orders {
+		ids[i] = order.ID
+	}
+
+	items, err := queryOrderItems(ctx, q, "oi.order_id = ANY($1)", ids)
+	if err != nil {
+		return err
+	}
+
+	itemsByOrder := make(map[int][]models.OrderItem, len(orders))
+	for _, item := range items {
+		itemsByOrder[item.OrderID] = append(itemsByOrder[item.OrderID], item)
+	}
+
+	for i := range orders {
+		orders[i].Items = itemsByOrder[orders[i].ID]
+		if orders[i].Items == nil {
+			orders[i].Items = make([]models.OrderItem, 0)
+		}
+	}
+
+	return nil
+}
+
+// queryOrderItems returns the order items matching the condition
+func queryOrderItems(ctx context.Context, q pgxQuerier, condition string, arg interface{}) ([]models.OrderItem, error) {
+	// Item prices are in the order currency
 	query := `
-		SELECT id, order_id, book_id, price, created_at
-		FROM order_items
-		WHERE order_id = $1
+		SELECT oi.id, oi.order_id, oi.book_id, oi.title, oi.author, oi.isbn, oi.category_name,
+			oi.price, o.currency, oi.quantity, oi.created_at
+		FROM order_items oi
+		JOIN orders o ON o.id = oi.order_id
+		WHERE ` + condition + `
+		ORDER BY oi.order_id, oi.id
 	`
 
-	rows, err := r.db.Query(ctx, query, orderID)
+	rows, err := q.Query(ctx, query, arg)
 	if err != nil {
 		return nil, fmt.Errorf("error getting order items: %w", err)
 	}
@@ -238,16 +646,27 @@ func (r *OrderRepository) GetOrderItems(ctx context.Context, orderID int) ([]mod
 	items := make([]models.OrderItem, 0)
 	for rows.Next() {
 		item := models.OrderItem{}
+		var price pgtype.Numeric
+		var currency string
 		err := rows.Scan(
 			&item.ID,
 			&item.OrderID,
 			&item.BookID,
-			&item.Price,
+			&item.Title,
+			&item.Author,
+			&item.ISBN,
+			&item.CategoryName,
+			&price,
+			&currency,
+			&item.Quantity,
 			&item.CreatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error scanning item data: %w", err)
 		}
+		if item.Price, err = money.FromNumeric(price, currency); err != nil {
+			return nil, fmt.Errorf("error scanning item price: %w", err)
+		}
 		items = append(items, item)
 	}
 
@@ -258,9 +677,9 @@ func (r *OrderRepository) GetOrderItems(ctx context.Context, orderID int) ([]mod
 	return items, nil
 }
 
-// Delete deletes an order by ID
+// Delete deletes an order by ID, within the transaction from context if there is one
 func (r *OrderRepository) Delete(ctx context.Context, id int) error {
-	tx, err := r.db.Begin(ctx)
+	tx, err := beginTx(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("error starting transaction: %w", err)
 	}
@@ -292,3 +711,36 @@ func (r *OrderRepository) Delete(ctx context.Context, id int) error {
 
 	return nil
 }
+
+// scanOrder scans a row selected with orderColumns, followed by the extra columns.
+// Amounts are read in the order currency
+func scanOrder(row pgx.Row, extra ...interface{}) (*models.Order, error) {
+	order := &models.Order{}
+	var rate string
+	var total pgtype.Numeric
+
+	dest := []interface{}{
+		&order.ID,
+		&order.UserID,
+		&order.Status,
+		&order.Currency,
+		&rate,
+		&total,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+	}
+
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.ExchangeRate, err = money.ParseRate(money.DefaultCurrency, order.Currency, rate); err != nil {
+		return nil, fmt.Errorf("error parsing exchange rate: %w", err)
+	}
+	if order.TotalPrice, err = money.FromNumeric(total, order.Currency); err != nil {
+		return nil, fmt.Errorf("error parsing total price: %w", err)
+	}
+
+	return order, nil
+}
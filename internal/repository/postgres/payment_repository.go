@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/bookshop/api/pkg/money"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// paymentColumns are the columns scanned by scanPayment
+const paymentColumns = `id, order_id, provider, COALESCE(provider_ref, ''), status, amount, currency, failure_reason, created_at, updated_at`
+
+// PaymentRepository implements repositories.PaymentRepository interface
+type PaymentRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPaymentRepository creates a new payment repository instance
+func NewPaymentRepository(db *pgxpool.Pool) repositories.PaymentRepository {
+	return &PaymentRepository{
+		db: db,
+	}
+}
+
+// Create creates a new payment
+func (r *PaymentRepository) Create(ctx context.Context, payment *models.Payment) error {
+	query := `
+		INSERT INTO payments (order_id, provider, provider_ref, status, amount, currency, failure_reason, created_at, updated_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8, $8)
+		RETURNING id
+	`
+
+	payment.CreatedAt = time.Now()
+	payment.UpdatedAt = payment.CreatedAt
+
+	err := querier(ctx, r.db).QueryRow(ctx, query,
+		payment.OrderID,
+		payment.Provider,
+		payment.ProviderRef,
+		payment.Status,
+		payment.Amount,
+		payment.Amount.Currency,
+		payment.FailureReason,
+		payment.CreatedAt,
+	).Scan(&payment.ID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == UniqueViolationCode {
+			return repositories.ErrDuplicateKey
+		}
+		return fmt.Errorf("error creating payment: %w", err)
+	}
+
+	return nil
+}
+
+// GetByProviderRef returns a payment by the provider and its reference of the payment
+func (r *PaymentRepository) GetByProviderRef(ctx context.Context, provider, ref string) (*models.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE provider = $1 AND provider_ref = $2`
+
+	payment, err := scanPayment(querier(ctx, r.db).QueryRow(ctx, query, provider, ref))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repositories.ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting payment: %w", err)
+	}
+
+	return payment, nil
+}
+
+// GetCapturedByOrderID returns the captured payment of an order
+func (r *PaymentRepository) GetCapturedByOrderID(ctx context.Context, orderID int) (*models.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE order_id = $1 AND status = $2`
+
+	payment, err := scanPayment(querier(ctx, r.db).QueryRow(ctx, query, orderID, models.PaymentStatusCaptured))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repositories.ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting captured payment: %w", err)
+	}
+
+	return payment, nil
+}
+
+// ListByOrderID returns the payments of an order, oldest first
+func (r *PaymentRepository) ListByOrderID(ctx context.Context, orderID int) ([]models.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE order_id = $1 ORDER BY created_at, id`
+
+	rows, err := querier(ctx, r.db).Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting payments: %w", err)
+	}
+	defer rows.Close()
+
+	payments := make([]models.Payment, 0)
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning payment: %w", err)
+		}
+		payments = append(payments, *payment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over results: %w", err)
+	}
+
+	return payments, nil
+}
+
+// UpdateStatus moves a payment from the previous status to its status
+func (r *PaymentRepository) UpdateStatus(ctx context.Context, payment *models.Payment, from string) error {
+	query := `
+		UPDATE payments
+		SET status = $1, provider_ref = COALESCE(NULLIF($2, ''), provider_ref), failure_reason = $3, updated_at = $4
+		WHERE id = $5 AND status = $6
+	`
+
+	updatedAt := time.Now()
+
+	result, err := querier(ctx, r.db).Exec(ctx, query,
+		payment.Status,
+		payment.ProviderRef,
+		payment.FailureReason,
+		updatedAt,
+		payment.ID,
+		from,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == UniqueViolationCode {
+			return repositories.ErrDuplicateKey
+		}
+		return fmt.Errorf("error updating payment status: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repositories.ErrNotFound
+	}
+
+	payment.UpdatedAt = updatedAt
+
+	return nil
+}
+
+// scanPayment scans a row of paymentColumns
+func scanPayment(row pgx.Row) (*models.Payment, error) {
+	payment := &models.Payment{}
+	var amount pgtype.Numeric
+	var currency string
+
+	err := row.Scan(
+		&payment.ID,
+		&payment.OrderID,
+		&payment.Provider,
+		&payment.ProviderRef,
+		&payment.Status,
+		&amount,
+		&currency,
+		&payment.FailureReason,
+		&payment.CreatedAt,
+		&payment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment.Amount, err = money.FromNumeric(amount, currency); err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
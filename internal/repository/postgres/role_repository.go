@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ForeignKeyViolationCode - error code for foreign key constraint violation
+const ForeignKeyViolationCode = "23503"
+
+// RoleRepository implements repositories.RoleRepository interface
+type RoleRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewRoleRepository creates a new role repository instance
+func NewRoleRepository(db *pgxpool.Pool) repositories.RoleRepository {
+	return &RoleRepository{
+		db: db,
+	}
+}
+
+// List returns all roles with their permissions
+func (r *RoleRepository) List(ctx context.Context) ([]models.Role, error) {
+	query := `
+		SELECT r.id, r.name, r.description,
+			COALESCE(array_agg(p.name ORDER BY p.name) FILTER (WHERE p.name IS NOT NULL), '{}')
+		FROM roles r
+		LEFT JOIN role_permissions rp ON rp.role_id = r.id
+		LEFT JOIN permissions p ON p.id = rp.permission_id
+		GROUP BY r.id
+		ORDER BY r.name
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	defer rows.Close()
+
+	var roles []models.Role
+	for rows.Next() {
+		var role models.Role
+		if err := rows.Scan(&role.ID, &role.Name, &role.Description, &role.Permissions); err != nil {
+			return nil, fmt.Errorf("failed to scan role: %w", err)
+		}
+		roles = append(roles, role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+// SetUserRoles replaces the roles assigned to the user
+func (r *RoleRepository) SetUserRoles(ctx context.Context, userID int, roles []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The user is locked so that it is not deleted before the roles are assigned.
+	// Without roles nothing is inserted, so a missing user would not be noticed otherwise
+	var exists bool
+	err = tx.QueryRow(ctx, `SELECT true FROM users WHERE id = $1 FOR SHARE`, userID).Scan(&exists)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domainerrors.ErrUserNotFound
+		}
+		return fmt.Errorf("failed to check user: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM user_roles WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to clear user roles: %w", err)
+	}
+
+	query := `
+		INSERT INTO user_roles (user_id, role_id)
+		SELECT $1, id FROM roles WHERE name = ANY($2)
+	`
+
+	result, err := tx.Exec(ctx, query, userID, roles)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == ForeignKeyViolationCode {
+			return domainerrors.ErrUserNotFound
+		}
+		return fmt.Errorf("failed to assign user roles: %w", err)
+	}
+
+	// Every requested role must exist
+	if int(result.RowsAffected()) != len(roles) {
+		return repositories.ErrNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return nil
+}
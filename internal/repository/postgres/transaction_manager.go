@@ -99,6 +99,23 @@ func GetTx(ctx context.Context) pgx.Tx {
 	return tx
 }
 
+// beginTx starts a transaction, or a savepoint of the transaction from context if there is one,
+// so that repository methods needing a transaction of their own also join the caller's
+func beginTx(ctx context.Context, db *pgxpool.Pool) (pgx.Tx, error) {
+	if tx := GetTx(ctx); tx != nil {
+		return tx.Begin(ctx)
+	}
+	return db.Begin(ctx)
+}
+
+// querier returns the transaction from context or the pool if no transaction exists
+func querier(ctx context.Context, db *pgxpool.Pool) pgxQuerier {
+	if tx := GetTx(ctx); tx != nil {
+		return tx
+	}
+	return db
+}
+
 // GetQueryer returns the transaction from context or connection pool if no transaction exists
 func (m *TransactionManager) GetQueryer(ctx context.Context) pgxQuerier {
 	tx := GetTx(ctx)
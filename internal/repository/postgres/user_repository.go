@@ -35,8 +35,8 @@ func NewUserRepository(db *pgxpool.Pool) repositories.UserRepository {
 // Create creates a new user
 func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
 	query := `
-		INSERT INTO users (email, password_hash, is_admin, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO users (email, password_hash, created_at, updated_at)
+		VALUES ($1, $2, $3, $4)
 		RETURNING id
 	`
 
@@ -47,7 +47,6 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
 	err := r.db.QueryRow(ctx, query,
 		user.Email,
 		user.PasswordHash,
-		user.IsAdmin,
 		user.CreatedAt,
 		user.UpdatedAt,
 	).Scan(&user.ID)
@@ -67,9 +66,15 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
 // GetByID returns a user by ID
 func (r *UserRepository) GetByID(ctx context.Context, id int) (*models.User, error) {
 	query := `
-		SELECT id, email, password_hash, is_admin, created_at, updated_at
-		FROM users
-		WHERE id = $1
+		SELECT u.id, u.email, u.password_hash, u.created_at, u.updated_at,
+			COALESCE((
+				SELECT array_agg(r.name ORDER BY r.name)
+				FROM user_roles ur
+				JOIN roles r ON r.id = ur.role_id
+				WHERE ur.user_id = u.id
+			), '{}')
+		FROM users u
+		WHERE u.id = $1
 	`
 
 	user := &models.User{}
@@ -77,9 +82,9 @@ func (r *UserRepository) GetByID(ctx context.Context, id int) (*models.User, err
 		&user.ID,
 		&user.Email,
 		&user.PasswordHash,
-		&user.IsAdmin,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.Roles,
 	)
 
 	if err != nil {
@@ -95,9 +100,15 @@ func (r *UserRepository) GetByID(ctx context.Context, id int) (*models.User, err
 // GetByEmail returns a user by email
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	query := `
-		SELECT id, email, password_hash, is_admin, created_at, updated_at
-		FROM users
-		WHERE email = $1
+		SELECT u.id, u.email, u.password_hash, u.created_at, u.updated_at,
+			COALESCE((
+				SELECT array_agg(r.name ORDER BY r.name)
+				FROM user_roles ur
+				JOIN roles r ON r.id = ur.role_id
+				WHERE ur.user_id = u.id
+			), '{}')
+		FROM users u
+		WHERE u.email = $1
 	`
 
 	user := &models.User{}
@@ -105,9 +116,9 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.
 		&user.ID,
 		&user.Email,
 		&user.PasswordHash,
-		&user.IsAdmin,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.Roles,
 	)
 
 	if err != nil {
@@ -124,8 +135,8 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.
 func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
 	query := `
 		UPDATE users
-		SET email = $1, password_hash = $2, is_admin = $3, updated_at = $4
-		WHERE id = $5
+		SET email = $1, password_hash = $2, updated_at = $3
+		WHERE id = $4
 	`
 
 	user.UpdatedAt = time.Now()
@@ -133,7 +144,6 @@ func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
 	_, err := r.db.Exec(ctx, query,
 		user.Email,
 		user.PasswordHash,
-		user.IsAdmin,
 		user.UpdatedAt,
 		user.ID,
 	)
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// webhookErrorMaxLength is the length of the error column of webhook events
+const webhookErrorMaxLength = 1000
+
+// WebhookEventRepository implements repositories.WebhookEventRepository interface
+type WebhookEventRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewWebhookEventRepository creates a new webhook event repository instance
+func NewWebhookEventRepository(db *pgxpool.Pool) repositories.WebhookEventRepository {
+	return &WebhookEventRepository{
+		db: db,
+	}
+}
+
+// Record stores a received event, or counts another delivery of an event stored before
+func (r *WebhookEventRepository) Record(ctx context.Context, event *models.StoredWebhookEvent) error {
+	// The payload of the first delivery is kept, redeliveries carry the same event
+	query := `
+		INSERT INTO payment_webhook_events
+			(provider, event_id, event_type, provider_ref, payload, signature, status, received_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+		ON CONFLICT (provider, event_id) DO UPDATE
+		SET deliveries = payment_webhook_events.deliveries + 1
+		RETURNING id, status, deliveries, received_at
+	`
+
+	err := querier(ctx, r.db).QueryRow(ctx, query,
+		event.Provider,
+		event.EventID,
+		event.EventType,
+		event.ProviderRef,
+		string(event.Payload),
+		event.Signature,
+		models.WebhookEventReceived,
+		time.Now(),
+	).Scan(&event.ID, &event.Status, &event.Deliveries, &event.ReceivedAt)
+	if err != nil {
+		return fmt.Errorf("error recording webhook event: %w", err)
+	}
+
+	return nil
+}
+
+// Claim marks an event as being processed by the caller
+func (r *WebhookEventRepository) Claim(ctx context.Context, id int, staleBefore time.Time) error {
+	query := `
+		UPDATE payment_webhook_events
+		SET status = $1, updated_at = $2
+		WHERE id = $3
+			AND (status IN ($4, $5) OR (status = $1 AND updated_at < $6))
+	`
+
+	result, err := querier(ctx, r.db).Exec(ctx, query,
+		models.WebhookEventProcessing,
+		time.Now(),
+		id,
+		models.WebhookEventReceived,
+		models.WebhookEventFailed,
+		staleBefore,
+	)
+	if err != nil {
+		return fmt.Errorf("error claiming webhook event: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repositories.ErrNotFound
+	}
+
+	return nil
+}
+
+// Finish records the outcome of processing an event
+func (r *WebhookEventRepository) Finish(ctx context.Context, id int, status string, message string) error {
+	if len(message) > webhookErrorMaxLength {
+		message = strings.ToValidUTF8(message[:webhookErrorMaxLength], "")
+	}
+
+	query := `
+		UPDATE payment_webhook_events
+		SET status = $1, error = $2, updated_at = $3, processed_at = CASE WHEN $1 = $5 THEN NULL ELSE $3 END
+		WHERE id = $4
+	`
+
+	now := time.Now()
+	result, err := querier(ctx, r.db).Exec(ctx, query, status, message, now, id, models.WebhookEventFailed)
+	if err != nil {
+		return fmt.Errorf("error finishing webhook event: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return repositories.ErrNotFound
+	}
+
+	return nil
+}
@@ -3,10 +3,12 @@ package redis
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/bookshop/api/internal/domain/models"
+	"github.com/bookshop/api/internal/domain/repositories"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -29,19 +31,15 @@ func NewCartRepository(client *redis.Client) *CartRepository {
 	}
 }
 
-// AddItem adds an item to the user's cart
-func (r *CartRepository) AddItem(ctx context.Context, userID int, bookID int, expiresAt time.Time) error {
+// SaveItem adds an item to the user's cart or replaces the item for the same book
+func (r *CartRepository) SaveItem(ctx context.Context, userID int, item models.CartItem) error {
 	// Check if cart is locked
 	if r.isCartLocked(ctx, userID) {
 		return fmt.Errorf("cart is locked")
 	}
 
-	// Create cart item
-	item := models.CartItem{
-		BookID:    bookID,
-		AddedAt:   time.Now(),
-		ExpiresAt: expiresAt,
-	}
+	// Book details are loaded separately and are not stored in the cart
+	item.Book = nil
 
 	// Serialize item
 	itemJSON, err := json.Marshal(item)
@@ -51,13 +49,37 @@ func (r *CartRepository) AddItem(ctx context.Context, userID int, bookID int, ex
 
 	// Add item to cart
 	key := fmt.Sprintf("%s%d", cartKeyPrefix, userID)
-	if err := r.client.HSet(ctx, key, fmt.Sprint(bookID), itemJSON).Err(); err != nil {
+	if err := r.client.HSet(ctx, key, fmt.Sprint(item.BookID), itemJSON).Err(); err != nil {
 		return fmt.Errorf("error adding item to cart: %w", err)
 	}
 
 	return nil
 }
 
+// GetItem returns the cart item for the book
+func (r *CartRepository) GetItem(ctx context.Context, userID int, bookID int) (*models.CartItem, error) {
+	key := fmt.Sprintf("%s%d", cartKeyPrefix, userID)
+	itemJSON, err := r.client.HGet(ctx, key, fmt.Sprint(bookID)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, repositories.ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting cart item: %w", err)
+	}
+
+	item, err := decodeCartItem(itemJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check item expiration
+	if time.Now().After(item.ExpiresAt) {
+		return nil, repositories.ErrNotFound
+	}
+
+	return item, nil
+}
+
 // GetCart returns the user's cart
 func (r *CartRepository) GetCart(ctx context.Context, userID int) (*models.Cart, error) {
 	// Get all cart items
@@ -75,9 +97,9 @@ func (r *CartRepository) GetCart(ctx context.Context, userID int) (*models.Cart,
 
 	// Deserialize items
 	for _, itemJSON := range items {
-		var item models.CartItem
-		if err := json.Unmarshal([]byte(itemJSON), &item); err != nil {
-			return nil, fmt.Errorf("error deserializing cart item: %w", err)
+		item, err := decodeCartItem(itemJSON)
+		if err != nil {
+			return nil, err
 		}
 
 		// Check item expiration
@@ -85,7 +107,7 @@ func (r *CartRepository) GetCart(ctx context.Context, userID int) (*models.Cart,
 			continue
 		}
 
-		cart.Items = append(cart.Items, item)
+		cart.Items = append(cart.Items, *item)
 	}
 
 	return cart, nil
@@ -240,6 +262,21 @@ func (r *CartRepository) GetExpiredCarts(ctx context.Context) ([]models.Cart, er
 	return expiredCarts, nil
 }
 
+// decodeCartItem deserializes a cart item
+func decodeCartItem(itemJSON string) (*models.CartItem, error) {
+	var item models.CartItem
+	if err := json.Unmarshal([]byte(itemJSON), &item); err != nil {
+		return nil, fmt.Errorf("error deserializing cart item: %w", err)
+	}
+
+	// Items stored before quantities were introduced hold a single copy
+	if item.Quantity <= 0 {
+		item.Quantity = 1
+	}
+
+	return &item, nil
+}
+
 // isCartLocked checks if the cart is locked
 func (r *CartRepository) isCartLocked(ctx context.Context, userID int) bool {
 	key := fmt.Sprintf("%s%d", cartLockKeyPrefix, userID)
@@ -0,0 +1,88 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// refreshFamilyKeyPrefix prefix for refresh token family keys
+	refreshFamilyKeyPrefix = "refresh_family:"
+)
+
+// rotateScript swaps the current token of a family if the presented token is the current one.
+// Returns 1 on success, 0 if the family does not exist and -1 if a stale token was presented
+// (in which case the family is deleted)
+var rotateScript = redis.NewScript(`
+local current = redis.call('HGET', KEYS[1], 'token_id')
+if not current then
+	return 0
+end
+if current ~= ARGV[1] then
+	redis.call('DEL', KEYS[1])
+	return -1
+end
+redis.call('HSET', KEYS[1], 'token_id', ARGV[2])
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+return 1
+`)
+
+// RefreshTokenRepository implements repositories.RefreshTokenRepository interface
+type RefreshTokenRepository struct {
+	client *redis.Client
+}
+
+// NewRefreshTokenRepository creates a new instance of refresh token repository
+func NewRefreshTokenRepository(client *redis.Client) *RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		client: client,
+	}
+}
+
+// CreateFamily starts a new refresh token family
+func (r *RefreshTokenRepository) CreateFamily(ctx context.Context, familyID string, userID int, tokenID string, ttl time.Duration) error {
+	key := refreshFamilyKeyPrefix + familyID
+
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, key, "token_id", tokenID, "user_id", userID)
+	pipe.PExpire(ctx, key, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("error creating refresh token family: %w", err)
+	}
+
+	return nil
+}
+
+// Rotate atomically replaces the current token of the family
+func (r *RefreshTokenRepository) Rotate(ctx context.Context, familyID, oldTokenID, newTokenID string, ttl time.Duration) error {
+	key := refreshFamilyKeyPrefix + familyID
+
+	result, err := rotateScript.Run(ctx, r.client, []string{key}, oldTokenID, newTokenID, ttl.Milliseconds()).Int()
+	if err != nil {
+		return fmt.Errorf("error rotating refresh token: %w", err)
+	}
+
+	switch result {
+	case 0:
+		return repositories.ErrNotFound
+	case -1:
+		return domainerrors.ErrTokenReused
+	}
+
+	return nil
+}
+
+// RevokeFamily revokes all refresh tokens of the family
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	key := refreshFamilyKeyPrefix + familyID
+	if err := r.client.Del(ctx, key).Err(); err != nil {
+		return fmt.Errorf("error revoking refresh token family: %w", err)
+	}
+
+	return nil
+}
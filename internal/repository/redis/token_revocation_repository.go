@@ -0,0 +1,84 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// revokedTokenKeyPrefix prefix for revoked token keys
+	revokedTokenKeyPrefix = "revoked_token:"
+	// userRevocationKeyPrefix prefix for per-user revocation watermark keys
+	userRevocationKeyPrefix = "tokens_revoked_before:"
+)
+
+// TokenRevocationRepository implements repositories.TokenRevocationRepository interface
+type TokenRevocationRepository struct {
+	client *redis.Client
+	// watermarkTTL is how long a user watermark is kept; it must not be shorter
+	// than the lifetime of the longest-lived token
+	watermarkTTL time.Duration
+}
+
+// NewTokenRevocationRepository creates a new instance of token revocation repository
+func NewTokenRevocationRepository(client *redis.Client, watermarkTTL time.Duration) *TokenRevocationRepository {
+	return &TokenRevocationRepository{
+		client:       client,
+		watermarkTTL: watermarkTTL,
+	}
+}
+
+// RevokeToken adds the token ID to the denylist until the token expires
+func (r *TokenRevocationRepository) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		// The token has already expired, nothing to revoke
+		return nil
+	}
+
+	key := revokedTokenKeyPrefix + tokenID
+	if err := r.client.Set(ctx, key, 1, ttl).Err(); err != nil {
+		return fmt.Errorf("error revoking token: %w", err)
+	}
+
+	return nil
+}
+
+// IsTokenRevoked checks if the token ID is in the denylist
+func (r *TokenRevocationRepository) IsTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	key := revokedTokenKeyPrefix + tokenID
+	exists, err := r.client.Exists(ctx, key).Result()
+	if err != nil {
+		return false, fmt.Errorf("error checking revoked token: %w", err)
+	}
+
+	return exists == 1, nil
+}
+
+// SetUserRevocationTime sets the watermark before which all tokens of the user are invalid
+func (r *TokenRevocationRepository) SetUserRevocationTime(ctx context.Context, userID int, revokedAt time.Time) error {
+	key := fmt.Sprintf("%s%d", userRevocationKeyPrefix, userID)
+	if err := r.client.Set(ctx, key, revokedAt.UnixMicro(), r.watermarkTTL).Err(); err != nil {
+		return fmt.Errorf("error setting user revocation time: %w", err)
+	}
+
+	return nil
+}
+
+// GetUserRevocationTime returns the user's watermark in microseconds or zero time if none is set
+func (r *TokenRevocationRepository) GetUserRevocationTime(ctx context.Context, userID int) (time.Time, error) {
+	key := fmt.Sprintf("%s%d", userRevocationKeyPrefix, userID)
+	value, err := r.client.Get(ctx, key).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("error getting user revocation time: %w", err)
+	}
+
+	return time.UnixMicro(value), nil
+}
@@ -1,37 +1,49 @@
 package server
 
 import (
-	"net/http"
+	"strings"
 
+	"github.com/bookshop/api/internal/domain/models"
 	"github.com/bookshop/api/internal/middleware"
-	"github.com/labstack/echo/v4"
 )
 
 // registerRoutes registers all API routes
 func (s *Server) registerRoutes() {
 	e := s.echo
 
-	// API v1 group
+	// Uploaded files of the local storage are served by the API unless they are served elsewhere
+	if s.config.Storage.Provider == "local" && strings.HasPrefix(s.config.Storage.PublicURL, "/") {
+		e.Static(s.config.Storage.PublicURL, s.config.Storage.LocalDir)
+	}
+
+	// API v1 group, prices are shown in the currency of the request
 	v1 := e.Group("/api/v1")
+	v1.Use(middleware.Currency(s.pricingModule.Service))
 
 	// Public routes
 	public := v1.Group("")
 	public.GET("/health", s.HealthCheck)
 
 	// Register book routes
-	s.bookModule.RegisterRoutes(v1)
+	s.bookModule.RegisterRoutes(public)
+
+	// Register author routes
+	s.authorModule.RegisterRoutes(public)
+
+	// Register category routes
+	s.categoryHandler.RegisterRoutes(public)
+
+	// Register exchange rate routes
+	s.pricingModule.RegisterRoutes(public)
+
+	// Payment provider webhooks
+	s.paymentModule.RegisterRoutes(public)
 
 	// Authentication routes
-	auth := public.Group("/auth")
-	auth.POST("/register", func(c echo.Context) error {
-		return c.JSON(http.StatusOK, map[string]string{"message": "Registration"})
-	})
-	auth.POST("/login", func(c echo.Context) error {
-		return c.JSON(http.StatusOK, map[string]string{"message": "Login"})
-	})
+	s.authHandler.RegisterRoutes(public)
 
 	// Create JWT configuration
-	jwtConfig := middleware.NewJWTConfig(s.config.JWT.Secret)
+	jwtConfig := middleware.NewJWTConfig(s.config.JWT.Secret, s.tokenRevocations)
 
 	// Protected routes (require authentication)
 	protected := v1.Group("")
@@ -43,31 +55,29 @@ func (s *Server) registerRoutes() {
 	// Checkout routes
 	s.checkoutHandler.RegisterRoutes(protected)
 
-	// Admin routes
+	// Order payment routes
+	s.paymentModule.RegisterProtectedRoutes(protected)
+
+	// Admin routes, each group requires its own permission
 	admin := protected.Group("/admin")
-	admin.Use(middleware.AdminMiddleware())
+
+	// Role management
+	s.rbacModule.RegisterRoutes(admin)
 
 	// Category management
-	adminCategories := admin.Group("/categories")
-	adminCategories.POST("", func(c echo.Context) error {
-		return c.JSON(http.StatusOK, map[string]string{"message": "Create category"})
-	})
-	adminCategories.PUT("/:id", func(c echo.Context) error {
-		return c.JSON(http.StatusOK, map[string]string{"message": "Update category"})
-	})
-	adminCategories.DELETE("/:id", func(c echo.Context) error {
-		return c.JSON(http.StatusOK, map[string]string{"message": "Delete category"})
-	})
+	s.categoryHandler.RegisterAdminRoutes(admin,
+		middleware.RequirePermission(s.rbacModule.Service, models.PermissionCategoriesWrite))
 
 	// Book management
-	adminBooks := admin.Group("/books")
-	adminBooks.POST("", func(c echo.Context) error {
-		return c.JSON(http.StatusOK, map[string]string{"message": "Create book"})
-	})
-	adminBooks.PUT("/:id", func(c echo.Context) error {
-		return c.JSON(http.StatusOK, map[string]string{"message": "Update book"})
-	})
-	adminBooks.DELETE("/:id", func(c echo.Context) error {
-		return c.JSON(http.StatusOK, map[string]string{"message": "Delete book"})
-	})
+	s.bookModule.RegisterAdminRoutes(admin,
+		middleware.RequirePermission(s.rbacModule.Service, models.PermissionBooksWrite))
+
+	// Order management
+	s.orderModule.RegisterAdminRoutes(admin,
+		middleware.RequirePermission(s.rbacModule.Service, models.PermissionOrdersRead),
+		middleware.RequirePermission(s.rbacModule.Service, models.PermissionOrdersWrite))
+
+	// Book price overrides
+	s.pricingModule.RegisterAdminRoutes(admin,
+		middleware.RequirePermission(s.rbacModule.Service, models.PermissionBooksWrite))
 }
@@ -7,44 +7,75 @@ import (
 	"time"
 
 	"github.com/bookshop/api/config"
+	"github.com/bookshop/api/internal/app/auth"
+	"github.com/bookshop/api/internal/app/author"
 	"github.com/bookshop/api/internal/app/book"
+	"github.com/bookshop/api/internal/app/order"
+	"github.com/bookshop/api/internal/app/payment"
+	"github.com/bookshop/api/internal/app/pricing"
+	"github.com/bookshop/api/internal/app/rbac"
 	"github.com/bookshop/api/internal/domain/repositories"
 	"github.com/bookshop/api/internal/domain/services"
 	"github.com/bookshop/api/internal/handlers"
 	customMiddleware "github.com/bookshop/api/internal/middleware"
 	"github.com/bookshop/api/pkg/logger"
+	"github.com/bookshop/api/pkg/validator"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
 
 // Server represents an HTTP server
 type Server struct {
-	echo            *echo.Echo
-	config          *config.Config
-	logger          *logger.Logger
-	Addr            string
-	checkoutService services.CheckoutService
-	checkoutHandler *handlers.CheckoutHandler
-	cartService     services.CartService
-	cartHandler     *handlers.CartHandler
-	bookModule      *book.Module
-	ipRateLimiter   *customMiddleware.IPRateLimiter   // IP-based rate limiter
-	pathRateLimiter *customMiddleware.PathRateLimiter // Path-based rate limiter
+	echo             *echo.Echo
+	config           *config.Config
+	logger           *logger.Logger
+	Addr             string
+	authService      services.AuthService
+	authHandler      *auth.Handler
+	tokenRevocations services.TokenRevocationService
+	rbacModule       *rbac.Module
+	pricingModule    *pricing.Module
+	orderModule      *order.Module
+	paymentModule    *payment.Module
+	checkoutService  services.CheckoutService
+	checkoutHandler  *handlers.CheckoutHandler
+	cartService      services.CartService
+	cartHandler      *handlers.CartHandler
+	categoryService  services.CategoryService
+	categoryHandler  *handlers.CategoryHandler
+	bookModule       *book.Module
+	authorModule     *author.Module
+	ipRateLimiter    *customMiddleware.IPRateLimiter   // IP-based rate limiter
+	pathRateLimiter  *customMiddleware.PathRateLimiter // Path-based rate limiter
 }
 
 // NewServer creates a new instance of HTTP server
 func NewServer(
 	cfg *config.Config,
 	logger *logger.Logger,
+	authService services.AuthService,
+	tokenRevocations services.TokenRevocationService,
+	rbacModule *rbac.Module,
+	pricingModule *pricing.Module,
+	orderModule *order.Module,
+	paymentModule *payment.Module,
+	storage services.BlobStorage,
 	checkoutService services.CheckoutService,
 	cartService services.CartService,
+	categoryService services.CategoryService,
 	bookRepo repositories.BookRepository,
 	categoryRepo repositories.CategoryRepository,
+	authorRepo repositories.AuthorRepository,
 	txManager repositories.TransactionManager,
 ) (*Server, error) {
 	e := echo.New()
 	e.HideBanner = true
 
+	// Request validation with the domain-specific validations
+	v := validator.NewValidator()
+	book.RegisterValidators(v.Engine())
+	e.Validator = v
+
 	// Create rate limiters if enabled in config
 	var ipRateLimiter *customMiddleware.IPRateLimiter
 	var pathRateLimiter *customMiddleware.PathRateLimiter
@@ -86,24 +117,42 @@ func NewServer(
 	e.Server.IdleTimeout = cfg.HTTP.IdleTimeout
 
 	// Handlers initialization
+	authHandler := auth.NewHandler(authService)
 	checkoutHandler := handlers.NewCheckoutHandler(checkoutService)
 	cartHandler := handlers.NewCartHandler(cartService)
+	categoryHandler := handlers.NewCategoryHandler(categoryService)
 
 	// Book module initialization
-	bookModule := book.NewModule(bookRepo, categoryRepo, txManager)
+	bookModule, err := book.NewModule(bookRepo, categoryRepo, authorRepo, txManager, pricingModule.Service, storage, cfg.Catalog)
+	if err != nil {
+		return nil, err
+	}
+
+	// Author module initialization
+	authorModule := author.NewModule(authorRepo)
 
 	server := &Server{
-		echo:            e,
-		config:          cfg,
-		logger:          logger,
-		Addr:            addr,
-		checkoutService: checkoutService,
-		checkoutHandler: checkoutHandler,
-		cartService:     cartService,
-		cartHandler:     cartHandler,
-		bookModule:      bookModule,
-		ipRateLimiter:   ipRateLimiter,   // Save rate limiter for cleanup during shutdown
-		pathRateLimiter: pathRateLimiter, // Save rate limiter for cleanup during shutdown
+		echo:             e,
+		config:           cfg,
+		logger:           logger,
+		Addr:             addr,
+		authService:      authService,
+		authHandler:      authHandler,
+		tokenRevocations: tokenRevocations,
+		rbacModule:       rbacModule,
+		pricingModule:    pricingModule,
+		orderModule:      orderModule,
+		paymentModule:    paymentModule,
+		checkoutService:  checkoutService,
+		checkoutHandler:  checkoutHandler,
+		cartService:      cartService,
+		cartHandler:      cartHandler,
+		categoryService:  categoryService,
+		categoryHandler:  categoryHandler,
+		bookModule:       bookModule,
+		authorModule:     authorModule,
+		ipRateLimiter:    ipRateLimiter,   // Save rate limiter for cleanup during shutdown
+		pathRateLimiter:  pathRateLimiter, // Save rate limiter for cleanup during shutdown
 	}
 
 	// Route registration
@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"time"
 
+	domainerrors "github.com/bookshop/api/internal/domain/errors"
 	"github.com/bookshop/api/internal/domain/models"
 	"github.com/bookshop/api/internal/domain/repositories"
 	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/pkg/money"
 )
 
 // CheckoutService implements services.CheckoutService interface
@@ -31,8 +33,13 @@ func NewCheckoutService(
 	}
 }
 
-// Checkout processes an order from the user's cart
-func (s *CheckoutService) Checkout(ctx context.Context, userID int) (*models.Order, error) {
+// Checkout processes an order from the user's cart.
+// Orders are placed in the base currency only
+func (s *CheckoutService) Checkout(ctx context.Context, userID int, currency string) (*models.Order, error) {
+	if currency != "" && currency != money.DefaultCurrency {
+		return nil, fmt.Errorf("%w: %s", domainerrors.ErrUnsupportedCurrency, currency)
+	}
+
 	// Get user's cart
 	cart, err := s.cartRepository.GetCart(ctx, userID)
 	if err != nil {
@@ -52,10 +59,9 @@ func (s *CheckoutService) Checkout(ctx context.Context, userID int) (*models.Ord
 
 	// Create order
 	order := &models.Order{
-		UserID:     userID,
-		Status:     "created",
-		TotalPrice: 0,
-		Items:      make([]models.OrderItem, 0, len(cart.Items)),
+		UserID: userID,
+		Status: models.OrderStatusPending,
+		Items:  make([]models.OrderItem, 0, len(cart.Items)),
 	}
 
 	// Add items to order
@@ -67,13 +73,14 @@ func (s *CheckoutService) Checkout(ctx context.Context, userID int) (*models.Ord
 		}
 
 		// Create order item
-		orderItem := models.OrderItem{
-			BookID: book.ID,
-			Price:  book.Price,
-		}
+		orderItem := models.NewOrderItem(book, cartItem.Quantity)
 
 		order.Items = append(order.Items, orderItem)
-		order.TotalPrice += book.Price
+		totalPrice, err := order.TotalPrice.Add(orderItem.Subtotal())
+		if err != nil {
+			return nil, fmt.Errorf("error calculating order total: %w", err)
+		}
+		order.TotalPrice = totalPrice
 	}
 
 	// Save order
@@ -96,18 +103,37 @@ func (s *CheckoutService) GetOrdersByUserID(ctx context.Context, userID int) ([]
 		return nil, fmt.Errorf("error getting user orders: %w", err)
 	}
 
-	// Load book information for each order
-	for i := range orders {
-		for j := range orders[i].Items {
-			book, err := s.bookRepository.GetByID(ctx, orders[i].Items[j].BookID)
-			if err != nil {
-				continue
-			}
-			orders[i].Items[j].Book = book
-		}
+	return orders, nil
+}
+
+// ListOrdersByUserID returns a page of user's order history
+func (s *CheckoutService) ListOrdersByUserID(ctx context.Context, filter models.OrderFilter) (*models.OrderListResponse, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = 10
 	}
 
-	return orders, nil
+	orders, pageInfo, err := s.orderRepository.ListByUserID(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user orders: %w", err)
+	}
+
+	response := &models.OrderListResponse{
+		Orders:     orders,
+		TotalCount: pageInfo.TotalCount,
+		PageSize:   filter.PageSize,
+		TotalPages: pageInfo.TotalPages(filter.PageSize),
+	}
+	response.NextCursor, response.PrevCursor = pageInfo.Cursors()
+
+	// Page numbers only make sense in page mode
+	if filter.After == nil && filter.Before == nil {
+		response.Page = filter.Page
+	}
+
+	return response, nil
 }
 
 // GetOrderByID returns an order by ID
@@ -122,19 +148,82 @@ func (s *CheckoutService) GetOrderByID(ctx context.Context, orderID int, userID
 		return nil, errors.New("order does not belong to user")
 	}
 
-	// Load book information for order
-	for i := range order.Items {
-		book, err := s.bookRepository.GetByID(ctx, order.Items[i].BookID)
-		if err != nil {
-			continue
+	return order, nil
+}
+
+// UpdateOrderStatus moves an order to a new status allowed by the order lifecycle
+func (s *CheckoutService) UpdateOrderStatus(ctx context.Context, orderID int, status string, changedBy *int, reason string) error {
+	order, err := s.orderRepository.GetByID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return domainerrors.ErrOrderNotFound
+		}
+		return fmt.Errorf("error getting order: %w", err)
+	}
+
+	if err := models.ValidateOrderTransition(order.Status, status); err != nil {
+		return err
+	}
+
+	change := &models.OrderStatusChange{
+		OrderID:    orderID,
+		FromStatus: order.Status,
+		ToStatus:   status,
+		ChangedBy:  changedBy,
+		Reason:     reason,
+	}
+	if err := s.orderRepository.UpdateStatus(ctx, change); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return fmt.Errorf("%w: the order status has changed, reload the order",
+				domainerrors.ErrInvalidOrderTransition)
 		}
-		order.Items[i].Book = book
+		return fmt.Errorf("error updating order status: %w", err)
+	}
+
+	return nil
+}
+
+// CancelOrder cancels an order of the user that is not paid yet and returns its books to stock.
+// Without a transaction manager the status change and the stock update are not atomic
+func (s *CheckoutService) CancelOrder(ctx context.Context, orderID int, userID int, reason string) (*models.Order, error) {
+	order, err := s.orderRepository.GetByID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, domainerrors.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("error getting order: %w", err)
+	}
+
+	// Check if order belongs to user
+	if order.UserID != userID {
+		return nil, domainerrors.ErrOrderNotFound
+	}
+
+	if err := s.UpdateOrderStatus(ctx, orderID, models.OrderStatusCanceled, &userID, reason); err != nil {
+		return nil, err
+	}
+	order.Status = models.OrderStatusCanceled
+
+	// Return books to stock
+	quantities := make(map[int]int, len(order.Items))
+	for _, item := range order.Items {
+		quantities[item.BookID] += item.Quantity
+	}
+	if err := s.bookRepository.ReleaseBooks(ctx, quantities); err != nil {
+		return nil, fmt.Errorf("error returning books to stock: %w", err)
 	}
 
 	return order, nil
 }
 
-// UpdateOrderStatus updates the status of an order
-func (s *CheckoutService) UpdateOrderStatus(ctx context.Context, orderID int, status string) error {
-	return s.orderRepository.UpdateStatus(ctx, orderID, status)
+// GetOrderStatusHistory returns the status changes of an order, oldest first
+func (s *CheckoutService) GetOrderStatusHistory(ctx context.Context, orderID int) ([]models.OrderStatusChange, error) {
+	if _, err := s.orderRepository.GetByID(ctx, orderID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, domainerrors.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("error getting order: %w", err)
+	}
+
+	return s.orderRepository.GetStatusHistory(ctx, orderID)
 }
@@ -84,12 +84,10 @@ func (p *OrderProcessor) processOrderTask(ctx context.Context, request OrderProc
 	time.Sleep(100 * time.Millisecond)
 
 	// Check book availability and reserve them
-	bookIDs := make([]int, 0, len(request.CartItems))
-	for _, item := range request.CartItems {
-		bookIDs = append(bookIDs, item.BookID)
-	}
+	cart := models.Cart{UserID: request.UserID, Items: request.CartItems}
+	quantities := cart.Quantities()
 
-	if err := p.bookRepo.ReserveBooks(ctx, bookIDs); err != nil {
+	if err := p.bookRepo.ReserveBooks(ctx, quantities); err != nil {
 		p.logger.Error("Error reserving books", "error", err, "orderID", request.Order.ID)
 		return fmt.Errorf("error reserving books: %w", err)
 	}
@@ -97,7 +95,7 @@ func (p *OrderProcessor) processOrderTask(ctx context.Context, request OrderProc
 	// Create order in database
 	if err := p.orderRepo.Create(ctx, request.Order); err != nil {
 		// In case of error, release reserved books
-		p.bookRepo.ReleaseBooks(ctx, bookIDs)
+		p.bookRepo.ReleaseBooks(ctx, quantities)
 		p.logger.Error("Error creating order", "error", err, "orderID", request.Order.ID)
 		return fmt.Errorf("error creating order: %w", err)
 	}
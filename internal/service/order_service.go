@@ -15,15 +15,6 @@ import (
 	"github.com/bookshop/api/pkg/logger"
 )
 
-const (
-	// OrderStatusNew status for new orders
-	OrderStatusNew = "new"
-	// OrderStatusPaid status for paid orders
-	OrderStatusPaid = "paid"
-	// OrderStatusCanceled status for canceled orders
-	OrderStatusCanceled = "canceled"
-)
-
 // OrderService handles business logic related to orders
 type OrderService struct {
 	orderRepo           repositories.OrderRepository
@@ -207,11 +198,10 @@ func (s *OrderService) CreateOrder(ctx context.Context, userID string, input mod
 
 		// Create new order object
 		order = &models.Order{
-			UserID:     userIDInt,
-			Status:     "pending",
-			TotalPrice: 0, // Will calculate from cart items
-			CreatedAt:  time.Now(),
-			Items:      []models.OrderItem{},
+			UserID:    userIDInt,
+			Status:    models.OrderStatusPending,
+			CreatedAt: time.Now(),
+			Items:     []models.OrderItem{},
 		}
 
 		// Calculate total price and populate order items
@@ -223,13 +213,14 @@ func (s *OrderService) CreateOrder(ctx context.Context, userID string, input mod
 			}
 
 			// Add item to order
-			orderItem := models.OrderItem{
-				BookID: item.BookID,
-				Price:  book.Price,
-				Book:   book,
-			}
+			orderItem := models.NewOrderItem(book, item.Quantity)
+			orderItem.Book = book
 			order.Items = append(order.Items, orderItem)
-			order.TotalPrice += book.Price
+			totalPrice, err := order.TotalPrice.Add(orderItem.Subtotal())
+			if err != nil {
+				return fmt.Errorf("error calculating order total: %w", err)
+			}
+			order.TotalPrice = totalPrice
 		}
 
 		return nil
@@ -261,9 +252,8 @@ func (s *OrderService) CreateOrder(ctx context.Context, userID string, input mod
 			return nil, fmt.Errorf("order processing error: %w", err)
 		}
 	case <-time.After(500 * time.Millisecond):
-		// If processing takes longer, return the order with "processing" status
+		// If processing takes longer, the order is returned while still pending
 		s.logger.Info("Order is being processed", "orderID", order.ID, "userID", userID)
-		order.Status = "processing"
 	}
 
 	// Unlock the cart as we locked it within the transaction
@@ -299,9 +289,26 @@ func (s *OrderService) UpdateOrder(ctx context.Context, orderID string, userID s
 			return fmt.Errorf("error getting order: %w", err)
 		}
 
-		// Update order status if provided
+		// Update order status if provided, the lifecycle must allow the change
 		if input.Status != "" {
-			if err := s.orderRepo.UpdateStatus(txCtx, orderIDInt, input.Status); err != nil {
+			if err := models.ValidateOrderTransition(order.Status, input.Status); err != nil {
+				return err
+			}
+
+			change := &models.OrderStatusChange{
+				OrderID:    orderIDInt,
+				FromStatus: order.Status,
+				ToStatus:   input.Status,
+				Reason:     input.Notes,
+			}
+			if changedBy, err := strconv.Atoi(userID); err == nil {
+				change.ChangedBy = &changedBy
+			}
+			if err := s.orderRepo.UpdateStatus(txCtx, change); err != nil {
+				if errors.Is(err, repositories.ErrNotFound) {
+					return fmt.Errorf("%w: the order status has changed, reload the order",
+						domainerrors.ErrInvalidOrderTransition)
+				}
 				return fmt.Errorf("error updating order status: %w", err)
 			}
 			order.Status = input.Status
@@ -539,19 +546,19 @@ func (s *OrderService) Checkout(ctx context.Context, userID int) (*models.Order,
 			return fmt.Errorf("error getting books: %w", err)
 		}
 
-		// Check if all books are in stock
+		// Check if enough copies of every book are in stock
+		quantities := cart.Quantities()
 		for _, book := range books {
-			if book.Stock <= 0 {
+			if book.Stock < quantities[book.ID] {
 				return domainerrors.ErrOutOfStock
 			}
 		}
 
 		// Create order
 		order = &models.Order{
-			UserID:     userID,
-			Status:     OrderStatusNew,
-			TotalPrice: 0,
-			Items:      make([]models.OrderItem, len(cart.Items)),
+			UserID: userID,
+			Status: models.OrderStatusPending,
+			Items:  make([]models.OrderItem, len(cart.Items)),
 		}
 
 		// Calculate total price and create order items
@@ -568,11 +575,12 @@ func (s *OrderService) Checkout(ctx context.Context, userID int) (*models.Order,
 				return domainerrors.ErrBookNotFound
 			}
 
-			order.Items[i] = models.OrderItem{
-				BookID: book.ID,
-				Price:  book.Price,
+			order.Items[i] = models.NewOrderItem(book, item.Quantity)
+			totalPrice, err := order.TotalPrice.Add(order.Items[i].Subtotal())
+			if err != nil {
+				return fmt.Errorf("error calculating order total: %w", err)
 			}
-			order.TotalPrice += book.Price
+			order.TotalPrice = totalPrice
 		}
 
 		// Save order
@@ -580,11 +588,9 @@ func (s *OrderService) Checkout(ctx context.Context, userID int) (*models.Order,
 			return fmt.Errorf("error creating order: %w", err)
 		}
 
-		// Update book stock
-		for _, item := range cart.Items {
-			if err := s.bookRepo.DecrementStock(txCtx, item.BookID, 1); err != nil {
-				return fmt.Errorf("error updating book stock: %w", err)
-			}
+		// Update book stock, books are reserved in a stable order so concurrent checkouts cannot deadlock
+		if err := s.bookRepo.ReserveBooks(txCtx, quantities); err != nil {
+			return fmt.Errorf("error updating book stock: %w", err)
 		}
 
 		// Clear cart
@@ -608,8 +614,9 @@ func (s *OrderService) Checkout(ctx context.Context, userID int) (*models.Order,
 	return order, nil
 }
 
-// UpdateOrderStatus updates order status
-func (s *OrderService) UpdateOrderStatus(ctx context.Context, orderID int, status string) error {
+// UpdateOrderStatus moves an order to a new status allowed by the order lifecycle
+// and records who changed it in the order history
+func (s *OrderService) UpdateOrderStatus(ctx context.Context, orderID int, status string, changedBy *int, reason string) error {
 	var order *models.Order
 
 	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
@@ -618,21 +625,29 @@ func (s *OrderService) UpdateOrderStatus(ctx context.Context, orderID int, statu
 		order, err = s.orderRepo.GetByID(txCtx, orderID)
 		if err != nil {
 			if errors.Is(err, repositories.ErrNotFound) {
-				return fmt.Errorf("order not found")
+				return domainerrors.ErrOrderNotFound
 			}
 			return fmt.Errorf("error getting order: %w", err)
 		}
 
-		// Check status validity
-		switch status {
-		case OrderStatusPaid, OrderStatusCanceled:
-			// Valid status
-		default:
-			return fmt.Errorf("invalid order status")
+		// Check that the lifecycle allows the change
+		if err := models.ValidateOrderTransition(order.Status, status); err != nil {
+			return err
 		}
 
-		// Update status
-		if err := s.orderRepo.UpdateStatus(txCtx, orderID, status); err != nil {
+		// Update status, the order must not have been changed in the meantime
+		change := &models.OrderStatusChange{
+			OrderID:    orderID,
+			FromStatus: order.Status,
+			ToStatus:   status,
+			ChangedBy:  changedBy,
+			Reason:     reason,
+		}
+		if err := s.orderRepo.UpdateStatus(txCtx, change); err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				return fmt.Errorf("%w: the order status has changed, reload the order",
+					domainerrors.ErrInvalidOrderTransition)
+			}
 			return fmt.Errorf("error updating order status: %w", err)
 		}
 
@@ -277,8 +277,11 @@ func (s *ProfileCacheService) convertCacheToModels(profile *cache.Profile) (*mod
 				order.Status = status
 			}
 
-			if price, ok := orderData["total_price"].(float64); ok {
-				order.TotalPrice = price
+			// Money decodes both the amount object and a bare number
+			if price, ok := orderData["total_price"]; ok {
+				if data, err := json.Marshal(price); err == nil {
+					_ = json.Unmarshal(data, &order.TotalPrice)
+				}
 			}
 
 			if createdAtStr, ok := orderData["created_at"].(string); ok {
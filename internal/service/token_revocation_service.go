@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bookshop/api/internal/domain/repositories"
+	"github.com/bookshop/api/internal/domain/services"
+	"github.com/bookshop/api/internal/pkg/cache"
+	"github.com/bookshop/api/pkg/logger"
+)
+
+// revocationCacheItem represents a cached revocation lookup with expiration time
+type revocationCacheItem struct {
+	revoked    bool      // Used for token ID lookups
+	watermark  time.Time // Used for user watermark lookups
+	expiration time.Time
+}
+
+// TokenRevocationService implements services.TokenRevocationService interface.
+// Lookups are served from an in-process LRU cache (L1) in front of Redis (L2).
+// Revocations made by this instance are visible immediately; revocations made by
+// other instances become visible once the cached entry expires
+type TokenRevocationService struct {
+	repo     repositories.TokenRevocationRepository
+	cache    *cache.LRUCache
+	cacheTTL time.Duration
+	logger   logger.Logger
+}
+
+// NewTokenRevocationService creates a new token revocation service
+func NewTokenRevocationService(
+	repo repositories.TokenRevocationRepository,
+	cacheTTL time.Duration,
+	logger logger.Logger,
+) services.TokenRevocationService {
+	return &TokenRevocationService{
+		repo:     repo,
+		cache:    cache.NewLRUCache(10000),
+		cacheTTL: cacheTTL,
+		logger:   logger,
+	}
+}
+
+// RevokeToken revokes a single token until it expires
+func (s *TokenRevocationService) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	if err := s.repo.RevokeToken(ctx, tokenID, expiresAt); err != nil {
+		return fmt.Errorf("error revoking token: %w", err)
+	}
+
+	// A revoked token stays revoked, so it can be cached until it expires
+	s.cache.Put(tokenCacheKey(tokenID), &revocationCacheItem{
+		revoked:    true,
+		expiration: expiresAt,
+	})
+
+	return nil
+}
+
+// RevokeUserTokens revokes all tokens issued to the user up to now
+func (s *TokenRevocationService) RevokeUserTokens(ctx context.Context, userID int) error {
+	// Tokens carry their issue time in microseconds, so tokens issued right after the revocation stay valid
+	watermark := time.Now().Truncate(time.Microsecond)
+
+	if err := s.repo.SetUserRevocationTime(ctx, userID, watermark); err != nil {
+		return fmt.Errorf("error revoking user tokens: %w", err)
+	}
+
+	s.cache.Put(userCacheKey(userID), &revocationCacheItem{
+		watermark:  watermark,
+		expiration: time.Now().Add(s.cacheTTL),
+	})
+
+	s.logger.Info("Revoked all tokens of user", "userID", userID)
+	return nil
+}
+
+// IsRevoked checks if a token was revoked individually or by the user's watermark
+func (s *TokenRevocationService) IsRevoked(ctx context.Context, tokenID string, userID int, issuedAt time.Time) (bool, error) {
+	watermark, err := s.userWatermark(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	// Tokens issued before the watermark are revoked. Tokens that only carry whole seconds
+	// are revoked if they were issued in the same second as the watermark
+	if !watermark.IsZero() && issuedAt.Before(watermark) {
+		return true, nil
+	}
+
+	return s.tokenRevoked(ctx, tokenID)
+}
+
+// tokenRevoked checks the token denylist through the cache
+func (s *TokenRevocationService) tokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	key := tokenCacheKey(tokenID)
+	if item, ok := s.getCached(key); ok {
+		return item.revoked, nil
+	}
+
+	revoked, err := s.repo.IsTokenRevoked(ctx, tokenID)
+	if err != nil {
+		return false, fmt.Errorf("error checking token revocation: %w", err)
+	}
+
+	s.cache.Put(key, &revocationCacheItem{
+		revoked:    revoked,
+		expiration: time.Now().Add(s.cacheTTL),
+	})
+
+	return revoked, nil
+}
+
+// userWatermark returns the user's revocation watermark through the cache
+func (s *TokenRevocationService) userWatermark(ctx context.Context, userID int) (time.Time, error) {
+	key := userCacheKey(userID)
+	if item, ok := s.getCached(key); ok {
+		return item.watermark, nil
+	}
+
+	watermark, err := s.repo.GetUserRevocationTime(ctx, userID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error checking user token revocation: %w", err)
+	}
+
+	s.cache.Put(key, &revocationCacheItem{
+		watermark:  watermark,
+		expiration: time.Now().Add(s.cacheTTL),
+	})
+
+	return watermark, nil
+}
+
+// getCached returns a non-expired cache item
+func (s *TokenRevocationService) getCached(key string) (*revocationCacheItem, bool) {
+	value, found := s.cache.Get(key)
+	if !found {
+		return nil, false
+	}
+
+	item, ok := value.(*revocationCacheItem)
+	if !ok || time.Now().After(item.expiration) {
+		s.cache.Remove(key)
+		return nil, false
+	}
+
+	return item, true
+}
+
+// tokenCacheKey returns the cache key for a token ID lookup
+func tokenCacheKey(tokenID string) string {
+	return "jti:" + tokenID
+}
+
+// userCacheKey returns the cache key for a user watermark lookup
+func userCacheKey(userID int) string {
+	return "user:" + strconv.Itoa(userID)
+}
@@ -0,0 +1,82 @@
+-- Create roles table
+CREATE TABLE IF NOT EXISTS roles (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create permissions table
+CREATE TABLE IF NOT EXISTS permissions (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create role permissions table
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id INT NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    permission_id INT NOT NULL REFERENCES permissions(id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+-- Create user roles table
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role_id INT NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, role_id)
+);
+
+-- Create index for fast role member search
+CREATE INDEX idx_user_roles_role_id ON user_roles(role_id);
+
+-- Seed roles
+INSERT INTO roles (name, description) VALUES
+    ('admin', 'Full access to the shop administration'),
+    ('catalog_editor', 'Manages books and categories'),
+    ('order_manager', 'Views and processes orders'),
+    ('support', 'Views customers and their orders')
+ON CONFLICT (name) DO NOTHING;
+
+-- Seed permissions
+INSERT INTO permissions (name, description) VALUES
+    ('books:write', 'Create, update and delete books'),
+    ('categories:write', 'Create, update and delete categories'),
+    ('orders:read', 'View orders of all customers'),
+    ('orders:write', 'Change orders of all customers'),
+    ('users:read', 'View user accounts'),
+    ('roles:write', 'Assign roles to users')
+ON CONFLICT (name) DO NOTHING;
+
+-- Grant permissions to roles
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON (r.name, p.name) IN (
+    ('admin', 'books:write'),
+    ('admin', 'categories:write'),
+    ('admin', 'orders:read'),
+    ('admin', 'orders:write'),
+    ('admin', 'users:read'),
+    ('admin', 'roles:write'),
+    ('catalog_editor', 'books:write'),
+    ('catalog_editor', 'categories:write'),
+    ('order_manager', 'orders:read'),
+    ('order_manager', 'orders:write'),
+    ('support', 'orders:read'),
+    ('support', 'users:read')
+)
+ON CONFLICT DO NOTHING;
+
+-- Move existing administrators to the admin role
+INSERT INTO user_roles (user_id, role_id)
+SELECT u.id, r.id
+FROM users u
+JOIN roles r ON r.name = 'admin'
+WHERE u.is_admin
+ON CONFLICT DO NOTHING;
+
+-- Roles replace the admin flag
+ALTER TABLE users DROP COLUMN IF EXISTS is_admin;
@@ -0,0 +1,2 @@
+-- Drop quantity from order items
+ALTER TABLE order_items DROP COLUMN IF EXISTS quantity;
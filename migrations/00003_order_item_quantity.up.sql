@@ -0,0 +1,3 @@
+-- Add quantity to order items, existing items were always a single copy
+ALTER TABLE order_items
+    ADD COLUMN IF NOT EXISTS quantity INT NOT NULL DEFAULT 1 CHECK (quantity > 0);
@@ -0,0 +1,7 @@
+-- Drop order currency
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS exchange_rate,
+    DROP COLUMN IF EXISTS currency;
+
+-- Drop book price overrides
+DROP TABLE IF EXISTS book_prices;
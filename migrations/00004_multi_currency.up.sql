@@ -0,0 +1,15 @@
+-- Create per-currency book price overrides, book prices are in the base currency otherwise
+CREATE TABLE IF NOT EXISTS book_prices (
+    book_id INT NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    currency CHAR(3) NOT NULL,
+    price DECIMAL(10, 2) NOT NULL CHECK (price > 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (book_id, currency)
+);
+
+-- Freeze the currency and the exchange rate from the base currency at checkout,
+-- existing orders were placed in the base currency
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD',
+    ADD COLUMN IF NOT EXISTS exchange_rate NUMERIC(20, 10) NOT NULL DEFAULT 1 CHECK (exchange_rate > 0);
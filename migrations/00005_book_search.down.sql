@@ -0,0 +1,5 @@
+-- Drop full-text search
+DROP INDEX IF EXISTS idx_books_search_vector;
+
+ALTER TABLE books
+    DROP COLUMN IF EXISTS search_vector;
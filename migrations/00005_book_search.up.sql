@@ -0,0 +1,11 @@
+-- Full-text search document of a book, titles weigh more than authors.
+-- The simple configuration does not stem or drop stop words, so prefix queries
+-- match what users type, including author names
+ALTER TABLE books
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(author, '')), 'B')
+    ) STORED;
+
+-- Create index for full-text search
+CREATE INDEX IF NOT EXISTS idx_books_search_vector ON books USING GIN (search_vector);
@@ -0,0 +1,3 @@
+-- Index matching the order of keyset-paginated order history,
+-- so that a page after a cursor is read without sorting all orders of the user
+CREATE INDEX IF NOT EXISTS idx_orders_user_id_created_at_id ON orders(user_id, created_at DESC, id DESC);
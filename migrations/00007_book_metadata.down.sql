@@ -0,0 +1,12 @@
+-- Drop book metadata
+DROP INDEX IF EXISTS idx_books_publisher;
+DROP INDEX IF EXISTS idx_books_isbn;
+
+ALTER TABLE books
+    DROP COLUMN IF EXISTS cover_url,
+    DROP COLUMN IF EXISTS description,
+    DROP COLUMN IF EXISTS page_count,
+    DROP COLUMN IF EXISTS format,
+    DROP COLUMN IF EXISTS language,
+    DROP COLUMN IF EXISTS publisher,
+    DROP COLUMN IF EXISTS isbn;
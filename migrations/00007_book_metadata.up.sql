@@ -0,0 +1,16 @@
+-- Book metadata. ISBNs are stored as ISBN-13 digits and are optional,
+-- books without one are NULL so that they don't collide in the unique index
+ALTER TABLE books
+    ADD COLUMN IF NOT EXISTS isbn VARCHAR(13),
+    ADD COLUMN IF NOT EXISTS publisher VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS language VARCHAR(3) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS format VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS page_count INT NOT NULL DEFAULT 0 CHECK (page_count >= 0),
+    ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS cover_url VARCHAR(2048) NOT NULL DEFAULT '';
+
+-- Create index for ISBN lookups
+CREATE UNIQUE INDEX IF NOT EXISTS idx_books_isbn ON books(isbn);
+
+-- Create index for publisher filtering
+CREATE INDEX IF NOT EXISTS idx_books_publisher ON books(lower(publisher));
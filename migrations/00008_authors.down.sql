@@ -0,0 +1,6 @@
+-- Drop authors, books keep their author column
+DROP INDEX IF EXISTS idx_book_authors_author_id;
+DROP TABLE IF EXISTS book_authors;
+
+DROP INDEX IF EXISTS idx_authors_name;
+DROP TABLE IF EXISTS authors;
@@ -0,0 +1,49 @@
+-- Create authors table. Names keep the author's own capitalization
+-- and are unique regardless of case
+CREATE TABLE IF NOT EXISTS authors (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_authors_name ON authors(lower(name));
+
+-- Create book authors table, position is the order the contributors are credited in
+CREATE TABLE IF NOT EXISTS book_authors (
+    book_id INT NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    author_id INT NOT NULL REFERENCES authors(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'author'
+        CHECK (role IN ('author', 'editor', 'translator', 'illustrator')),
+    position INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (book_id, author_id, role)
+);
+
+-- Create index for fast author book search
+CREATE INDEX IF NOT EXISTS idx_book_authors_author_id ON book_authors(author_id);
+
+-- Split the existing author column on semicolons, ampersands and " and ".
+-- Commas are part of names, e.g. "Tolkien, J.R.R." or "Martin Luther King, Jr."
+CREATE TEMPORARY TABLE split_book_authors AS
+SELECT b.id AS book_id,
+       regexp_replace(trim(part.name), '\s+', ' ', 'g') AS name,
+       part.position
+FROM books b,
+     regexp_split_to_table(b.author, '\s*(;|&|\sand\s)\s*') WITH ORDINALITY AS part(name, position)
+WHERE trim(part.name) <> '';
+
+-- The first spelling of a name wins
+INSERT INTO authors (name)
+SELECT DISTINCT ON (lower(name)) name
+FROM split_book_authors
+ORDER BY lower(name), book_id, position
+ON CONFLICT DO NOTHING;
+
+INSERT INTO book_authors (book_id, author_id, role, position)
+SELECT s.book_id, a.id, 'author', MIN(s.position) - 1
+FROM split_book_authors s
+JOIN authors a ON lower(a.name) = lower(s.name)
+GROUP BY s.book_id, a.id
+ON CONFLICT DO NOTHING;
+
+DROP TABLE split_book_authors;
@@ -0,0 +1,6 @@
+-- Flatten categories
+DROP INDEX IF EXISTS idx_categories_parent_id;
+
+ALTER TABLE categories
+    DROP CONSTRAINT IF EXISTS categories_parent_id_check,
+    DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,8 @@
+-- Categories form a tree, root categories have no parent.
+-- Categories with subcategories cannot be deleted
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS parent_id INT REFERENCES categories(id) ON DELETE RESTRICT,
+    ADD CONSTRAINT categories_parent_id_check CHECK (parent_id <> id);
+
+-- Create index for subcategory lookups
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories(parent_id);
@@ -0,0 +1,4 @@
+-- Drop uploaded cover data, cover URLs are kept
+ALTER TABLE books
+    DROP COLUMN IF EXISTS cover_thumbnails,
+    DROP COLUMN IF EXISTS cover_key;
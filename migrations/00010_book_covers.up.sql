@@ -0,0 +1,5 @@
+-- Uploaded covers are stored under cover_key in blob storage with thumbnails
+-- of several sizes, cover_url stays the URL of the full-size cover
+ALTER TABLE books
+    ADD COLUMN IF NOT EXISTS cover_key VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS cover_thumbnails JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,28 @@
+-- Restore the cascades
+ALTER TABLE books
+    DROP CONSTRAINT IF EXISTS books_category_id_fkey,
+    ADD CONSTRAINT books_category_id_fkey FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE;
+
+ALTER TABLE order_items
+    DROP CONSTRAINT IF EXISTS order_items_book_id_fkey,
+    ADD CONSTRAINT order_items_book_id_fkey FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE;
+
+-- Deleted rows are removed for good, deleted books with their order items.
+-- Subcategories of deleted categories are deleted too, so parents are detached first
+DELETE FROM books WHERE deleted_at IS NOT NULL;
+UPDATE categories SET parent_id = NULL WHERE deleted_at IS NOT NULL;
+DELETE FROM categories WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_books_deleted_at;
+
+DROP INDEX IF EXISTS idx_categories_name;
+ALTER TABLE categories ADD CONSTRAINT categories_name_key UNIQUE (name);
+
+DROP INDEX IF EXISTS idx_books_isbn;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_books_isbn ON books(isbn);
+
+ALTER TABLE categories
+    DROP COLUMN IF EXISTS deleted_at;
+
+ALTER TABLE books
+    DROP COLUMN IF EXISTS deleted_at;
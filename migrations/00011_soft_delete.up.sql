@@ -0,0 +1,27 @@
+-- Books and categories are soft deleted, deleted rows keep the time they were deleted at
+ALTER TABLE books
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- ISBNs and category names only need to be unique among rows that are not deleted,
+-- so that a deleted book or category does not block a new one
+DROP INDEX IF EXISTS idx_books_isbn;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_books_isbn ON books(isbn) WHERE deleted_at IS NULL;
+
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS categories_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_name ON categories(name) WHERE deleted_at IS NULL;
+
+-- Create index for listing deleted books
+CREATE INDEX IF NOT EXISTS idx_books_deleted_at ON books(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Order history keeps its books and books keep their categories,
+-- rows still referenced can no longer be removed along with them
+ALTER TABLE order_items
+    DROP CONSTRAINT IF EXISTS order_items_book_id_fkey,
+    ADD CONSTRAINT order_items_book_id_fkey FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE RESTRICT;
+
+ALTER TABLE books
+    DROP CONSTRAINT IF EXISTS books_category_id_fkey,
+    ADD CONSTRAINT books_category_id_fkey FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE RESTRICT;
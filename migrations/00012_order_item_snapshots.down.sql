@@ -0,0 +1,6 @@
+-- Drop book snapshots from order items
+ALTER TABLE order_items
+    DROP COLUMN IF EXISTS category_name,
+    DROP COLUMN IF EXISTS isbn,
+    DROP COLUMN IF EXISTS author,
+    DROP COLUMN IF EXISTS title;
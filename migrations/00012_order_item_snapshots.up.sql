@@ -0,0 +1,17 @@
+-- Order items keep the book data they were sold with, so that order history
+-- does not change when a book is edited or deleted. price is already the unit price
+ALTER TABLE order_items
+    ADD COLUMN IF NOT EXISTS title VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS author VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS isbn VARCHAR(13) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS category_name VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Existing items get the current book data, the best there is
+UPDATE order_items oi
+SET title = b.title,
+    author = b.author,
+    isbn = COALESCE(b.isbn, ''),
+    category_name = COALESCE(c.name, '')
+FROM books b
+LEFT JOIN categories c ON c.id = b.category_id
+WHERE b.id = oi.book_id;
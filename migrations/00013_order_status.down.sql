@@ -0,0 +1,7 @@
+-- Drop order status history table
+DROP TABLE IF EXISTS order_status_history;
+
+-- Statuses are free-form again, mapped statuses are kept
+ALTER TABLE orders
+    DROP CONSTRAINT IF EXISTS orders_status_check,
+    ALTER COLUMN status SET DEFAULT 'completed';
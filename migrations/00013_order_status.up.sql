@@ -0,0 +1,33 @@
+-- Orders follow a single lifecycle, statuses written before it are mapped onto it
+UPDATE orders SET status = CASE status
+    WHEN 'paid' THEN 'paid'
+    WHEN 'canceled' THEN 'canceled'
+    WHEN 'cancelled' THEN 'canceled'
+    WHEN 'completed' THEN 'delivered'
+    ELSE 'pending'
+END;
+
+ALTER TABLE orders
+    ALTER COLUMN status SET DEFAULT 'pending',
+    ADD CONSTRAINT orders_status_check CHECK (status IN (
+        'pending', 'awaiting_payment', 'paid', 'fulfilling',
+        'shipped', 'delivered', 'canceled', 'refunded'
+    ));
+
+-- Create order status history table, changed_by is empty for changes made by the system
+CREATE TABLE IF NOT EXISTS order_status_history (
+    id SERIAL PRIMARY KEY,
+    order_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    from_status VARCHAR(50),
+    to_status VARCHAR(50) NOT NULL,
+    changed_by INT REFERENCES users(id) ON DELETE SET NULL,
+    reason VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create index for reading the history of an order
+CREATE INDEX IF NOT EXISTS idx_order_status_history_order_id ON order_status_history(order_id, created_at);
+
+-- Existing orders start their history with the status they have now
+INSERT INTO order_status_history (order_id, from_status, to_status, created_at)
+SELECT id, NULL, status, created_at FROM orders;
@@ -0,0 +1,2 @@
+-- Drop payments table
+DROP TABLE IF EXISTS payments;
@@ -0,0 +1,27 @@
+-- Create payments table, every attempt to pay an order is a payment.
+-- Amounts are in the order currency
+CREATE TABLE IF NOT EXISTS payments (
+    id SERIAL PRIMARY KEY,
+    order_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    provider_ref VARCHAR(255),
+    status VARCHAR(50) NOT NULL DEFAULT 'pending',
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    failure_reason VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT payments_status_check CHECK (status IN (
+        'pending', 'authorized', 'captured', 'declined', 'failed', 'voided', 'refunded'
+    ))
+);
+
+-- Create index for reading the payments of an order
+CREATE INDEX IF NOT EXISTS idx_payments_order_id ON payments(order_id, created_at);
+
+-- Webhooks name payments by the reference of their provider
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_provider_ref ON payments(provider, provider_ref);
+
+-- An order is paid by one payment at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_active_order ON payments(order_id)
+    WHERE status IN ('pending', 'authorized', 'captured');
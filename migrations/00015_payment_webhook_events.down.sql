@@ -0,0 +1,2 @@
+-- Drop payment webhook events table
+DROP TABLE IF EXISTS payment_webhook_events;
@@ -0,0 +1,26 @@
+-- Create payment webhook events table, every verified event is stored as it was received.
+-- Providers redeliver events, deliveries of an event share its row
+CREATE TABLE IF NOT EXISTS payment_webhook_events (
+    id SERIAL PRIMARY KEY,
+    provider VARCHAR(50) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    provider_ref VARCHAR(255) NOT NULL,
+    payload TEXT NOT NULL,
+    signature VARCHAR(255) NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'received',
+    error VARCHAR(1000) NOT NULL DEFAULT '',
+    deliveries INT NOT NULL DEFAULT 1,
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT payment_webhook_events_status_check CHECK (status IN (
+        'received', 'processing', 'processed', 'ignored', 'failed'
+    ))
+);
+
+-- Events are deduplicated by the ID their provider gave them
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payment_webhook_events_event_id ON payment_webhook_events(provider, event_id);
+
+-- Create index for reading the events of a payment
+CREATE INDEX IF NOT EXISTS idx_payment_webhook_events_provider_ref ON payment_webhook_events(provider, provider_ref);
@@ -0,0 +1,15 @@
+-- Money columns keep two decimal places again, amounts with three minor digits are rounded
+ALTER TABLE payments
+    ALTER COLUMN amount TYPE DECIMAL(10, 2);
+
+ALTER TABLE order_items
+    ALTER COLUMN price TYPE DECIMAL(10, 2);
+
+ALTER TABLE orders
+    ALTER COLUMN total_price TYPE DECIMAL(10, 2);
+
+ALTER TABLE book_prices
+    ALTER COLUMN price TYPE DECIMAL(10, 2);
+
+ALTER TABLE books
+    ALTER COLUMN price TYPE DECIMAL(10, 2);
@@ -0,0 +1,16 @@
+-- Amounts in currencies with three minor digits, e.g. BHD and KWD, do not fit two decimal places.
+-- Money columns keep four decimal places, every supported currency fits them
+ALTER TABLE books
+    ALTER COLUMN price TYPE NUMERIC(19, 4);
+
+ALTER TABLE book_prices
+    ALTER COLUMN price TYPE NUMERIC(19, 4);
+
+ALTER TABLE orders
+    ALTER COLUMN total_price TYPE NUMERIC(19, 4);
+
+ALTER TABLE order_items
+    ALTER COLUMN price TYPE NUMERIC(19, 4);
+
+ALTER TABLE payments
+    ALTER COLUMN amount TYPE NUMERIC(19, 4);
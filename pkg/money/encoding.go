@@ -0,0 +1,131 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// jsonMoney is the decoded JSON representation of an amount.
+// The amount may be a number or a decimal string in major units
+type jsonMoney struct {
+	Amount   json.Number `json:"amount"`
+	Currency string      `json:"currency"`
+}
+
+// MarshalJSON encodes the amount as {"amount": "12.34", "currency": "USD"}.
+// The amount is a string so that no precision is lost in clients
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}{
+		Amount:   m.Decimal(),
+		Currency: m.currencyOrDefault(),
+	})
+}
+
+// UnmarshalJSON decodes an amount object, a bare number or a decimal string.
+// Bare numbers and strings are in DefaultCurrency
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '{' {
+		var v jsonMoney
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&v); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidAmount, err)
+		}
+		parsed, err := Parse(v.Amount.String(), v.Currency)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	}
+
+	var amount json.Number
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&amount); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAmount, err)
+	}
+	parsed, err := Parse(amount.String(), DefaultCurrency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// UnmarshalParam decodes a query or form parameter, e.g. "12.34" or "12.34 EUR"
+func (m *Money) UnmarshalParam(param string) error {
+	amount, currency, _ := strings.Cut(strings.TrimSpace(param), " ")
+	parsed, err := Parse(amount, strings.TrimSpace(currency))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// ScanNumeric implements pgtype.NumericScanner.
+// NUMERIC columns carry no currency, so the currency already set on m is kept
+// and DefaultCurrency is used when there is none
+func (m *Money) ScanNumeric(v pgtype.Numeric) error {
+	parsed, err := FromNumeric(v, m.currencyOrDefault())
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// FromNumeric converts a NUMERIC value to an amount in the currency
+func FromNumeric(v pgtype.Numeric, currency string) (Money, error) {
+	if !v.Valid {
+		return Money{}, fmt.Errorf("%w: cannot scan NULL", ErrInvalidAmount)
+	}
+	if v.NaN || v.InfinityModifier != pgtype.Finite {
+		return Money{}, fmt.Errorf("%w: cannot scan non-finite numeric", ErrInvalidAmount)
+	}
+
+	exp := int32(Exponent(currency))
+
+	// Shift the value to minor units: value = Int * 10^Exp
+	amount := new(big.Int).Set(v.Int)
+	shift := v.Exp + exp
+	ten := big.NewInt(10)
+	if shift > 0 {
+		amount.Mul(amount, new(big.Int).Exp(ten, big.NewInt(int64(shift)), nil))
+	} else if shift < 0 {
+		var remainder big.Int
+		amount.QuoRem(amount, new(big.Int).Exp(ten, big.NewInt(int64(-shift)), nil), &remainder)
+		if remainder.Sign() != 0 {
+			return Money{}, fmt.Errorf("%w: numeric has more than %d decimal places", ErrInvalidAmount, exp)
+		}
+	}
+
+	if !amount.IsInt64() {
+		return Money{}, fmt.Errorf("%w: numeric is out of range", ErrInvalidAmount)
+	}
+
+	return Money{Amount: amount.Int64(), Currency: currency}, nil
+}
+
+// NumericValue implements pgtype.NumericValuer
+func (m Money) NumericValue() (pgtype.Numeric, error) {
+	return pgtype.Numeric{
+		Int:   big.NewInt(m.Amount),
+		Exp:   -int32(Exponent(m.currencyOrDefault())),
+		Valid: true,
+	}, nil
+}
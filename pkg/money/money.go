@@ -0,0 +1,283 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DefaultCurrency is the currency used when an amount comes without one,
+// e.g. a bare number in a request or a NUMERIC column
+const DefaultCurrency = "USD"
+
+var (
+	// ErrCurrencyMismatch is returned when amounts in different currencies are combined
+	ErrCurrencyMismatch = errors.New("money: currency mismatch")
+	// ErrInvalidAmount is returned when an amount cannot be parsed
+	ErrInvalidAmount = errors.New("money: invalid amount")
+	// ErrInvalidCurrency is returned for a currency code that is not three letters
+	ErrInvalidCurrency = errors.New("money: invalid currency")
+)
+
+// currencyExponents holds the number of minor unit digits for currencies that don't use two
+var currencyExponents = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+	"VND": 0,
+	"BHD": 3,
+	"KWD": 3,
+	"OMR": 3,
+}
+
+// Money is an amount in minor units (e.g. cents) of an ISO 4217 currency.
+// The zero value has no currency and can be added to an amount in any currency,
+// which makes it usable as an accumulator
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// New creates an amount from minor units
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(currency)}
+}
+
+// Zero returns a zero amount in the currency
+func Zero(currency string) Money {
+	return New(0, currency)
+}
+
+// Parse parses a decimal amount in major units, e.g. "12.34", in the currency
+func Parse(s string, currency string) (Money, error) {
+	currency = strings.ToUpper(currency)
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	if !ValidCurrency(currency) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidCurrency, currency)
+	}
+
+	amount, err := parseMinor(strings.TrimSpace(s), Exponent(currency))
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// ValidCurrency checks that the code looks like an ISO 4217 currency code
+func ValidCurrency(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+	for _, r := range currency {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Exponent returns the number of minor unit digits of the currency
+func Exponent(currency string) int {
+	if exp, ok := currencyExponents[currency]; ok {
+		return exp
+	}
+	return 2
+}
+
+// currencyOrDefault returns the currency of m or DefaultCurrency if it has none
+func (m Money) currencyOrDefault() string {
+	if m.Currency == "" {
+		return DefaultCurrency
+	}
+	return m.Currency
+}
+
+// IsZero checks if the amount is zero
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsPositive checks if the amount is greater than zero
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// IsNegative checks if the amount is less than zero
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// sameCurrency returns the currency shared by both amounts.
+// An amount without currency takes the currency of the other one
+func sameCurrency(a, b Money) (string, error) {
+	switch {
+	case a.Currency == b.Currency:
+		return a.Currency, nil
+	case a.Currency == "":
+		return b.Currency, nil
+	case b.Currency == "":
+		return a.Currency, nil
+	default:
+		return "", fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, a.Currency, b.Currency)
+	}
+}
+
+// Add returns the sum of two amounts in the same currency
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := sameCurrency(m, other)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: currency}, nil
+}
+
+// Sub returns the difference of two amounts in the same currency
+func (m Money) Sub(other Money) (Money, error) {
+	currency, err := sameCurrency(m, other)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount - other.Amount, Currency: currency}, nil
+}
+
+// Mul returns the amount multiplied by a quantity
+func (m Money) Mul(quantity int) Money {
+	return Money{Amount: m.Amount * int64(quantity), Currency: m.Currency}
+}
+
+// Cmp compares two amounts in the same currency and returns -1, 0 or +1
+func (m Money) Cmp(other Money) (int, error) {
+	if _, err := sameCurrency(m, other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.Amount < other.Amount:
+		return -1, nil
+	case m.Amount > other.Amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Percent returns the given share of the amount in basis points (1/100 of a percent),
+// rounded half away from zero to whole minor units
+func (m Money) Percent(basisPoints int64) Money {
+	return Money{Amount: divRound(m.Amount*basisPoints, 10000), Currency: m.Currency}
+}
+
+// Discount returns the amount reduced by the rate in basis points, e.g. 1500 for 15%
+func (m Money) Discount(basisPoints int64) Money {
+	return Money{Amount: m.Amount - m.Percent(basisPoints).Amount, Currency: m.Currency}
+}
+
+// Tax returns the tax on the amount at the rate in basis points, e.g. 2000 for 20%
+func (m Money) Tax(basisPoints int64) Money {
+	return m.Percent(basisPoints)
+}
+
+// WithTax returns the amount with tax at the rate in basis points added
+func (m Money) WithTax(basisPoints int64) Money {
+	return Money{Amount: m.Amount + m.Tax(basisPoints).Amount, Currency: m.Currency}
+}
+
+// Sum returns the sum of amounts in the same currency.
+// The sum of no amounts is a zero amount without currency
+func Sum(amounts ...Money) (Money, error) {
+	var total Money
+	for _, amount := range amounts {
+		var err error
+		if total, err = total.Add(amount); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// Decimal returns the amount in major units, e.g. "12.34"
+func (m Money) Decimal() string {
+	return formatMinor(m.Amount, Exponent(m.currencyOrDefault()))
+}
+
+// String returns the amount with its currency, e.g. "12.34 USD"
+func (m Money) String() string {
+	return m.Decimal() + " " + m.currencyOrDefault()
+}
+
+// divRound divides rounding half away from zero
+func divRound(n, d int64) int64 {
+	q, r := n/d, n%d
+	if r < 0 {
+		r = -r
+	}
+	if 2*r >= d {
+		if n < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+	return q
+}
+
+// parseMinor parses a decimal string into minor units with exp fraction digits
+func parseMinor(s string, exp int) (int64, error) {
+	if s == "" {
+		return 0, fmt.Errorf("%w: empty", ErrInvalidAmount)
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(s, ".")
+	if whole == "" && fraction == "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+
+	// Trailing zeros beyond the currency precision are harmless
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > exp {
+		return 0, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidAmount, s, exp)
+	}
+	fraction += strings.Repeat("0", exp-len(fraction))
+
+	var amount int64
+	for _, r := range whole + fraction {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+		}
+		if amount > (1<<63-1-int64(r-'0'))/10 {
+			return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidAmount, s)
+		}
+		amount = amount*10 + int64(r-'0')
+	}
+
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+// formatMinor formats minor units as a decimal string with exp fraction digits
+func formatMinor(amount int64, exp int) string {
+	sign := ""
+	abs := uint64(amount)
+	if amount < 0 {
+		sign = "-"
+		abs = uint64(-amount)
+	}
+
+	digits := fmt.Sprintf("%0*d", exp+1, abs)
+	if exp == 0 {
+		return sign + digits
+	}
+	return sign + digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+}
@@ -0,0 +1,412 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		currency string
+		want     Money
+		wantErr  error
+	}{
+		{name: "whole and fraction", input: "12.34", currency: "USD", want: New(1234, "USD")},
+		{name: "negative", input: "-12.34", currency: "USD", want: New(-1234, "USD")},
+		{name: "negative below one", input: "-0.05", currency: "USD", want: New(-5, "USD")},
+		{name: "explicit plus", input: "+1", currency: "USD", want: New(100, "USD")},
+		{name: "short fraction", input: "12.3", currency: "USD", want: New(1230, "USD")},
+		{name: "no whole part", input: ".5", currency: "USD", want: New(50, "USD")},
+		{name: "no fraction digits", input: "5.", currency: "USD", want: New(500, "USD")},
+		{name: "surrounding spaces", input: " 7.10 ", currency: "USD", want: New(710, "USD")},
+		{name: "trailing zeros beyond precision", input: "12.3400", currency: "USD", want: New(1234, "USD")},
+		{name: "lowercase currency", input: "1", currency: "eur", want: New(100, "EUR")},
+		{name: "default currency", input: "1", currency: "", want: New(100, DefaultCurrency)},
+		{name: "zero exponent currency", input: "150", currency: "JPY", want: New(150, "JPY")},
+		{name: "three digit currency", input: "1.234", currency: "KWD", want: New(1234, "KWD")},
+		{name: "more digits than minor units", input: "12.345", currency: "USD", wantErr: ErrInvalidAmount},
+		{name: "negative with more digits than minor units", input: "-0.001", currency: "USD", wantErr: ErrInvalidAmount},
+		{name: "fraction of zero exponent currency", input: "1.5", currency: "JPY", wantErr: ErrInvalidAmount},
+		{name: "empty", input: "", currency: "USD", wantErr: ErrInvalidAmount},
+		{name: "sign only", input: "-", currency: "USD", wantErr: ErrInvalidAmount},
+		{name: "dot only", input: ".", currency: "USD", wantErr: ErrInvalidAmount},
+		{name: "letters", input: "12a", currency: "USD", wantErr: ErrInvalidAmount},
+		{name: "exponent notation", input: "1e3", currency: "USD", wantErr: ErrInvalidAmount},
+		{name: "double sign", input: "--1", currency: "USD", wantErr: ErrInvalidAmount},
+		{name: "out of range", input: "92233720368547758.08", currency: "USD", wantErr: ErrInvalidAmount},
+		{name: "invalid currency", input: "1", currency: "US", wantErr: ErrInvalidCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input, tt.currency)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Parse(%q, %q) error = %v, want %v", tt.input, tt.currency, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q, %q) error = %v", tt.input, tt.currency, err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q, %q) = %v, want %v", tt.input, tt.currency, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{money: New(1234, "USD"), want: "12.34 USD"},
+		{money: New(5, "USD"), want: "0.05 USD"},
+		{money: New(-5, "USD"), want: "-0.05 USD"},
+		{money: New(-1234, "USD"), want: "-12.34 USD"},
+		{money: New(150, "JPY"), want: "150 JPY"},
+		{money: New(1234, "KWD"), want: "1.234 KWD"},
+		{money: Money{Amount: 100}, want: "1.00 USD"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.money.String(); got != tt.want {
+			t.Errorf("%#v.String() = %q, want %q", tt.money, got, tt.want)
+		}
+	}
+}
+
+func TestRounding(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Money
+		want Money
+	}{
+		{name: "percent rounds half up", got: New(5, "USD").Percent(5000), want: New(3, "USD")},
+		{name: "percent rounds negative half away from zero", got: New(-5, "USD").Percent(5000), want: New(-3, "USD")},
+		{name: "percent rounds down below half", got: New(1999, "USD").Percent(1), want: New(0, "USD")},
+		{name: "discount", got: New(1999, "USD").Discount(1500), want: New(1699, "USD")},
+		{name: "discount of negative amount", got: New(-1999, "USD").Discount(1500), want: New(-1699, "USD")},
+		{name: "tax", got: New(1999, "USD").Tax(2000), want: New(400, "USD")},
+		{name: "with tax", got: New(1999, "USD").WithTax(2000), want: New(2399, "USD")},
+		{name: "multiply", got: New(-250, "USD").Mul(3), want: New(-750, "USD")},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	usdEUR, err := ParseRate("USD", "EUR", "0.9215")
+	if err != nil {
+		t.Fatalf("ParseRate error = %v", err)
+	}
+	usdJPY, err := ParseRate("USD", "JPY", "150.5")
+	if err != nil {
+		t.Fatalf("ParseRate error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		amount  Money
+		rate    Rate
+		want    Money
+		wantErr error
+	}{
+		{name: "rounds half away from zero", amount: New(1000, "USD"), rate: usdEUR, want: New(922, "EUR")},
+		{name: "negative", amount: New(-1000, "USD"), rate: usdEUR, want: New(-922, "EUR")},
+		{name: "to zero exponent currency", amount: New(1234, "USD"), rate: usdJPY, want: New(1857, "JPY")},
+		{name: "from zero exponent currency", amount: New(1857, "JPY"), rate: usdJPY.Inverse(), want: New(1234, "USD")},
+		{name: "identity", amount: New(1234, "EUR"), rate: IdentityRate("EUR"), want: New(1234, "EUR")},
+		{name: "amount without currency", amount: Money{Amount: 1000}, rate: usdEUR, want: New(922, "EUR")},
+		{name: "currency mismatch", amount: New(1000, "GBP"), rate: usdEUR, wantErr: ErrCurrencyMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.amount.Convert(tt.rate)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Convert error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Convert error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Convert = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArithmeticCurrencies(t *testing.T) {
+	usd, eur := New(150, "USD"), New(100, "EUR")
+
+	tests := []struct {
+		name    string
+		op      func() (Money, error)
+		want    Money
+		wantErr error
+	}{
+		{name: "add same currency", op: func() (Money, error) { return usd.Add(New(50, "USD")) }, want: New(200, "USD")},
+		{name: "add currency mismatch", op: func() (Money, error) { return usd.Add(eur) }, wantErr: ErrCurrencyMismatch},
+		{name: "sub currency mismatch", op: func() (Money, error) { return eur.Sub(usd) }, wantErr: ErrCurrencyMismatch},
+		{name: "zero value takes the other currency", op: func() (Money, error) { return Money{}.Add(eur) }, want: eur},
+		{name: "sub below zero", op: func() (Money, error) { return eur.Sub(New(250, "EUR")) }, want: New(-150, "EUR")},
+		{name: "sum", op: func() (Money, error) { return Sum(eur, eur, New(1, "EUR")) }, want: New(201, "EUR")},
+		{name: "sum of nothing", op: func() (Money, error) { return Sum() }, want: Money{}},
+		{name: "sum currency mismatch", op: func() (Money, error) { return Sum(eur, usd) }, wantErr: ErrCurrencyMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.op()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := usd.Cmp(eur); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Cmp error = %v, want %v", err, ErrCurrencyMismatch)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Money
+		wantErr error
+	}{
+		{name: "bare number", input: `12.34`, want: New(1234, DefaultCurrency)},
+		{name: "bare negative number", input: `-0.5`, want: New(-50, DefaultCurrency)},
+		{name: "decimal string", input: `"12.34"`, want: New(1234, DefaultCurrency)},
+		{name: "object with string amount", input: `{"amount": "10", "currency": "EUR"}`, want: New(1000, "EUR")},
+		{name: "object with number amount", input: `{"amount": 10.5, "currency": "eur"}`, want: New(1050, "EUR")},
+		{name: "object without currency", input: `{"amount": "1.25"}`, want: New(125, DefaultCurrency)},
+		{name: "object with zero exponent currency", input: `{"amount": "150", "currency": "JPY"}`, want: New(150, "JPY")},
+		{name: "bare number with more digits than minor units", input: `12.345`, wantErr: ErrInvalidAmount},
+		{name: "object with more digits than minor units", input: `{"amount": "1.5", "currency": "JPY"}`, wantErr: ErrInvalidAmount},
+		{name: "object with invalid currency", input: `{"amount": "1", "currency": "EURO"}`, wantErr: ErrInvalidCurrency},
+		{name: "exponent number", input: `1e3`, wantErr: ErrInvalidAmount},
+		{name: "boolean", input: `true`, wantErr: ErrInvalidAmount},
+		{name: "object with boolean amount", input: `{"amount": true}`, wantErr: ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Money
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Unmarshal(%s) error = %v, want %v", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unmarshal(%s) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("Unmarshal(%s) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("null keeps the value", func(t *testing.T) {
+		got := New(100, "EUR")
+		if err := json.Unmarshal([]byte(`null`), &got); err != nil {
+			t.Fatalf("Unmarshal(null) error = %v", err)
+		}
+		if got != New(100, "EUR") {
+			t.Errorf("Unmarshal(null) = %v, want 1.00 EUR", got)
+		}
+	})
+}
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{money: New(1234, "USD"), want: `{"amount":"12.34","currency":"USD"}`},
+		{money: New(-5, "EUR"), want: `{"amount":"-0.05","currency":"EUR"}`},
+		{money: New(150, "JPY"), want: `{"amount":"150","currency":"JPY"}`},
+		{money: Money{}, want: `{"amount":"0.00","currency":"USD"}`},
+	}
+
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.money)
+		if err != nil {
+			t.Fatalf("Marshal(%v) error = %v", tt.money, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("Marshal(%v) = %s, want %s", tt.money, got, tt.want)
+		}
+
+		var decoded Money
+		if err := json.Unmarshal(got, &decoded); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", got, err)
+		}
+		if decoded.Amount != tt.money.Amount || decoded.Currency != tt.money.currencyOrDefault() {
+			t.Errorf("round trip of %v = %v", tt.money, decoded)
+		}
+	}
+}
+
+func TestUnmarshalParam(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Money
+		wantErr error
+	}{
+		{input: "12.34", want: New(1234, DefaultCurrency)},
+		{input: "12.50 EUR", want: New(1250, "EUR")},
+		{input: " -3 gbp ", want: New(-300, "GBP")},
+		{input: "12.345 EUR", wantErr: ErrInvalidAmount},
+		{input: "12 EURO", wantErr: ErrInvalidCurrency},
+	}
+
+	for _, tt := range tests {
+		var got Money
+		err := got.UnmarshalParam(tt.input)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("UnmarshalParam(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("UnmarshalParam(%q) error = %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("UnmarshalParam(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestFromNumeric(t *testing.T) {
+	tests := []struct {
+		name     string
+		numeric  pgtype.Numeric
+		currency string
+		want     Money
+		wantErr  error
+	}{
+		{name: "same scale", numeric: numeric(1234, -2), currency: "USD", want: New(1234, "USD")},
+		{name: "negative", numeric: numeric(-1234, -2), currency: "USD", want: New(-1234, "USD")},
+		{name: "wider scale with zeros", numeric: numeric(123400, -4), currency: "USD", want: New(1234, "USD")},
+		{name: "positive exponent", numeric: numeric(5, 1), currency: "USD", want: New(5000, "USD")},
+		{name: "zero exponent currency", numeric: numeric(150, 0), currency: "JPY", want: New(150, "JPY")},
+		{name: "more digits than minor units", numeric: numeric(12345, -3), currency: "USD", wantErr: ErrInvalidAmount},
+		{name: "negative with more digits than minor units", numeric: numeric(-12345, -3), currency: "USD", wantErr: ErrInvalidAmount},
+		{name: "out of range", numeric: numeric(1, 20), currency: "USD", wantErr: ErrInvalidAmount},
+		{name: "null", numeric: pgtype.Numeric{}, currency: "USD", wantErr: ErrInvalidAmount},
+		{name: "not a number", numeric: pgtype.Numeric{NaN: true, Valid: true}, currency: "USD", wantErr: ErrInvalidAmount},
+		{name: "infinity", numeric: pgtype.Numeric{InfinityModifier: pgtype.Infinity, Valid: true}, currency: "USD", wantErr: ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromNumeric(tt.numeric, tt.currency)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("FromNumeric error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FromNumeric error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FromNumeric = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumericRoundTrip(t *testing.T) {
+	m := pgtype.NewMap()
+
+	tests := []Money{
+		New(1234, "USD"),
+		New(-1234, "USD"),
+		New(0, "USD"),
+		New(5, "USD"),
+		New(1<<62, "USD"),
+		New(150, "JPY"),
+		New(-1234, "KWD"),
+	}
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		for _, want := range tests {
+			buf, err := m.Encode(pgtype.NumericOID, format, want, nil)
+			if err != nil {
+				t.Fatalf("Encode(%v, format %d) error = %v", want, format, err)
+			}
+
+			// NUMERIC columns carry no currency, the scanned amount keeps the one it is scanned into
+			got := Zero(want.Currency)
+			if err := m.Scan(pgtype.NumericOID, format, buf, &got); err != nil {
+				t.Fatalf("Scan(%v, format %d) error = %v", want, format, err)
+			}
+			if got != want {
+				t.Errorf("round trip in format %d = %v, want %v", format, got, want)
+			}
+		}
+	}
+
+	t.Run("scan without currency uses the default", func(t *testing.T) {
+		buf, err := m.Encode(pgtype.NumericOID, pgtype.TextFormatCode, "12.30", nil)
+		if err != nil {
+			t.Fatalf("Encode error = %v", err)
+		}
+
+		var got Money
+		if err := m.Scan(pgtype.NumericOID, pgtype.TextFormatCode, buf, &got); err != nil {
+			t.Fatalf("Scan error = %v", err)
+		}
+		if got != New(1230, DefaultCurrency) {
+			t.Errorf("Scan = %v, want 12.30 %s", got, DefaultCurrency)
+		}
+	})
+
+	t.Run("scan rejects more digits than minor units", func(t *testing.T) {
+		buf, err := m.Encode(pgtype.NumericOID, pgtype.TextFormatCode, "12.345", nil)
+		if err != nil {
+			t.Fatalf("Encode error = %v", err)
+		}
+
+		var got Money
+		if err := m.Scan(pgtype.NumericOID, pgtype.TextFormatCode, buf, &got); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("Scan error = %v, want %v", err, ErrInvalidAmount)
+		}
+	})
+}
+
+// numeric returns the NUMERIC value digits * 10^exp
+func numeric(digits int64, exp int32) pgtype.Numeric {
+	return pgtype.Numeric{Int: big.NewInt(digits), Exp: exp, Valid: true}
+}
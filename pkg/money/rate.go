@@ -0,0 +1,129 @@
+package money
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// rateDecimalPlaces is the precision used when a rate has no exact decimal representation
+const rateDecimalPlaces = 10
+
+// Rate is an exchange rate: one unit of From is worth Value units of To
+type Rate struct {
+	From  string
+	To    string
+	Value *big.Rat
+}
+
+// NewRate creates an exchange rate
+func NewRate(from, to string, value *big.Rat) Rate {
+	return Rate{
+		From:  strings.ToUpper(from),
+		To:    strings.ToUpper(to),
+		Value: new(big.Rat).Set(value),
+	}
+}
+
+// IdentityRate returns the rate of a currency to itself
+func IdentityRate(currency string) Rate {
+	return NewRate(currency, currency, big.NewRat(1, 1))
+}
+
+// ParseRate parses a decimal exchange rate, e.g. "0.9215"
+func ParseRate(from, to, value string) (Rate, error) {
+	rat, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok || rat.Sign() <= 0 {
+		return Rate{}, fmt.Errorf("%w: invalid exchange rate %q", ErrInvalidAmount, value)
+	}
+	return NewRate(from, to, rat), nil
+}
+
+// Inverse returns the rate in the opposite direction
+func (r Rate) Inverse() Rate {
+	return Rate{From: r.To, To: r.From, Value: new(big.Rat).Inv(r.Value)}
+}
+
+// Decimal returns the rate as a decimal string, e.g. "0.9215"
+func (r Rate) Decimal() string {
+	if r.Value == nil {
+		return "0"
+	}
+	s := r.Value.FloatString(rateDecimalPlaces)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// String returns the rate with its currencies, e.g. "USD/EUR 0.9215"
+func (r Rate) String() string {
+	return r.From + "/" + r.To + " " + r.Decimal()
+}
+
+// MarshalJSON encodes the rate as {"from": "USD", "to": "EUR", "rate": "0.9215"}
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+		Rate string `json:"rate"`
+	}{
+		From: r.From,
+		To:   r.To,
+		Rate: r.Decimal(),
+	})
+}
+
+// Convert converts the amount with the rate, rounding half away from zero to whole minor units
+func (m Money) Convert(rate Rate) (Money, error) {
+	if m.currencyOrDefault() != rate.From {
+		return Money{}, fmt.Errorf("%w: cannot convert %s with %s rate", ErrCurrencyMismatch, m.currencyOrDefault(), rate.From)
+	}
+	if rate.From == rate.To {
+		return Money{Amount: m.Amount, Currency: rate.To}, nil
+	}
+
+	// amount in target minor units = amount * rate * 10^(target exponent - source exponent)
+	value := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Amount), rate.Value)
+	shift := Exponent(rate.To) - Exponent(rate.From)
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(shift))), nil))
+	if shift >= 0 {
+		value.Mul(value, scale)
+	} else {
+		value.Quo(value, scale)
+	}
+
+	amount, err := roundRat(value)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: amount, Currency: rate.To}, nil
+}
+
+// roundRat rounds a rational number half away from zero to an int64
+func roundRat(value *big.Rat) (int64, error) {
+	num := new(big.Int).Abs(value.Num())
+	den := value.Denom()
+
+	quotient, remainder := new(big.Int).QuoRem(num, den, new(big.Int))
+	if new(big.Int).Mul(remainder, big.NewInt(2)).Cmp(den) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	if value.Sign() < 0 {
+		quotient.Neg(quotient)
+	}
+
+	if !quotient.IsInt64() {
+		return 0, fmt.Errorf("%w: converted amount is out of range", ErrInvalidAmount)
+	}
+	return quotient.Int64(), nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/bookshop/api/pkg/money"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -26,9 +27,22 @@ func NewValidator() *Validator {
 		return name
 	})
 
+	// Validate money by its amount so that tags like gt=0 work on prices
+	validate.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		if m, ok := field.Interface().(money.Money); ok {
+			return m.Amount
+		}
+		return nil
+	}, money.Money{})
+
 	return &Validator{validate: validate}
 }
 
+// Engine returns the underlying validator, e.g. to register custom validations
+func (v *Validator) Engine() *validator.Validate {
+	return v.validate
+}
+
 // Validate validates a struct according to validation rules
 func (v *Validator) Validate(i interface{}) error {
 	if err := v.validate.Struct(i); err != nil {
@@ -87,6 +101,10 @@ func getErrorMessage(field, tag string, value interface{}) string {
 		return fmt.Sprintf("%s must contain only letters", field)
 	case "alphanum":
 		return fmt.Sprintf("%s must contain only letters and numbers", field)
+	case "isbn":
+		return fmt.Sprintf("%s must be a valid ISBN-10 or ISBN-13", field)
+	case "url":
+		return fmt.Sprintf("%s must be a valid URL", field)
 	default:
 		return fmt.Sprintf("%s is invalid", field)
 	}